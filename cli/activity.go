@@ -44,7 +44,7 @@ func newActivityCommands() []*cli.Command {
 				},
 				&cli.StringFlag{
 					Name:  FlagRunIDWithAlias,
-					Usage: "RunId",
+					Usage: "RunId, the current run by default",
 				},
 				&cli.StringFlag{
 					Name:  FlagActivityIDWithAlias,
@@ -52,11 +52,11 @@ func newActivityCommands() []*cli.Command {
 				},
 				&cli.StringFlag{
 					Name:  FlagResult,
-					Usage: "Result of the activity",
+					Usage: "Result of the activity in JSON, - to read it from stdin. A value that is not JSON is passed as a string",
 				},
 				&cli.StringFlag{
 					Name:  FlagIdentity,
-					Usage: "Identity of the operator",
+					Usage: "Identity of the operator, tctl@<hostname> by default",
 				},
 				&cli.StringFlag{
 					Name:  color.FlagColor,
@@ -65,8 +65,7 @@ func newActivityCommands() []*cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				CompleteActivity(c)
-				return nil
+				return CompleteActivity(c)
 			},
 		},
 		{
@@ -79,7 +78,7 @@ func newActivityCommands() []*cli.Command {
 				},
 				&cli.StringFlag{
 					Name:  FlagRunIDWithAlias,
-					Usage: "RunId",
+					Usage: "RunId, the current run by default",
 				},
 				&cli.StringFlag{
 					Name:  FlagActivityIDWithAlias,
@@ -91,11 +90,15 @@ func newActivityCommands() []*cli.Command {
 				},
 				&cli.StringFlag{
 					Name:  FlagDetail,
-					Usage: "Detail to fail the activity",
+					Usage: "Optional details of the failure in JSON, - to read them from stdin",
+				},
+				&cli.BoolFlag{
+					Name:  FlagRetryable,
+					Usage: "Let the activity be retried by its retry policy instead of failing it for good",
 				},
 				&cli.StringFlag{
 					Name:  FlagIdentity,
-					Usage: "Identity of the operator",
+					Usage: "Identity of the operator, tctl@<hostname> by default",
 				},
 				&cli.StringFlag{
 					Name:  color.FlagColor,
@@ -104,8 +107,7 @@ func newActivityCommands() []*cli.Command {
 				},
 			},
 			Action: func(c *cli.Context) error {
-				FailActivity(c)
-				return nil
+				return FailActivity(c)
 			},
 		},
 	}
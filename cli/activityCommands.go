@@ -29,26 +29,18 @@ import (
 
 	"github.com/temporalio/tctl/pkg/color"
 	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
 	failurepb "go.temporal.io/api/failure/v1"
 	"go.temporal.io/api/workflowservice/v1"
-
-	"go.temporal.io/server/common/payloads"
 )
 
 // CompleteActivity completes an activity
 func CompleteActivity(c *cli.Context) error {
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	wid, rid := getWorkflowParams(c)
-	if rid == "" {
-		return fmt.Errorf("provide non-empty run id")
-	}
-
 	activityID := getRequiredOption(c, FlagActivityID)
-	if len(activityID) == 0 {
-		return fmt.Errorf("provide non-empty activity id")
-	}
-	result := getRequiredOption(c, FlagResult)
-	identity := getRequiredOption(c, FlagIdentity)
+	result := getActivityJSONOption(getRequiredOption(c, FlagResult))
+	identity := getActivityIdentity(c)
 	ctx, cancel := newContext(c)
 	defer cancel()
 
@@ -58,14 +50,13 @@ func CompleteActivity(c *cli.Context) error {
 		WorkflowId: wid,
 		RunId:      rid,
 		ActivityId: activityID,
-		Result:     payloads.EncodeString(result),
+		Result:     result,
 		Identity:   identity,
 	})
 	if err != nil {
-		return fmt.Errorf("unable to Complete activity.\n%s", err)
-	} else {
-		fmt.Println(color.Green(c, "activity was Completed"))
+		ErrorAndExit("Unable to complete activity.", err)
 	}
+	fmt.Println(color.Green(c, "activity was Completed"))
 	return nil
 }
 
@@ -73,16 +64,13 @@ func CompleteActivity(c *cli.Context) error {
 func FailActivity(c *cli.Context) error {
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	wid, rid := getWorkflowParams(c)
-	if rid == "" {
-		return fmt.Errorf("provide non-empty run id")
-	}
 	activityID := getRequiredOption(c, FlagActivityID)
-	if len(activityID) == 0 {
-		return fmt.Errorf("provide non-empty activity id")
-	}
 	reason := getRequiredOption(c, FlagReason)
-	detail := getRequiredOption(c, FlagDetail)
-	identity := getRequiredOption(c, FlagIdentity)
+	var details *commonpb.Payloads
+	if c.IsSet(FlagDetail) {
+		details = getActivityJSONOption(c.String(FlagDetail))
+	}
+	identity := getActivityIdentity(c)
 	ctx, cancel := newContext(c)
 	defer cancel()
 
@@ -96,17 +84,31 @@ func FailActivity(c *cli.Context) error {
 			Message: reason,
 			Source:  "CLI",
 			FailureInfo: &failurepb.Failure_ApplicationFailureInfo{ApplicationFailureInfo: &failurepb.ApplicationFailureInfo{
-				NonRetryable: true,
-				Details:      payloads.EncodeString(detail),
+				NonRetryable: !c.Bool(FlagRetryable),
+				Details:      details,
 			}},
 		},
 		Identity: identity,
 	})
 	if err != nil {
-		return fmt.Errorf("unable to Fail activity.\n%s", err)
-	} else {
-		fmt.Println(color.Green(c, "activity was Failed"))
+		ErrorAndExit("Unable to fail activity.", err)
+	}
+	fmt.Println(color.Green(c, "activity was Failed"))
+	return nil
+}
+
+// getActivityJSONOption encodes the JSON value of the option, read from stdin for -. A value that is
+// not JSON is encoded as a string
+func getActivityJSONOption(value string) *commonpb.Payloads {
+	if value == stdinInput {
+		value = string(readInputFile(stdinInput))
+	}
+	return encodeJSONArgs([]string{value})
+}
 
-		return nil
+func getActivityIdentity(c *cli.Context) string {
+	if c.IsSet(FlagIdentity) {
+		return c.String(FlagIdentity)
 	}
+	return getCliIdentity()
 }
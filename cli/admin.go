@@ -0,0 +1,90 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/flags"
+)
+
+func newAdminCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:        "dlq",
+			Usage:       "Read, purge and merge the messages of the replication and namespace dead letter queues",
+			Subcommands: newAdminDLQCommands(),
+		},
+	}
+}
+
+func newAdminDLQCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:    "read",
+			Aliases: []string{"r"},
+			Usage:   "Read the messages of a dead letter queue",
+			Flags: append(append(flagsForDLQ, &cli.IntFlag{
+				Name:  FlagPageSize,
+				Value: defaultPageSizeDLQ,
+				Usage: "Number of messages fetched at a time",
+			}), flags.FlagsForListing...),
+			Action: func(c *cli.Context) error {
+				ReadDLQMessages(c)
+				return nil
+			},
+		},
+		{
+			Name:  "purge",
+			Usage: "Delete the messages of a dead letter queue up to --" + FlagLastMessageID,
+			Flags: append(flagsForDLQ, &cli.BoolFlag{
+				Name:  FlagYes,
+				Usage: "Purge without the confirmation prompt",
+			}),
+			Action: func(c *cli.Context) error {
+				PurgeDLQMessages(c)
+				return nil
+			},
+		},
+		{
+			Name:  "merge",
+			Usage: "Apply the messages of a dead letter queue up to --" + FlagLastMessageID + " and remove them from the queue",
+			Flags: append(flagsForDLQ,
+				&cli.IntFlag{
+					Name:  FlagPageSize,
+					Value: defaultPageSizeDLQ,
+					Usage: "Number of messages merged at a time",
+				},
+				&cli.BoolFlag{
+					Name:  FlagYes,
+					Usage: "Merge without the confirmation prompt",
+				},
+			),
+			Action: func(c *cli.Context) error {
+				MergeDLQMessages(c)
+				return nil
+			},
+		},
+	}
+}
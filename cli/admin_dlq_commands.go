@@ -0,0 +1,197 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/urfave/cli/v2"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	replicationspb "go.temporal.io/server/api/replication/v1"
+
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+type dlqMessageRow struct {
+	MessageId   int64
+	TaskType    enumsspb.ReplicationTaskType
+	NamespaceId string
+	WorkflowId  string
+	RunId       string
+	Task        *replicationspb.ReplicationTask
+}
+
+// ReadDLQMessages prints the messages of the dead letter queue page by page
+func ReadDLQMessages(c *cli.Context) {
+	req := newDLQRequest(c)
+	client := cFactory.AdminClient(c)
+
+	paginationFunc := func(npt []byte) ([]interface{}, []byte, error) {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		resp, err := client.GetDLQMessages(ctx, &adminservice.GetDLQMessagesRequest{
+			Type:                  req.dlqType,
+			ShardId:               req.shardID,
+			SourceCluster:         req.sourceCluster,
+			InclusiveEndMessageId: req.lastMessageID,
+			MaximumPageSize:       int32(c.Int(FlagPageSize)),
+			NextPageToken:         npt,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		var items []interface{}
+		for _, task := range resp.GetReplicationTasks() {
+			items = append(items, newDLQMessageRow(task))
+		}
+		return items, resp.GetNextPageToken(), nil
+	}
+
+	iter := output.NewPagingIterator(c, paginationFunc)
+	opts := &output.PrintOptions{
+		Fields:       []string{"MessageId", "TaskType", "NamespaceId", "WorkflowId", "RunId"},
+		FieldsLong:   []string{"Task"},
+		ItemTemplate: &dlqMessageRow{},
+	}
+	if err := output.Pager(c, iter, opts); err != nil {
+		ErrorAndExit("Unable to read DLQ messages.", err)
+	}
+}
+
+// PurgeDLQMessages deletes the messages of the dead letter queue up to the last message id
+func PurgeDLQMessages(c *cli.Context) {
+	req := newDLQRequest(c)
+	prompt(fmt.Sprintf("Purge %s? The messages are lost [y/N]", req), c.Bool(FlagYes) || c.Bool(FlagAutoConfirm))
+
+	client := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := client.PurgeDLQMessages(ctx, &adminservice.PurgeDLQMessagesRequest{
+		Type:                  req.dlqType,
+		ShardId:               req.shardID,
+		SourceCluster:         req.sourceCluster,
+		InclusiveEndMessageId: req.lastMessageID,
+	})
+	if err != nil {
+		ErrorAndExit("Unable to purge DLQ messages.", err)
+	}
+	fmt.Printf("Purged %s.\n", req)
+}
+
+// MergeDLQMessages applies the messages of the dead letter queue up to the last message id, page by page
+func MergeDLQMessages(c *cli.Context) {
+	req := newDLQRequest(c)
+	prompt(fmt.Sprintf("Merge %s? [y/N]", req), c.Bool(FlagYes) || c.Bool(FlagAutoConfirm))
+
+	client := cFactory.AdminClient(c)
+	var npt []byte
+	for more := true; more; more = len(npt) > 0 {
+		ctx, cancel := newContext(c)
+		resp, err := client.MergeDLQMessages(ctx, &adminservice.MergeDLQMessagesRequest{
+			Type:                  req.dlqType,
+			ShardId:               req.shardID,
+			SourceCluster:         req.sourceCluster,
+			InclusiveEndMessageId: req.lastMessageID,
+			MaximumPageSize:       int32(c.Int(FlagPageSize)),
+			NextPageToken:         npt,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("Unable to merge DLQ messages.", err)
+		}
+		npt = resp.GetNextPageToken()
+	}
+	fmt.Printf("Merged %s.\n", req)
+}
+
+// dlqRequest identifies the dead letter queue and the last message of the read, purge and merge commands
+type dlqRequest struct {
+	dlqType       enumsspb.DeadLetterQueueType
+	shardID       int32
+	sourceCluster string
+	lastMessageID int64
+}
+
+func newDLQRequest(c *cli.Context) dlqRequest {
+	dlqType, err := stringToEnum(getRequiredOption(c, FlagDLQType), enumsspb.DeadLetterQueueType_value)
+	if err != nil || dlqType == int32(enumsspb.DEAD_LETTER_QUEUE_TYPE_UNSPECIFIED) {
+		ErrorAndExit(fmt.Sprintf("Option %s format is invalid, valid values are replication and namespace.", FlagDLQType), err)
+	}
+	req := dlqRequest{
+		dlqType:       enumsspb.DeadLetterQueueType(dlqType),
+		lastMessageID: math.MaxInt64,
+	}
+	if req.dlqType == enumsspb.DEAD_LETTER_QUEUE_TYPE_REPLICATION {
+		req.sourceCluster = getRequiredOption(c, FlagCluster)
+		if !c.IsSet(FlagShardID) {
+			process.UsageErrorAndExit(fmt.Sprintf("Option %s is required for the replication DLQ.", FlagShardID))
+		}
+		req.shardID = int32(c.Int(FlagShardID))
+	}
+	if c.IsSet(FlagLastMessageID) {
+		req.lastMessageID = c.Int64(FlagLastMessageID)
+	}
+	return req
+}
+
+func (r dlqRequest) String() string {
+	s := "the namespace DLQ messages"
+	if r.dlqType == enumsspb.DEAD_LETTER_QUEUE_TYPE_REPLICATION {
+		s = fmt.Sprintf("the replication DLQ messages of shard %d from cluster %s", r.shardID, r.sourceCluster)
+	}
+	if r.lastMessageID == math.MaxInt64 {
+		return "all " + s
+	}
+	return fmt.Sprintf("%s up to message %d", s, r.lastMessageID)
+}
+
+func newDLQMessageRow(task *replicationspb.ReplicationTask) dlqMessageRow {
+	row := dlqMessageRow{
+		MessageId:   task.GetSourceTaskId(),
+		TaskType:    task.GetTaskType(),
+		NamespaceId: task.GetNamespaceTaskAttributes().GetId(),
+		Task:        task,
+	}
+	// the getters of the attributes not set on the task return empty values
+	for _, attrs := range []interface {
+		GetNamespaceId() string
+		GetWorkflowId() string
+		GetRunId() string
+	}{
+		task.GetHistoryTaskAttributes(),
+		task.GetHistoryTaskV2Attributes(),
+		task.GetHistoryMetadataTaskAttributes(),
+		task.GetSyncActivityTaskAttributes(),
+	} {
+		if attrs.GetWorkflowId() != "" {
+			row.NamespaceId, row.WorkflowId, row.RunId = attrs.GetNamespaceId(), attrs.GetWorkflowId(), attrs.GetRunId()
+			break
+		}
+	}
+	return row
+}
@@ -0,0 +1,97 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	enumsspb "go.temporal.io/server/api/enums/v1"
+	replicationspb "go.temporal.io/server/api/replication/v1"
+)
+
+type adminDLQSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestAdminDLQSuite(t *testing.T) {
+	suite.Run(t, new(adminDLQSuite))
+}
+
+func (s *adminDLQSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *adminDLQSuite) TestNewDLQMessageRow() {
+	task := &replicationspb.ReplicationTask{
+		TaskType:     enumsspb.REPLICATION_TASK_TYPE_HISTORY_V2_TASK,
+		SourceTaskId: 42,
+		Attributes: &replicationspb.ReplicationTask_HistoryTaskV2Attributes{
+			HistoryTaskV2Attributes: &replicationspb.HistoryTaskV2Attributes{
+				NamespaceId: "ns-id",
+				WorkflowId:  "order-1",
+				RunId:       "run-1",
+			},
+		},
+	}
+	s.Equal(dlqMessageRow{
+		MessageId:   42,
+		TaskType:    enumsspb.REPLICATION_TASK_TYPE_HISTORY_V2_TASK,
+		NamespaceId: "ns-id",
+		WorkflowId:  "order-1",
+		RunId:       "run-1",
+		Task:        task,
+	}, newDLQMessageRow(task))
+
+	task = &replicationspb.ReplicationTask{
+		TaskType:     enumsspb.REPLICATION_TASK_TYPE_NAMESPACE_TASK,
+		SourceTaskId: 7,
+		Attributes: &replicationspb.ReplicationTask_NamespaceTaskAttributes{
+			NamespaceTaskAttributes: &replicationspb.NamespaceTaskAttributes{Id: "ns-id"},
+		},
+	}
+	s.Equal(dlqMessageRow{
+		MessageId:   7,
+		TaskType:    enumsspb.REPLICATION_TASK_TYPE_NAMESPACE_TASK,
+		NamespaceId: "ns-id",
+		Task:        task,
+	}, newDLQMessageRow(task))
+}
+
+func (s *adminDLQSuite) TestDLQRequestString() {
+	s.Equal("all the namespace DLQ messages", dlqRequest{
+		dlqType:       enumsspb.DEAD_LETTER_QUEUE_TYPE_NAMESPACE,
+		lastMessageID: math.MaxInt64,
+	}.String())
+	s.Equal("the replication DLQ messages of shard 3 from cluster standby up to message 100", dlqRequest{
+		dlqType:       enumsspb.DEAD_LETTER_QUEUE_TYPE_REPLICATION,
+		shardID:       3,
+		sourceCluster: "standby",
+		lastMessageID: 100,
+	}.String())
+}
@@ -34,6 +34,9 @@ import (
 	"github.com/temporalio/tctl/cli/dataconverter"
 	"github.com/temporalio/tctl/cli/plugin"
 	"github.com/temporalio/tctl/pkg/color"
+	"github.com/temporalio/tctl/pkg/flags"
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
 	"go.temporal.io/server/common/headers"
 )
 
@@ -107,8 +110,13 @@ func NewCliApp() *cli.App {
 			Usage:   "data converter plugin executable name",
 			EnvVars: []string{"TEMPORAL_CLI_PLUGIN_DATA_CONVERTER"},
 		},
+		flags.FlagQuiet,
 	}
 	app.Commands = tctlCommands
+	useWatch(app.Commands)
+	useJSONErrors(app.Commands)
+	app.OnUsageError = onUsageError
+	useUsageErrors(app.Commands)
 	app.Before = loadPlugins
 	app.After = stopPlugins
 	app.ExitErrHandler = handleError
@@ -121,6 +129,65 @@ func NewCliApp() *cli.App {
 	return app
 }
 
+// useWatch lets the commands with the --watch flag re-run their action periodically
+func useWatch(commands []*cli.Command) {
+	for _, cmd := range commands {
+		useWatch(cmd.Subcommands)
+		if cmd.Action == nil || !hasFlag(cmd, output.FlagWatch) {
+			continue
+		}
+		action := cmd.Action
+		cmd.Action = func(c *cli.Context) error {
+			return output.Watch(c, action)
+		}
+	}
+}
+
+// useUsageErrors makes the commands exit with ExitCodeInvalidArgument on flag parse errors
+func useUsageErrors(commands []*cli.Command) {
+	for _, cmd := range commands {
+		useUsageErrors(cmd.Subcommands)
+		if cmd.OnUsageError == nil {
+			cmd.OnUsageError = onUsageError
+		}
+	}
+}
+
+func onUsageError(c *cli.Context, err error, _ bool) error {
+	// the flags failed to parse, so the context can't be used to print the error
+	process.UsageErrorAndExit(fmt.Sprintf("Incorrect Usage: %v. See --help", err))
+	return err
+}
+
+// useJSONErrors makes the commands printing json report their errors as json as well
+func useJSONErrors(commands []*cli.Command) {
+	for _, cmd := range commands {
+		useJSONErrors(cmd.Subcommands)
+		if cmd.Action == nil || !hasFlag(cmd, output.FlagOutput) {
+			continue
+		}
+		action := cmd.Action
+		cmd.Action = func(c *cli.Context) error {
+			switch output.OutputOption(c.String(output.FlagOutput)) {
+			case output.JSON, output.NDJSON:
+				process.SetJSONErrors(true)
+			}
+			return action(c)
+		}
+	}
+}
+
+func hasFlag(cmd *cli.Command, name string) bool {
+	for _, flag := range cmd.Flags {
+		for _, n := range flag.Names() {
+			if n == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func loadPlugins(ctx *cli.Context) error {
 	dcPlugin := ctx.String(FlagDataConverterPlugin)
 	if dcPlugin != "" {
@@ -138,7 +205,7 @@ func loadPlugins(ctx *cli.Context) error {
 func stopPlugins(ctx *cli.Context) error {
 	plugin.StopPlugins()
 
-	return nil
+	return process.RunExitHooks(false)
 }
 
 func handleError(c *cli.Context, err error) {
@@ -146,6 +213,10 @@ func handleError(c *cli.Context, err error) {
 		return
 	}
 
+	if process.JSONErrors() {
+		process.ErrorAndExit("", err)
+	}
+
 	fmt.Fprintf(os.Stderr, "%s %+v\n", color.Red(c, "Error:"), err)
 	if os.Getenv(showErrorStackEnv) != `` {
 		fmt.Fprintln(os.Stderr, color.Magenta(c, "Stack trace:"))
@@ -153,5 +224,5 @@ func handleError(c *cli.Context, err error) {
 	} else {
 		fmt.Fprintf(os.Stderr, "('export %s=1' to see stack traces)\n", showErrorStackEnv)
 	}
-	os.Exit(1)
+	process.ExitOnError(process.ExitCode(err))
 }
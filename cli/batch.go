@@ -30,6 +30,8 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"go.temporal.io/server/service/worker/batcher"
+
+	"github.com/temporalio/tctl/pkg/flags"
 )
 
 func newBatchCommands() []*cli.Command {
@@ -37,12 +39,13 @@ func newBatchCommands() []*cli.Command {
 		{
 			Name:  "describe",
 			Usage: "Describe a batch operation job",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				&cli.StringFlag{
-					Name:  FlagJobIDWithAlias,
-					Usage: "Batch Job Id",
+					Name:    FlagJobID,
+					Aliases: []string{"jid"},
+					Usage:   "Batch Job Id",
 				},
-			},
+			}, flags.FlagsForViewing...),
 			Action: func(c *cli.Context) error {
 				return DescribeBatchJob(c)
 			},
@@ -50,13 +53,14 @@ func newBatchCommands() []*cli.Command {
 		{
 			Name:  "list",
 			Usage: "List batch operation jobs",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				&cli.IntFlag{
-					Name:  FlagPageSizeWithAlias,
-					Value: 30,
-					Usage: "Result page size",
+					Name:    FlagPageSize,
+					Aliases: []string{"ps"},
+					Value:   30,
+					Usage:   "Result page size",
 				},
-			},
+			}, flags.FlagsForListing...),
 			Action: func(c *cli.Context) error {
 				return ListBatchJobs(c)
 			},
@@ -90,10 +94,19 @@ func newBatchCommands() []*cli.Command {
 					Value: batcher.DefaultRPS,
 					Usage: "RPS of processing",
 				},
+				&cli.IntFlag{
+					Name:  FlagConcurrency,
+					Value: batcher.DefaultConcurrency,
+					Usage: "Number of workflows processed at the same time",
+				},
 				&cli.BoolFlag{
 					Name:  FlagYes,
 					Usage: "Optional flag to disable confirmation prompt",
 				},
+				&cli.BoolFlag{
+					Name:  FlagProgress,
+					Usage: "Wait for the batch job to finish and show its progress",
+				},
 			},
 			Action: func(c *cli.Context) error {
 				return StartBatchJob(c)
@@ -104,8 +117,9 @@ func newBatchCommands() []*cli.Command {
 			Usage: "terminate a batch operation job",
 			Flags: []cli.Flag{
 				&cli.StringFlag{
-					Name:  FlagJobIDWithAlias,
-					Usage: "Batch Job Id",
+					Name:    FlagJobID,
+					Aliases: []string{"jid"},
+					Usage:   "Batch Job Id",
 				},
 				&cli.StringFlag{
 					Name:  FlagReasonWithAlias,
@@ -29,9 +29,11 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/urfave/cli/v2"
 	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
 	"go.temporal.io/api/workflowservice/v1"
 	sdkclient "go.temporal.io/sdk/client"
 
@@ -41,8 +43,23 @@ import (
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/service/worker/batcher"
+
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
 )
 
+type batchJobRow struct {
+	JobId     string
+	Status    enumspb.WorkflowExecutionStatus
+	Operator  string
+	Reason    string
+	StartTime time.Time
+	CloseTime time.Time
+	Total     int64
+	Succeeded int
+	Failed    int
+}
+
 // DescribeBatchJob describe the status of the batch job
 func DescribeBatchJob(c *cli.Context) error {
 	jobID := getRequiredOption(c, FlagJobID)
@@ -52,29 +69,33 @@ func DescribeBatchJob(c *cli.Context) error {
 	defer cancel()
 	wf, err := client.DescribeWorkflowExecution(tcCtx, jobID, "")
 	if err != nil {
-		return fmt.Errorf("failed to describe batch job: %w", err)
+		ErrorAndExit("Failed to describe batch job.", err)
 	}
 
-	output := map[string]interface{}{}
-	if wf.WorkflowExecutionInfo.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
-		if wf.WorkflowExecutionInfo.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED {
-			output["msg"] = "batch job stopped status: " + wf.WorkflowExecutionInfo.GetStatus().String()
-		} else {
-			output["msg"] = "batch job is finished successfully"
-		}
-	} else {
-		output["msg"] = "batch job is running"
-		if len(wf.PendingActivities) > 0 {
-			hbdPayload := wf.PendingActivities[0].HeartbeatDetails
-			var hbd batcher.HeartBeatDetails
-			err := payloads.Decode(hbdPayload, &hbd)
-			if err != nil {
-				return fmt.Errorf("failed to describe batch job: %w", err)
-			}
-			output["progress"] = hbd
-		}
+	job, err := newBatchJobRow(wf.GetWorkflowExecutionInfo())
+	if err != nil {
+		ErrorAndExit("Failed to describe batch job.", err)
 	}
-	prettyPrintJSONObject(output)
+	var hbd *batcher.HeartBeatDetails
+	switch job.Status {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		hbd, err = getBatchJobHeartbeat(wf)
+	case enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		// the batch workflow returns the final progress as its result
+		hbd = &batcher.HeartBeatDetails{}
+		err = client.GetWorkflow(tcCtx, jobID, "").Get(tcCtx, hbd)
+	}
+	if err != nil {
+		ErrorAndExit("Failed to get the progress of batch job.", err)
+	}
+	job.setProgress(hbd)
+
+	opts := &output.PrintOptions{
+		Fields:  []string{"JobId", "Status", "Operator", "Reason", "StartTime", "CloseTime", "Total", "Succeeded", "Failed"},
+		Output:  output.Card,
+		NoPager: true,
+	}
+	output.PrintItems(c, []interface{}{job}, opts)
 	return nil
 }
 
@@ -83,48 +104,92 @@ func ListBatchJobs(c *cli.Context) error {
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	pageSize := c.Int(FlagPageSize)
 	client := cFactory.SDKClient(c, common.SystemLocalNamespace)
-	tcCtx, cancel := newContext(c)
-	defer cancel()
-	resp, err := client.ListWorkflow(tcCtx, &workflowservice.ListWorkflowExecutionsRequest{
-		Namespace: common.SystemLocalNamespace,
-		PageSize:  int32(pageSize),
-		Query:     fmt.Sprintf("%s = '%s'", searchattribute.BatcherNamespace, namespace),
-	})
-	if err != nil {
-		return fmt.Errorf("failed to list batch jobs: %w", err)
-	}
 
-	output := make([]interface{}, 0, len(resp.Executions))
-	for _, wf := range resp.Executions {
-		var reason, operator string
-		err = payload.Decode(wf.Memo.Fields["Reason"], &reason)
+	paginationFunc := func(npt []byte) ([]interface{}, []byte, error) {
+		tcCtx, cancel := newContext(c)
+		defer cancel()
+		resp, err := client.ListWorkflow(tcCtx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace:     common.SystemLocalNamespace,
+			PageSize:      int32(pageSize),
+			NextPageToken: npt,
+			Query:         fmt.Sprintf("%s = '%s'", searchattribute.BatcherNamespace, namespace),
+		})
 		if err != nil {
-			return fmt.Errorf("failed to deserialize reason memo field: %w", err)
+			return nil, nil, err
 		}
 
-		err = payload.Decode(wf.SearchAttributes.IndexedFields[searchattribute.BatcherUser], &operator)
-		if err != nil {
-			return fmt.Errorf("failed to deserialize operator search attribute: %w", err)
+		items := make([]interface{}, 0, len(resp.Executions))
+		for _, wf := range resp.Executions {
+			job, err := newBatchJobRow(wf)
+			if err != nil {
+				return nil, nil, err
+			}
+			// only the running jobs report the progress without reading their history
+			if job.Status == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+				desc, err := client.DescribeWorkflowExecution(tcCtx, job.JobId, "")
+				if err != nil {
+					return nil, nil, err
+				}
+				hbd, err := getBatchJobHeartbeat(desc)
+				if err != nil {
+					return nil, nil, err
+				}
+				job.setProgress(hbd)
+			}
+			items = append(items, job)
 		}
+		return items, resp.GetNextPageToken(), nil
+	}
 
-		job := map[string]string{
-			"jobId":     wf.Execution.GetWorkflowId(),
-			"startTime": formatTime(timestamp.TimeValue(wf.GetStartTime()), false),
-			"reason":    reason,
-			"operator":  operator,
-		}
+	iter := output.NewPagingIterator(c, paginationFunc)
+	opts := &output.PrintOptions{
+		Fields:       []string{"JobId", "Status", "StartTime", "Total", "Succeeded", "Failed"},
+		FieldsLong:   []string{"Operator", "Reason", "CloseTime"},
+		ItemTemplate: &batchJobRow{},
+	}
+	if err := output.Pager(c, iter, opts); err != nil {
+		ErrorAndExit("Failed to list batch jobs.", err)
+	}
+	return nil
+}
 
-		if wf.GetStatus() != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
-			job["status"] = wf.GetStatus().String()
-			job["closeTime"] = formatTime(timestamp.TimeValue(wf.GetCloseTime()), false)
-		} else {
-			job["status"] = "RUNNING"
-		}
+func newBatchJobRow(wf *workflowpb.WorkflowExecutionInfo) (batchJobRow, error) {
+	var reason, operator string
+	if err := payload.Decode(wf.GetMemo().GetFields()["Reason"], &reason); err != nil {
+		return batchJobRow{}, fmt.Errorf("failed to deserialize reason memo field: %w", err)
+	}
+	if err := payload.Decode(wf.GetSearchAttributes().GetIndexedFields()[searchattribute.BatcherUser], &operator); err != nil {
+		return batchJobRow{}, fmt.Errorf("failed to deserialize operator search attribute: %w", err)
+	}
+	return batchJobRow{
+		JobId:     wf.GetExecution().GetWorkflowId(),
+		Status:    wf.GetStatus(),
+		Operator:  operator,
+		Reason:    reason,
+		StartTime: timestamp.TimeValue(wf.GetStartTime()),
+		CloseTime: timestamp.TimeValue(wf.GetCloseTime()),
+	}, nil
+}
 
-		output = append(output, job)
+// getBatchJobHeartbeat returns the progress a running batch job reported, or nil before the first heartbeat
+func getBatchJobHeartbeat(wf *workflowservice.DescribeWorkflowExecutionResponse) (*batcher.HeartBeatDetails, error) {
+	if len(wf.GetPendingActivities()) == 0 || wf.GetPendingActivities()[0].GetHeartbeatDetails() == nil {
+		return nil, nil
 	}
-	prettyPrintJSONObject(output)
-	return nil
+	var hbd batcher.HeartBeatDetails
+	if err := payloads.Decode(wf.GetPendingActivities()[0].GetHeartbeatDetails(), &hbd); err != nil {
+		return nil, fmt.Errorf("failed to deserialize batch job progress: %w", err)
+	}
+	return &hbd, nil
+}
+
+func (j *batchJobRow) setProgress(hbd *batcher.HeartBeatDetails) {
+	if hbd == nil {
+		return
+	}
+	j.Total = hbd.TotalEstimate
+	j.Succeeded = hbd.SuccessCount
+	j.Failed = hbd.ErrorCount
 }
 
 // StartBatchJob starts a batch job
@@ -134,16 +199,68 @@ func StartBatchJob(c *cli.Context) error {
 	reason := getRequiredOption(c, FlagReason)
 	batchType := getRequiredOption(c, FlagBatchType)
 	if !validateBatchType(batchType) {
-		return fmt.Errorf("unknown batch type, supported types: %s", strings.Join(batcher.AllBatchTypes, ","))
+		process.UsageErrorAndExit(fmt.Sprintf("Unknown batch type, supported types: %s.", strings.Join(batcher.AllBatchTypes, ",")))
 	}
-	operator := getCurrentUserFromEnv()
 	var sigName, sigVal string
 	if batchType == batcher.BatchTypeSignal {
 		sigName = getRequiredOption(c, FlagSignalName)
 		sigVal = getRequiredOption(c, FlagInput)
 	}
-	rps := c.Int(FlagRPS)
+	sigInput, err := payloads.Encode(sigVal)
+	if err != nil {
+		ErrorAndExit("Failed to serialize signal value.", err)
+	}
+
+	confirmed, err := confirmBatchJob(c, namespace, query, false)
+	if err == nil && confirmed {
+		err = startBatchJob(c, batcher.BatchParams{
+			Namespace: namespace,
+			Query:     query,
+			Reason:    reason,
+			BatchType: batchType,
+			SignalParams: batcher.SignalParams{
+				SignalName: sigName,
+				Input:      sigInput,
+			},
+			RPS:         c.Int(FlagRPS),
+			Concurrency: c.Int(FlagConcurrency),
+		})
+	}
+	if err != nil {
+		ErrorAndExit("Batch job failed.", err)
+	}
+	return nil
+}
+
+// startBatchByQuery runs the workflow command as a batch job on the executions matching --query
+func startBatchByQuery(c *cli.Context, batchType string, signalParams batcher.SignalParams) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	query := c.String(FlagListQuery)
+	reason := c.String(FlagReason)
+	if reason == "" {
+		reason = fmt.Sprintf("tctl workflow %s --%s", batchType, FlagListQuery)
+	}
 
+	confirmed, err := confirmBatchJob(c, namespace, query, true)
+	if err == nil && confirmed {
+		err = startBatchJob(c, batcher.BatchParams{
+			Namespace:    namespace,
+			Query:        query,
+			Reason:       reason,
+			BatchType:    batchType,
+			SignalParams: signalParams,
+			RPS:          c.Int(FlagRPS),
+			Concurrency:  c.Int(FlagConcurrency),
+		})
+	}
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Batch %s failed.", batchType), err)
+	}
+}
+
+// confirmBatchJob prints the number of the workflows the query matches, with a few of them if
+// showSample, and asks to confirm unless --yes. Nothing is confirmed with --dry-run
+func confirmBatchJob(c *cli.Context, namespace, query string, showSample bool) (bool, error) {
 	client := cFactory.SDKClient(c, common.SystemLocalNamespace)
 	tcCtx, cancel := newContext(c)
 	defer cancel()
@@ -152,55 +269,67 @@ func StartBatchJob(c *cli.Context) error {
 		Query:     query,
 	})
 	if err != nil {
-		return fmt.Errorf("failed to count impacted workflows: %w", err)
+		return false, fmt.Errorf("failed to count impacted workflows: %w", err)
 	}
 	fmt.Printf("This batch job will be operating on %v workflows.\n", resp.GetCount())
+
+	if showSample && resp.GetCount() > 0 {
+		sample, err := client.ListWorkflow(tcCtx, &workflowservice.ListWorkflowExecutionsRequest{
+			Namespace: namespace,
+			PageSize:  batchSampleSize,
+			Query:     query,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to list impacted workflows: %w", err)
+		}
+		items := make([]interface{}, len(sample.Executions))
+		for i, e := range sample.Executions {
+			items[i] = e
+		}
+		opts := &output.PrintOptions{
+			Fields:      []string{"Execution.WorkflowId", "Execution.RunId", "Type.Name", "StartTime"},
+			IgnoreFlags: true,
+			NoPager:     true,
+		}
+		output.PrintItems(c, items, opts)
+		if resp.GetCount() > int64(len(items)) {
+			fmt.Printf("and %v more.\n", resp.GetCount()-int64(len(items)))
+		}
+	}
+
+	if c.Bool(FlagDryRun) {
+		return false, nil
+	}
 	if !c.Bool(FlagYes) {
 		reader := bufio.NewReader(os.Stdin)
-		for {
-			fmt.Print("Please confirm[Yes/No]:")
-			text, err := reader.ReadString('\n')
-			if err != nil {
-				return fmt.Errorf("failed to get confirmation to start a batch job: %w", err)
-			}
-			if strings.EqualFold(strings.TrimSpace(text), "yes") {
-				break
-			} else {
-				fmt.Println("Batch job is not started")
-				return nil
-			}
+		fmt.Print("Please confirm[Yes/No]:")
+		text, err := reader.ReadString('\n')
+		if err != nil {
+			return false, fmt.Errorf("failed to get confirmation to start a batch job: %w", err)
+		}
+		if !strings.EqualFold(strings.TrimSpace(text), "yes") {
+			fmt.Println("Batch job is not started")
+			return false, nil
 		}
-
 	}
-	tcCtx, cancel = newContext(c)
+	return true, nil
+}
+
+func startBatchJob(c *cli.Context, params batcher.BatchParams) error {
+	client := cFactory.SDKClient(c, common.SystemLocalNamespace)
+	tcCtx, cancel := newContext(c)
 	defer cancel()
 	options := sdkclient.StartWorkflowOptions{
 		TaskQueue: batcher.BatcherTaskQueueName,
 		Memo: map[string]interface{}{
-			"Reason": reason,
+			"Reason": params.Reason,
 		},
 		SearchAttributes: map[string]interface{}{
-			searchattribute.BatcherNamespace: namespace,
-			searchattribute.BatcherUser:      operator,
+			searchattribute.BatcherNamespace: params.Namespace,
+			searchattribute.BatcherUser:      getCurrentUserFromEnv(),
 		},
 	}
 
-	sigInput, err := payloads.Encode(sigVal)
-	if err != nil {
-		return fmt.Errorf("failed to serialize signal value: %w", err)
-	}
-
-	params := batcher.BatchParams{
-		Namespace: namespace,
-		Query:     query,
-		Reason:    reason,
-		BatchType: batchType,
-		SignalParams: batcher.SignalParams{
-			SignalName: sigName,
-			Input:      sigInput,
-		},
-		RPS: rps,
-	}
 	wf, err := client.ExecuteWorkflow(tcCtx, options, batcher.BatchWFTypeName, params)
 	if err != nil {
 		return fmt.Errorf("failed to start batch job: %w", err)
@@ -210,9 +339,55 @@ func StartBatchJob(c *cli.Context) error {
 		"jobId": wf.GetID(),
 	}
 	prettyPrintJSONObject(output)
+
+	if c.Bool(FlagProgress) {
+		return waitForBatchJob(c, wf.GetID())
+	}
 	return nil
 }
 
+// waitForBatchJob polls the batch job until it closes, drawing the progress it reports
+func waitForBatchJob(c *cli.Context, jobID string) error {
+	client := cFactory.SDKClient(c, common.SystemLocalNamespace)
+	progress := newProgressBar(0)
+	for {
+		tcCtx, cancel := newContext(c)
+		wf, err := client.DescribeWorkflowExecution(tcCtx, jobID, "")
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to describe batch job: %w", err)
+		}
+		status := wf.GetWorkflowExecutionInfo().GetStatus()
+		if status == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+			hbd, err := getBatchJobHeartbeat(wf)
+			if err != nil {
+				return err
+			}
+			if hbd != nil {
+				progress.Set(int64(hbd.SuccessCount+hbd.ErrorCount), hbd.TotalEstimate)
+			}
+			time.Sleep(batchProgressInterval)
+			continue
+		}
+
+		if status != enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED {
+			progress.Finish()
+			return fmt.Errorf("batch job %s stopped with status %s", jobID, status)
+		}
+		var hbd batcher.HeartBeatDetails
+		tcCtx, cancel = newContext(c)
+		err = client.GetWorkflow(tcCtx, jobID, "").Get(tcCtx, &hbd)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("failed to get the result of batch job: %w", err)
+		}
+		progress.Set(int64(hbd.SuccessCount+hbd.ErrorCount), hbd.TotalEstimate)
+		progress.Finish()
+		fmt.Printf("Batch job %s finished: %d succeeded, %d failed.\n", jobID, hbd.SuccessCount, hbd.ErrorCount)
+		return nil
+	}
+}
+
 // TerminateBatchJob stops abatch job
 func TerminateBatchJob(c *cli.Context) error {
 	jobID := getRequiredOption(c, FlagJobID)
@@ -222,12 +397,9 @@ func TerminateBatchJob(c *cli.Context) error {
 	defer cancel()
 	err := client.TerminateWorkflow(tcCtx, jobID, "", reason, nil)
 	if err != nil {
-		return fmt.Errorf("failed to terminate batch job: %w", err)
+		ErrorAndExit("Failed to terminate batch job.", err)
 	}
-	output := map[string]interface{}{
-		"msg": "batch job is terminated",
-	}
-	prettyPrintJSONObject(output)
+	fmt.Printf("Batch job %s is terminated.\n", jobID)
 	return nil
 }
 
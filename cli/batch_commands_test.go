@@ -0,0 +1,94 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/payloads"
+	"go.temporal.io/server/common/searchattribute"
+	"go.temporal.io/server/service/worker/batcher"
+)
+
+type batchCommandsSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestBatchCommandsSuite(t *testing.T) {
+	suite.Run(t, new(batchCommandsSuite))
+}
+
+func (s *batchCommandsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *batchCommandsSuite) TestBatchJobRow() {
+	startTime := time.Date(2021, 7, 1, 10, 0, 0, 0, time.UTC)
+	info := &workflowpb.WorkflowExecutionInfo{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "job-1"},
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		StartTime: &startTime,
+		Memo: &commonpb.Memo{Fields: map[string]*commonpb.Payload{
+			"Reason": payload.EncodeString("cleanup"),
+		}},
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+			searchattribute.BatcherUser: payload.EncodeString("alice"),
+		}},
+	}
+	job, err := newBatchJobRow(info)
+	s.NoError(err)
+
+	details, err := payloads.Encode(batcher.HeartBeatDetails{TotalEstimate: 100, SuccessCount: 40, ErrorCount: 2})
+	s.NoError(err)
+	hbd, err := getBatchJobHeartbeat(&workflowservice.DescribeWorkflowExecutionResponse{
+		PendingActivities: []*workflowpb.PendingActivityInfo{{HeartbeatDetails: details}},
+	})
+	s.NoError(err)
+	job.setProgress(hbd)
+
+	s.Equal(batchJobRow{
+		JobId:     "job-1",
+		Status:    enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+		Operator:  "alice",
+		Reason:    "cleanup",
+		StartTime: startTime,
+		Total:     100,
+		Succeeded: 40,
+		Failed:    2,
+	}, job)
+
+	hbd, err = getBatchJobHeartbeat(&workflowservice.DescribeWorkflowExecutionResponse{})
+	s.NoError(err)
+	s.Nil(hbd)
+}
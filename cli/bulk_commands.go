@@ -0,0 +1,481 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	taskqueuepb "go.temporal.io/api/taskqueue/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/payloads"
+	"go.temporal.io/server/common/primitives/timestamp"
+
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+const (
+	bulkSucceeded      = "Succeeded"
+	bulkAlreadyStarted = "AlreadyStarted"
+	bulkFailed         = "Failed"
+)
+
+// bulkRow is the outcome of one line of a bulk command
+type bulkRow struct {
+	Line       int
+	WorkflowId string
+	RunId      string
+	Status     string
+	Error      string
+}
+
+// bulkStart is a line of the start-bulk file
+type bulkStart struct {
+	WorkflowType string            `json:"workflowType"`
+	WorkflowId   string            `json:"workflowId"`
+	TaskQueue    string            `json:"taskQueue"`
+	Input        []json.RawMessage `json:"input"`
+}
+
+// StartWorkflowsInBulk starts a workflow per line of the NDJSON file, skipping the lines that succeeded
+// in the --progress-file
+func StartWorkflowsInBulk(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	progress := newBulkProgress(c.String(FlagProgressFile))
+	defer progress.close()
+	var lines []bulkLine
+	for _, line := range readBulkLines(getRequiredOption(c, FlagFromFile), c.Int(FlagStartLine)) {
+		if !progress.skip(line.number) {
+			lines = append(lines, line)
+		}
+	}
+	et := c.Int(FlagExecutionTimeout)
+	dt := c.Int(FlagWorkflowTaskTimeout)
+	client := cFactory.FrontendClient(c)
+
+	rows := make([]bulkRow, len(lines))
+	bar := newProgressBar(int64(len(lines)))
+	runInParallel(c.Int(FlagConcurrency), c.Int(FlagRPS), len(lines), func(i int) {
+		defer bar.Add(1)
+		row := &rows[i]
+		row.Line = lines[i].number
+
+		var start bulkStart
+		err := json.Unmarshal([]byte(lines[i].text), &start)
+		if err == nil && (start.WorkflowType == "" || start.TaskQueue == "") {
+			err = errors.New("workflowType and taskQueue are required")
+		}
+		var input *commonpb.Payloads
+		if err == nil {
+			input, err = encodeBulkInput(start.Input)
+		}
+		if err != nil {
+			row.Status, row.Error = bulkFailed, err.Error()
+			return
+		}
+		if start.WorkflowId == "" {
+			start.WorkflowId = uuid.New()
+		}
+		row.WorkflowId = start.WorkflowId
+
+		ctx, cancel := newContext(c)
+		defer cancel()
+		resp, err := client.StartWorkflowExecution(ctx, &workflowservice.StartWorkflowExecutionRequest{
+			RequestId:                uuid.New(),
+			Namespace:                namespace,
+			WorkflowId:               start.WorkflowId,
+			WorkflowType:             &commonpb.WorkflowType{Name: start.WorkflowType},
+			TaskQueue:                &taskqueuepb.TaskQueue{Name: start.TaskQueue, Kind: enumspb.TASK_QUEUE_KIND_NORMAL},
+			Input:                    input,
+			WorkflowExecutionTimeout: timestamp.DurationPtr(time.Duration(et) * time.Second),
+			WorkflowTaskTimeout:      timestamp.DurationPtr(time.Duration(dt) * time.Second),
+			Identity:                 getCliIdentity(),
+			WorkflowIdReusePolicy:    defaultWorkflowIDReusePolicy,
+		})
+		var alreadyStarted *serviceerror.WorkflowExecutionAlreadyStarted
+		switch {
+		case errors.As(err, &alreadyStarted):
+			// the line was started by an earlier attempt, so that a resumed run does not fail on it
+			row.Status, row.RunId = bulkAlreadyStarted, alreadyStarted.RunId
+		case err != nil:
+			row.Status, row.Error = bulkFailed, err.Error()
+		default:
+			row.Status, row.RunId = bulkSucceeded, resp.GetRunId()
+		}
+		if row.Status != bulkFailed {
+			progress.add(row.Line)
+		}
+	})
+	bar.Finish()
+
+	printBulkRows(c, rows, progress)
+}
+
+// bulkSignal is a row of the signal-bulk file, the signal name and input default to the flags
+type bulkSignal struct {
+	line       int
+	WorkflowId string            `json:"workflowId"`
+	RunId      string            `json:"runId"`
+	SignalName string            `json:"signalName"`
+	Input      []json.RawMessage `json:"input"`
+}
+
+// SignalWorkflowsInBulk sends a signal to each workflow of the CSV or NDJSON file, skipping the lines
+// that succeeded in the --progress-file
+func SignalWorkflowsInBulk(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	progress := newBulkProgress(c.String(FlagProgressFile))
+	defer progress.close()
+	fileName := getRequiredOption(c, FlagFromFile)
+	defaultName := c.String(FlagName)
+	defaultInput := processJSONInput(c)
+	client := cFactory.FrontendClient(c)
+
+	var signals []bulkSignal
+	var rows []bulkRow
+	if strings.EqualFold(filepath.Ext(fileName), ".csv") {
+		for _, signal := range readBulkSignalsCSV(fileName, c.Int(FlagStartLine)) {
+			if !progress.skip(signal.line) {
+				signals = append(signals, signal)
+			}
+		}
+	} else {
+		for _, line := range readBulkLines(fileName, c.Int(FlagStartLine)) {
+			if progress.skip(line.number) {
+				continue
+			}
+			signal := bulkSignal{line: line.number}
+			if err := json.Unmarshal([]byte(line.text), &signal); err != nil {
+				rows = append(rows, bulkRow{Line: line.number, Status: bulkFailed, Error: err.Error()})
+				continue
+			}
+			signals = append(signals, signal)
+		}
+	}
+
+	results := make([]bulkRow, len(signals))
+	bar := newProgressBar(int64(len(signals)))
+	runInParallel(c.Int(FlagConcurrency), c.Int(FlagRPS), len(signals), func(i int) {
+		defer bar.Add(1)
+		signal := signals[i]
+		row := &results[i]
+		row.Line, row.WorkflowId, row.RunId = signal.line, signal.WorkflowId, signal.RunId
+
+		name := signal.SignalName
+		if name == "" {
+			name = defaultName
+		}
+		input := defaultInput
+		var err error
+		if signal.Input != nil {
+			input, err = encodeBulkInput(signal.Input)
+		}
+		if err == nil && (signal.WorkflowId == "" || name == "") {
+			err = errors.New("workflowId and signal name are required")
+		}
+		if err != nil {
+			row.Status, row.Error = bulkFailed, err.Error()
+			return
+		}
+
+		ctx, cancel := newContext(c)
+		defer cancel()
+		_, err = client.SignalWorkflowExecution(ctx, &workflowservice.SignalWorkflowExecutionRequest{
+			Namespace:         namespace,
+			WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: signal.WorkflowId, RunId: signal.RunId},
+			SignalName:        name,
+			Input:             input,
+			Identity:          getCliIdentity(),
+		})
+		if err != nil {
+			row.Status, row.Error = bulkFailed, err.Error()
+		} else {
+			row.Status = bulkSucceeded
+			progress.add(row.Line)
+		}
+	})
+	bar.Finish()
+
+	printBulkRows(c, append(rows, results...), progress)
+}
+
+// readBulkSignalsCSV reads the CSV file with the header of workflowId, runId, signalName and input
+// columns, only workflowId is required. An input cell is a JSON argument
+func readBulkSignalsCSV(fileName string, startLine int) []bulkSignal {
+	// This code is only used in the CLI. The input provided is from a trusted user.
+	// #nosec
+	file, err := os.Open(fileName)
+	if err != nil {
+		ErrorAndExit("Unable to open input file.", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		ErrorAndExit("Unable to read input file.", err)
+	}
+	if len(records) == 0 {
+		return nil
+	}
+
+	columns := make(map[string]int)
+	for i, name := range records[0] {
+		columns[strings.TrimSpace(name)] = i
+	}
+	if _, ok := columns["workflowId"]; !ok {
+		process.UsageErrorAndExit("The header of the input file has no workflowId column.")
+	}
+	cell := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	var signals []bulkSignal
+	for i, record := range records[1:] {
+		line := i + 2
+		if line < startLine {
+			continue
+		}
+		signal := bulkSignal{
+			line:       line,
+			WorkflowId: cell(record, "workflowId"),
+			RunId:      cell(record, "runId"),
+			SignalName: cell(record, "signalName"),
+		}
+		if input := cell(record, "input"); input != "" {
+			signal.Input = []json.RawMessage{json.RawMessage(input)}
+		}
+		signals = append(signals, signal)
+	}
+	return signals
+}
+
+// bulkLine is a non empty line of the input file of a bulk command
+type bulkLine struct {
+	number int
+	text   string
+}
+
+// readBulkLines reads the non empty lines of the file from the 1-based line number
+func readBulkLines(fileName string, startLine int) []bulkLine {
+	// This code is only used in the CLI. The input provided is from a trusted user.
+	// #nosec
+	file, err := os.Open(fileName)
+	if err != nil {
+		ErrorAndExit("Unable to open input file.", err)
+	}
+	defer file.Close()
+
+	var lines []bulkLine
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	number := 0
+	for scanner.Scan() {
+		number++
+		text := strings.TrimSpace(scanner.Text())
+		if number < startLine || len(text) == 0 {
+			continue
+		}
+		lines = append(lines, bulkLine{number: number, text: text})
+	}
+	if err := scanner.Err(); err != nil {
+		ErrorAndExit("Unable to read input file.", err)
+	}
+	return lines
+}
+
+// runInParallel calls do for 0..n-1 from the number of goroutines, starting at most rps calls a second
+// unless rps is 0
+func runInParallel(concurrency int, rps int, n int, do func(i int)) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	var limiter <-chan time.Time
+	if rps > 0 {
+		ticker := time.NewTicker(time.Second / time.Duration(rps))
+		defer ticker.Stop()
+		limiter = ticker.C
+	}
+	indexes := make(chan int)
+	wg := &sync.WaitGroup{}
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				do(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		if limiter != nil && i > 0 {
+			<-limiter
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+func encodeBulkInput(args []json.RawMessage) (*commonpb.Payloads, error) {
+	var values []interface{}
+	for _, arg := range args {
+		var v interface{}
+		if err := json.Unmarshal(arg, &v); err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+	}
+	return payloads.Encode(values...)
+}
+
+// bulkProgress records the succeeded lines of a bulk command in the --progress-file, so that a rerun
+// with the file retries only the failed lines
+type bulkProgress struct {
+	sync.Mutex
+	fileName string
+	file     *os.File
+	done     map[int]bool
+	skipped  int
+}
+
+// newBulkProgress reads the lines recorded in the progress file, which may not exist yet, and opens it
+// to record the new ones. Without a file the succeeded lines are kept in memory
+func newBulkProgress(fileName string) *bulkProgress {
+	p := &bulkProgress{fileName: fileName, done: make(map[int]bool)}
+	if fileName == "" {
+		return p
+	}
+	for key := range readProgressFile(fileName) {
+		line, err := strconv.Atoi(key)
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Invalid line %q of progress file.", key), err)
+		}
+		p.done[line] = true
+	}
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		ErrorAndExit("Unable to open progress file.", err)
+	}
+	p.file = f
+	return p
+}
+
+// skip reports whether the line succeeded in an earlier run
+func (p *bulkProgress) skip(line int) bool {
+	if p.done[line] {
+		p.skipped++
+		return true
+	}
+	return false
+}
+
+func (p *bulkProgress) add(line int) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.done[line] = true
+	if p.file != nil {
+		_, _ = fmt.Fprintln(p.file, line)
+	}
+}
+
+// save returns the progress file to resume from, writing the succeeded lines to a temporary one when
+// the command ran without --progress-file
+func (p *bulkProgress) save() string {
+	if p.fileName != "" {
+		return p.fileName
+	}
+	f, err := ioutil.TempFile("", "tctl-bulk-*.progress")
+	if err != nil {
+		ErrorAndExit("Unable to create progress file.", err)
+	}
+	defer f.Close()
+	lines := make([]int, 0, len(p.done))
+	for line := range p.done {
+		lines = append(lines, line)
+	}
+	sort.Ints(lines)
+	for _, line := range lines {
+		if _, err := fmt.Fprintln(f, line); err != nil {
+			ErrorAndExit("Unable to write progress file.", err)
+		}
+	}
+	p.fileName = f.Name()
+	return p.fileName
+}
+
+func (p *bulkProgress) close() {
+	if p.file != nil {
+		_ = p.file.Close()
+	}
+}
+
+// printBulkRows prints the rows by line and fails with the progress file to resume from if any failed
+func printBulkRows(c *cli.Context, rows []bulkRow, progress *bulkProgress) {
+	sort.Slice(rows, func(i, j int) bool { return rows[i].Line < rows[j].Line })
+	items := make([]interface{}, len(rows))
+	failed := 0
+	for i, row := range rows {
+		items[i] = row
+		if row.Status == bulkFailed {
+			failed++
+		}
+	}
+
+	opts := &output.PrintOptions{
+		Fields:       []string{"Line", "WorkflowId", "RunId", "Status", "Error"},
+		ItemTemplate: bulkRow{},
+	}
+	output.PrintItems(c, items, opts)
+	if progress.skipped > 0 {
+		fmt.Fprintf(os.Stderr, "%d succeeded, %d failed, %d skipped as succeeded before\n", len(rows)-failed, failed, progress.skipped)
+	} else {
+		fmt.Fprintf(os.Stderr, "%d succeeded, %d failed\n", len(rows)-failed, failed)
+	}
+	if failed > 0 {
+		ErrorAndExit(fmt.Sprintf("%d of %d lines failed, rerun with --%s %s to retry them.", failed, len(rows), FlagProgressFile, progress.save()), nil)
+	}
+}
@@ -0,0 +1,142 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type bulkCommandsSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestBulkCommandsSuite(t *testing.T) {
+	suite.Run(t, new(bulkCommandsSuite))
+}
+
+func (s *bulkCommandsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *bulkCommandsSuite) TestReadBulkLines() {
+	file := filepath.Join(s.T().TempDir(), "starts.ndjson")
+	s.NoError(ioutil.WriteFile(file, []byte("{\"a\":1}\n\n  {\"a\":2}\n{\"a\":3}\n"), 0644))
+
+	s.Equal([]bulkLine{{1, `{"a":1}`}, {3, `{"a":2}`}, {4, `{"a":3}`}}, readBulkLines(file, 0))
+	s.Equal([]bulkLine{{3, `{"a":2}`}, {4, `{"a":3}`}}, readBulkLines(file, 2))
+}
+
+func (s *bulkCommandsSuite) TestRunInParallel() {
+	var sum int64
+	runInParallel(3, 0, 100, func(i int) {
+		atomic.AddInt64(&sum, int64(i))
+	})
+	s.Equal(int64(4950), sum)
+
+	start := time.Now()
+	runInParallel(3, 50, 6, func(i int) {})
+	s.GreaterOrEqual(int64(time.Since(start)), int64(90*time.Millisecond))
+}
+
+func (s *bulkCommandsSuite) TestProgressBar() {
+	start := time.Date(2021, 7, 1, 10, 0, 0, 0, time.UTC)
+	now := start
+	out := &bytes.Buffer{}
+	b := &progressBar{out: out, total: 10, start: start, now: func() time.Time { return now }}
+
+	s.Equal("[                              ] 0/10   0% ETA ?", b.line(now))
+	now = start.Add(30 * time.Second)
+	b.Add(3)
+	s.Equal("\r[=========                     ] 3/10  30% ETA 1m10s", out.String())
+
+	out.Reset()
+	b.Add(1)
+	s.Empty(out.String(), "redraw is throttled")
+	b.Finish()
+	s.Equal("\r[============                  ] 4/10  40% ETA 45s\n", out.String())
+
+	b.Set(7, 0)
+	s.Equal("7 processed", b.line(now))
+
+	var nilBar *progressBar
+	nilBar.Add(1)
+	nilBar.Finish()
+}
+
+func (s *bulkCommandsSuite) TestReadBulkSignalsCSV() {
+	file := filepath.Join(s.T().TempDir(), "targets.csv")
+	s.NoError(ioutil.WriteFile(file, []byte("workflowId,input\norder-1,\"{\"\"late\"\": true}\"\norder-2\n"), 0644))
+
+	signals := readBulkSignalsCSV(file, 0)
+	s.Len(signals, 2)
+	s.Equal(2, signals[0].line)
+	s.Equal("order-1", signals[0].WorkflowId)
+	s.Equal(`{"late": true}`, string(signals[0].Input[0]))
+	s.Equal("order-2", signals[1].WorkflowId)
+	s.Nil(signals[1].Input)
+
+	s.Len(readBulkSignalsCSV(file, 3), 1)
+}
+
+func (s *bulkCommandsSuite) TestBulkProgress() {
+	file := filepath.Join(s.T().TempDir(), "starts.progress")
+	progress := newBulkProgress(file)
+	s.False(progress.skip(3))
+	progress.add(3)
+	progress.add(5)
+	progress.close()
+
+	progress = newBulkProgress(file)
+	defer progress.close()
+	s.True(progress.skip(3))
+	s.False(progress.skip(4))
+	s.True(progress.skip(5))
+	s.Equal(2, progress.skipped)
+	s.Equal(file, progress.save())
+}
+
+func (s *bulkCommandsSuite) TestBulkProgress_Save() {
+	progress := newBulkProgress("")
+	progress.add(7)
+	progress.add(2)
+	file := progress.save()
+	defer os.Remove(file)
+
+	data, err := ioutil.ReadFile(file)
+	s.NoError(err)
+	s.Equal("2\n7\n", string(data))
+	resumed := newBulkProgress(file)
+	defer resumed.close()
+	s.True(resumed.skip(7))
+}
@@ -28,6 +28,7 @@ import (
 	"fmt"
 
 	"github.com/temporalio/tctl/pkg/color"
+	"github.com/temporalio/tctl/pkg/flags"
 	"github.com/temporalio/tctl/pkg/output"
 	"github.com/urfave/cli/v2"
 )
@@ -37,11 +38,26 @@ func newClusterCommands() []*cli.Command {
 		{
 			Name:    "health",
 			Aliases: []string{"h"},
-			Usage:   "Check health of frontend service",
+			Usage:   "Check health of frontend service and print its version. Exits with 6 unless it is serving",
+			Flags:   flags.FlagsForViewing,
 			Action: func(c *cli.Context) error {
 				return HealthCheck(c)
 			},
 		},
+		{
+			Name:    "describe",
+			Aliases: []string{"d"},
+			Usage:   "Describe the cluster name, version and history shards",
+			Flags: append([]cli.Flag{
+				&cli.BoolFlag{
+					Name:  FlagMembers,
+					Usage: "Also print the hosts of the services of the cluster, using the admin service",
+				},
+			}, flags.FlagsForViewing...),
+			Action: func(c *cli.Context) error {
+				return DescribeCluster(c)
+			},
+		},
 		{
 			Name:    "list-search-attributes",
 			Usage:   "List search attributes that can be used in list workflow query",
@@ -26,12 +26,18 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/urfave/cli/v2"
+	"go.temporal.io/api/workflowservice/v1"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
+	"go.temporal.io/server/api/adminservice/v1"
+
 	"github.com/temporalio/tctl/pkg/color"
 	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
 )
 
 const (
@@ -50,16 +56,79 @@ func HealthCheck(c *cli.Context) error {
 	resp, err := healthClient.Check(ctx, req)
 
 	if err != nil {
-		return fmt.Errorf("unable to check health, service: %q.\n%s", req.GetService(), err)
+		return fmt.Errorf("unable to check health, service: %q.\n%w", req.GetService(), err)
 	}
 
 	fmt.Printf("%s: ", req.GetService())
 	if resp.Status != healthpb.HealthCheckResponse_SERVING {
 		fmt.Println(color.Red(c, "%v", resp.Status))
+		process.Exit(process.ExitCodeConnectionFailure)
+	}
+	fmt.Println(color.Green(c, "%v", resp.Status))
+
+	info, err := cFactory.FrontendClient(c).GetClusterInfo(ctx, &workflowservice.GetClusterInfoRequest{})
+	if err != nil {
+		// the frontend is serving, older servers may not implement the cluster info
+		fmt.Fprintf(os.Stderr, "unable to get cluster info: %s\n", err)
 		return nil
 	}
+	opts := &output.PrintOptions{
+		Fields:     []string{"ServerVersion", "ClusterName", "SupportedClients"},
+		FieldsLong: []string{"ClusterId", "HistoryShardCount"},
+		Output:     output.Card,
+		NoPager:    true,
+	}
+	output.PrintItems(c, []interface{}{info}, opts)
+	return nil
+}
 
-	fmt.Println(color.Green(c, "%v", resp.Status))
+type clusterRingRow struct {
+	Role        string
+	MemberCount int32
+	Members     string
+}
+
+// DescribeCluster prints the name, version and shards of the cluster, with the hosts of its services if --members
+func DescribeCluster(c *cli.Context) error {
+	ctx, cancel := newContext(c)
+	defer cancel()
+	info, err := cFactory.FrontendClient(c).GetClusterInfo(ctx, &workflowservice.GetClusterInfoRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to get cluster info.\n%w", err)
+	}
+	opts := &output.PrintOptions{
+		Fields:     []string{"ClusterName", "ClusterId", "ServerVersion", "HistoryShardCount", "SupportedClients"},
+		FieldsLong: []string{"VersionInfo.Recommended.Version", "VersionInfo.Instructions"},
+		Output:     output.Card,
+		NoPager:    true,
+	}
+	output.PrintItems(c, []interface{}{info}, opts)
+
+	if !c.Bool(FlagMembers) {
+		return nil
+	}
+	resp, err := cFactory.AdminClient(c).DescribeCluster(ctx, &adminservice.DescribeClusterRequest{})
+	if err != nil {
+		return fmt.Errorf("unable to describe cluster membership.\n%w", err)
+	}
+	var rings []interface{}
+	for _, ring := range resp.GetMembershipInfo().GetRings() {
+		var members []string
+		for _, m := range ring.GetMembers() {
+			members = append(members, m.GetIdentity())
+		}
+		rings = append(rings, clusterRingRow{
+			Role:        ring.GetRole(),
+			MemberCount: ring.GetMemberCount(),
+			Members:     strings.Join(members, ", "),
+		})
+	}
+	fmt.Println(color.Magenta(c, "\nServices"))
+	output.PrintItems(c, rings, &output.PrintOptions{
+		Fields:      []string{"Role", "MemberCount", "Members"},
+		IgnoreFlags: true,
+		NoPager:     true,
+	})
 	return nil
 }
 
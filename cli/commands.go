@@ -40,6 +40,18 @@ var tctlCommands = []*cli.Command{
 		Usage:       "Operate Temporal cluster",
 		Subcommands: newClusterCommands(),
 	},
+	{
+		Name:        "search-attribute",
+		Aliases:     []string{"sa"},
+		Usage:       "Operate custom search attributes of the cluster",
+		Subcommands: newSearchAttributeCommands(),
+	},
+	{
+		Name:        "admin",
+		Aliases:     []string{"adm"},
+		Usage:       "Run admin operations on the cluster",
+		Subcommands: newAdminCommands(),
+	},
 	{
 		Name:        "dataconverter",
 		Aliases:     []string{"dc"},
@@ -32,6 +32,8 @@ import (
 
 	"github.com/temporalio/tctl/pkg/color"
 	"github.com/temporalio/tctl/pkg/config"
+	"github.com/temporalio/tctl/pkg/flags"
+	"github.com/temporalio/tctl/pkg/process"
 )
 
 func newConfigCommands() []*cli.Command {
@@ -52,6 +54,32 @@ func newConfigCommands() []*cli.Command {
 				return SetValue(c)
 			},
 		},
+		{
+			Name:      "save-query",
+			Usage:     "save a visibility query under a name, to be used with --" + FlagSavedQuery,
+			ArgsUsage: "<name> <query>",
+			Flags:     []cli.Flag{},
+			Action: func(c *cli.Context) error {
+				return SaveQuery(c)
+			},
+		},
+		{
+			Name:  "list-queries",
+			Usage: "list the saved visibility queries",
+			Flags: flags.FlagsForViewing,
+			Action: func(c *cli.Context) error {
+				return ListSavedQueries(c)
+			},
+		},
+		{
+			Name:      "delete-query",
+			Usage:     "delete a saved visibility query",
+			ArgsUsage: "<name>",
+			Flags:     []cli.Flag{},
+			Action: func(c *cli.Context) error {
+				return DeleteSavedQuery(c)
+			},
+		},
 	}
 }
 
@@ -61,12 +89,17 @@ var (
 		"address",
 		"alias",
 		"version",
+		"card",
+		"time-zone",
+		"time-format",
+		"pager",
+		savedQueryKey,
 	}
 )
 
 func GetValue(c *cli.Context) error {
 	if c.NArg() != 1 {
-		ErrorAndExit("invalid number of args, expected 1: property name", nil)
+		process.UsageErrorAndExit("invalid number of args, expected 1: property name")
 	}
 
 	key := c.Args().Get(0)
@@ -85,7 +118,7 @@ func GetValue(c *cli.Context) error {
 
 func SetValue(c *cli.Context) error {
 	if c.NArg() != 2 {
-		ErrorAndExit("invalid number of args, expected 2: property and value", nil)
+		process.UsageErrorAndExit("invalid number of args, expected 2: property and value")
 	}
 
 	key := c.Args().Get(0)
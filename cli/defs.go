@@ -34,9 +34,10 @@ import (
 const (
 	localHostPort = "127.0.0.1:7233"
 
-	maxOutputStringLength = 200 // max length for output string
-	maxWorkflowTypeLength = 32  // max item length for output workflow type in table
-	defaultMaxFieldLength = 500 // default max length for each attribute field
+	maxOutputStringLength = 200  // max length for output string
+	maxWorkflowTypeLength = 32   // max item length for output workflow type in table
+	defaultMaxFieldLength = 500  // default max length for each attribute field
+	historyPageSize       = 1000 // events per history page, bounds the memory used to print huge histories
 
 	// regex expression for parsing time durations, shorter, longer notations and numeric value respectively
 	defaultDateTimeRangeShortRE = "^[1-9][0-9]*[smhdwMy]$"                                // eg. 1s, 20m, 300h etc.
@@ -63,6 +64,8 @@ const (
 	defaultPageSizeForScan              = 2000
 	defaultWorkflowIDReusePolicy        = enumspb.WORKFLOW_ID_REUSE_POLICY_ALLOW_DUPLICATE
 	defaultPageSizeDLQ                  = 1000
+	batchSampleSize                     = 10 // workflows shown before confirming a batch job by query
+	batchProgressInterval               = time.Second
 
 	workflowStatusNotSet = -1
 	showErrorStackEnv    = `TEMPORAL_CLI_SHOW_STACKS`
@@ -84,13 +87,18 @@ var resetTypesMap = map[string]string{
 	"LastWorkflowTask":   "",
 	"LastContinuedAsNew": "",
 	"BadBinary":          FlagResetBadBinaryChecksum,
+	"BuildId":            FlagResetBuildID,
 }
 
 type jsonType int
 
+// stdinInput is the value of --input or --input-file to read the input from stdin
+const stdinInput = "-"
+
 const (
 	jsonTypeInput jsonType = iota
 	jsonTypeMemo
+	jsonTypeSignalInput
 )
 
 var (
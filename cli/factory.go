@@ -38,6 +38,7 @@ import (
 	"google.golang.org/grpc/credentials"
 	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 
+	"go.temporal.io/server/api/adminservice/v1"
 	"go.temporal.io/server/common/auth"
 	"go.temporal.io/server/common/log"
 	"go.temporal.io/server/common/log/tag"
@@ -48,6 +49,7 @@ type ClientFactory interface {
 	FrontendClient(c *cli.Context) workflowservice.WorkflowServiceClient
 	SDKClient(c *cli.Context, namespace string) sdkclient.Client
 	HealthClient(c *cli.Context) healthpb.HealthClient
+	AdminClient(c *cli.Context) adminservice.AdminServiceClient
 }
 
 type clientFactory struct {
@@ -105,6 +107,13 @@ func (b *clientFactory) HealthClient(c *cli.Context) healthpb.HealthClient {
 	return healthpb.NewHealthClient(connection)
 }
 
+// AdminClient builds an admin client.
+func (b *clientFactory) AdminClient(c *cli.Context) adminservice.AdminServiceClient {
+	connection, _ := b.createGRPCConnection(c)
+
+	return adminservice.NewAdminServiceClient(connection)
+}
+
 func (b *clientFactory) createGRPCConnection(c *cli.Context) (*grpc.ClientConn, error) {
 	hostPort := c.String(FlagAddress)
 	if hostPort == "" {
@@ -24,7 +24,11 @@
 
 package cli
 
-import "github.com/urfave/cli/v2"
+import (
+	"github.com/urfave/cli/v2"
+
+	"go.temporal.io/server/service/worker/batcher"
+)
 
 // Flags used to specify cli command line arguments
 const (
@@ -93,6 +97,7 @@ const (
 	FlagResult                           = "result"
 	FlagIdentity                         = "identity"
 	FlagDetail                           = "detail"
+	FlagRetryable                        = "retryable"
 	FlagReason                           = "reason"
 	FlagReasonWithAlias                  = FlagReason + ", re"
 	FlagOpen                             = "open"
@@ -136,6 +141,10 @@ const (
 	FlagHeartbeatedWithin                = "heartbeated-within"
 	FlagVisibilityArchivalState          = "visibility-archival-state"
 	FlagVisibilityArchivalStateWithAlias = FlagVisibilityArchivalState + ", vas"
+	FlagNamespaceState                   = "state"
+	FlagNamespaceFile                    = "file"
+	FlagGlobalOnly                       = "global-only"
+	FlagMembers                          = "members"
 	FlagVisibilityArchivalURI            = "visibility-uri"
 	FlagVisibilityArchivalURIWithAlias   = FlagVisibilityArchivalURI + ", vuri"
 	FlagName                             = "name"
@@ -174,6 +183,7 @@ const (
 	FlagMessageTypeWithAlias             = FlagMessageType + ", mt"
 	FlagURL                              = "url"
 	FlagIndex                            = "index"
+	FlagSearchAttributeType              = "type"
 	FlagBatchSize                        = "batch-size"
 	FlagBatchSizeWithAlias               = FlagBatchSize + ", bs"
 	FlagMemoKey                          = "memo-key"
@@ -182,17 +192,45 @@ const (
 	FlagSearchAttributesKey              = "search-attr-key"
 	FlagSearchAttributesVal              = "search-attr-value"
 	FlagSearchAttributesType             = "search-attr-type"
+	FlagSearchAttribute                  = "search-attribute"
 	FlagAddBadBinary                     = "add-bad-binary"
 	FlagRemoveBadBinary                  = "remove-bad-binary"
 	FlagResetType                        = "reset-type"
 	FlagResetPointsOnly                  = "reset-points-only"
+	FlagPendingTables                    = "pending-tables"
+	FlagFollow                           = "follow"
+	FlagArchived                         = "archived"
+	FlagEventType                        = "event-type"
+	FlagExcludeEventType                 = "exclude-event-type"
+	FlagSummary                          = "summary"
+	FlagHistoryFile                      = "history-file"
+	FlagWorkflowPlugin                   = "workflow-plugin"
+	FlagTargetWorkflowID                 = "target-workflow-id"
+	FlagTargetRunID                      = "target-run-id"
+	FlagDepth                            = "depth"
+	FlagDiagram                          = "diagram"
+	FlagTimeout                          = "timeout"
+	FlagUntil                            = "until"
+	FlagInterval                         = "interval"
+	FlagFromFile                         = "from-file"
+	FlagStartLine                        = "start-line"
+	FlagProgressFile                     = "progress-file"
+	FlagProgress                         = "progress"
+	FlagReportFile                       = "report-file"
 	FlagResetBadBinaryChecksum           = "reset-bad-binary-checksum"
+	FlagResetBuildID                     = "reset-build-id"
+	FlagReapplyType                      = "reapply-type"
 	FlagListQuery                        = "query"
+	FlagGroupBy                          = "group-by"
+	FlagSavedQuery                       = "saved-query"
+	FlagBuildQuery                       = "build-query"
 	FlagListQueryWithAlias               = FlagListQuery + ", q"
 	FlagBatchType                        = "batch-type"
 	FlagBatchTypeWithAlias               = FlagBatchType + ", bt"
 	FlagSignalName                       = "signal-name"
 	FlagSignalNameWithAlias              = FlagSignalName + ", sig"
+	FlagSignalInput                      = "signal-input"
+	FlagSignalInputFile                  = "signal-input-file"
 	FlagTaskID                           = "task-id"
 	FlagTaskType                         = "task-type"
 	FlagMinReadLevel                     = "min-read-level"
@@ -203,7 +241,6 @@ const (
 	FlagStartingRPS                      = "starting-rps"
 	FlagRPS                              = "rps"
 	FlagJobID                            = "job-id"
-	FlagJobIDWithAlias                   = FlagJobID + ", jid"
 	FlagYes                              = "yes"
 	FlagServiceConfigDir                 = "service-config-dir"
 	FlagServiceConfigDirWithAlias        = FlagServiceConfigDir + ", scd"
@@ -263,7 +300,7 @@ var flagsForShowWorkflow = []cli.Flag{
 	},
 	&cli.StringFlag{
 		Name:  FlagOutputFilenameWithAlias,
-		Usage: "Serialize history event to a file",
+		Usage: "Write the history to a file in the JSON the SDK workflow replayer reads",
 	},
 	&cli.BoolFlag{
 		Name:  FlagPrintFullyDetailWithAlias,
@@ -277,15 +314,224 @@ var flagsForShowWorkflow = []cli.Flag{
 		Name:  FlagEventIDWithAlias,
 		Usage: "Print specific event details",
 	},
-	&cli.IntFlag{
-		Name:  FlagMaxFieldLengthWithAlias,
-		Usage: "Maximum length for each attribute field",
-		Value: defaultMaxFieldLength,
-	},
 	&cli.BoolFlag{
 		Name:  FlagResetPointsOnly,
 		Usage: "Only show events that are eligible for reset",
 	},
+	&cli.BoolFlag{
+		Name:  FlagFollow,
+		Usage: "Keep printing the new events as they are appended, until the workflow closes or Ctrl+C",
+	},
+	&cli.StringSliceFlag{
+		Name:  FlagEventType,
+		Usage: "Show only the events of the type, ex. ActivityTaskFailed. Can be repeated",
+	},
+	&cli.StringSliceFlag{
+		Name:  FlagExcludeEventType,
+		Usage: "Hide the events of the type, ex. TimerStarted. Can be repeated",
+	},
+	&cli.StringFlag{
+		Name:  FlagDiagram,
+		Usage: "Print the history as a diagram of activities, timers, signals and child workflows instead of the events [mermaid, dot]",
+	},
+	&cli.BoolFlag{
+		Name:  FlagSummary,
+		Usage: "Show one line per activity, timer and child workflow with its status and duration instead of the events",
+	},
+	&cli.BoolFlag{
+		Name:  FlagArchived,
+		Usage: "Read the history of a run past its retention from the archival provider of the namespace. Requires the run id",
+	},
+}
+
+var flagsForDiff = []cli.Flag{
+	&cli.StringFlag{
+		Name:  FlagTargetWorkflowID,
+		Usage: "WorkflowID of the run to compare with, the same workflow by default",
+	},
+	&cli.StringFlag{
+		Name:  FlagTargetRunID,
+		Usage: "RunID of the run to compare with",
+	},
+}
+
+var flagsForTrace = []cli.Flag{
+	&cli.IntFlag{
+		Name:  FlagDepth,
+		Usage: "Levels of child workflows to fetch, all by default",
+	},
+}
+
+var flagsForSignalWithStart = append(flagsForRunWorkflow,
+	&cli.StringFlag{
+		Name:  FlagSignalNameWithAlias,
+		Usage: "SignalName",
+	},
+	&cli.StringSliceFlag{
+		Name: FlagSignalInput,
+		Usage: "Optional input for the signal in JSON format. If there are multiple parameters, pass each as a separate input flag. " +
+			"Pass \"null\" for null values",
+	},
+	&cli.StringFlag{
+		Name:  FlagSignalInputFile,
+		Usage: "Optional input for the signal from JSON file, - for stdin",
+	},
+)
+
+var flagsForResult = []cli.Flag{
+	&cli.DurationFlag{
+		Name:  FlagTimeout,
+		Usage: "How long to wait for the workflow to close, ex. 5m. Waits until it closes by default. Exits with 9 when it expires",
+	},
+}
+
+var flagsForStartBulk = []cli.Flag{
+	&cli.StringFlag{
+		Name: FlagFromFile,
+		Usage: "NDJSON file with a workflow per line, " +
+			"ex. {\"workflowType\": \"Order\", \"workflowId\": \"order-1\", \"taskQueue\": \"orders\", \"input\": [{\"item\": 1}]}. " +
+			"The input is the list of the arguments, the workflowId is generated if empty",
+	},
+	&cli.IntFlag{
+		Name:  FlagConcurrency,
+		Value: 10,
+		Usage: "Number of workflows started at the same time",
+	},
+	&cli.IntFlag{
+		Name:  FlagRPS,
+		Usage: "Maximum number of workflows started a second, unlimited by default",
+	},
+	&cli.StringFlag{
+		Name:  FlagProgressFile,
+		Usage: "File to record the succeeded lines in, the ones already in it are skipped. Rerun with the same file to retry the failed lines",
+	},
+	&cli.IntFlag{
+		Name:  FlagStartLine,
+		Usage: "Line of the file to start from",
+	},
+	&cli.IntFlag{
+		Name:  FlagExecutionTimeoutWithAlias,
+		Usage: "Execution start to close timeout in seconds",
+	},
+	&cli.IntFlag{
+		Name:  FlagWorkflowTaskTimeoutWithAlias,
+		Value: defaultWorkflowTaskTimeoutInSeconds,
+		Usage: "Workflow task start to close timeout in seconds",
+	},
+}
+
+var flagsForSignalBulk = []cli.Flag{
+	&cli.StringFlag{
+		Name: FlagFromFile,
+		Usage: "CSV file with a header of workflowId, runId, signalName and input columns, " +
+			"or NDJSON file with a workflow per line, ex. {\"workflowId\": \"order-1\", \"signalName\": \"cancel\", \"input\": [\"late\"]}",
+	},
+	&cli.StringFlag{
+		Name:  FlagNameWithAlias,
+		Usage: "SignalName of the rows without one",
+	},
+	&cli.StringSliceFlag{
+		Name:  FlagInputWithAlias,
+		Usage: "Input of the rows without one, in JSON format. If there are multiple parameters, pass each as a separate input flag",
+	},
+	&cli.StringFlag{
+		Name:  FlagInputFileWithAlias,
+		Usage: "Input of the rows without one, from JSON file",
+	},
+	&cli.IntFlag{
+		Name:  FlagConcurrency,
+		Value: 10,
+		Usage: "Number of workflows signaled at the same time",
+	},
+	&cli.IntFlag{
+		Name:  FlagRPS,
+		Usage: "Maximum number of workflows signaled a second, unlimited by default",
+	},
+	&cli.StringFlag{
+		Name:  FlagProgressFile,
+		Usage: "File to record the succeeded lines in, the ones already in it are skipped. Rerun with the same file to retry the failed lines",
+	},
+	&cli.IntFlag{
+		Name:  FlagStartLine,
+		Usage: "Line of the file to start from",
+	},
+}
+
+// flagsForBatchByQuery run a workflow command as a batch job on the executions matching the query
+var flagsForBatchByQuery = []cli.Flag{
+	&cli.StringFlag{
+		Name:  FlagListQueryWithAlias,
+		Usage: "Run on the executions matching the SQL like query as a batch job instead of the workflow_id, ex. 'WorkflowType=\"Foo\" AND ExecutionStatus=\"Running\"'",
+	},
+	&cli.BoolFlag{
+		Name:  FlagDryRun,
+		Usage: "With query, only show the number of the matching executions",
+	},
+	&cli.BoolFlag{
+		Name:  FlagYes,
+		Usage: "With query, start the batch job without the confirmation prompt",
+	},
+	&cli.IntFlag{
+		Name:  FlagRPS,
+		Value: batcher.DefaultRPS,
+		Usage: "With query, RPS of processing",
+	},
+	&cli.IntFlag{
+		Name:  FlagConcurrency,
+		Value: batcher.DefaultConcurrency,
+		Usage: "With query, number of workflows processed at the same time",
+	},
+	&cli.BoolFlag{
+		Name:  FlagProgress,
+		Usage: "With query, wait for the batch job to finish and show its progress",
+	},
+}
+
+// flagsForDLQ select the dead letter queue of the admin dlq commands
+var flagsForDLQ = []cli.Flag{
+	&cli.StringFlag{
+		Name:    FlagDLQType,
+		Aliases: []string{"dt"},
+		Usage:   "Type of the DLQ: replication or namespace",
+	},
+	&cli.StringFlag{
+		Name:  FlagCluster,
+		Usage: "Source cluster of the replication DLQ",
+	},
+	&cli.IntFlag{
+		Name:    FlagShardID,
+		Aliases: []string{"sid"},
+		Usage:   "Shard of the replication DLQ",
+	},
+	&cli.Int64Flag{
+		Name:  FlagLastMessageID,
+		Usage: "Id of the last message to include, all the messages by default",
+	},
+}
+
+var flagResetBuildID = &cli.StringFlag{
+	Name:  FlagResetBuildID,
+	Usage: "Build id (binary checksum) of the worker for resetType of BuildId, resets to the first workflow task it completed",
+}
+
+var flagReapplyType = &cli.StringFlag{
+	Name:  FlagReapplyType,
+	Usage: "Events to reapply after the reset point: Signal (default) or None",
+}
+
+var flagsForReplay = []cli.Flag{
+	&cli.StringSliceFlag{
+		Name:  FlagHistoryFile,
+		Usage: "History file written by tctl workflow show --output-filename. Can be repeated",
+	},
+	&cli.StringFlag{
+		Name:  FlagListQuery,
+		Usage: "Replay the executions matching the SQL like query instead of the files, ex. 'WorkflowType=\"wtype\"'",
+	},
+	&cli.StringFlag{
+		Name:  FlagWorkflowPlugin,
+		Usage: "Go plugin (go build -buildmode=plugin) exporting RegisterWorkflows func(worker.WorkflowRegistry) that registers the workflows to replay",
+	},
 }
 
 var flagsForRunWorkflow = []cli.Flag{
@@ -329,35 +575,36 @@ var flagsForRunWorkflow = []cli.Flag{
 	&cli.StringSliceFlag{
 		Name: FlagInputWithAlias,
 		Usage: "Optional input for the workflow in JSON format. If there are multiple parameters, pass each as a separate input flag. " +
-			"Pass \"null\" for null values",
+			"Pass \"null\" for null values, - to read from stdin",
 	},
 	&cli.StringFlag{
 		Name: FlagInputFileWithAlias,
-		Usage: "Optional input for the workflow from JSON file. If there are multiple JSON, concatenate them and separate by space or newline. " +
+		Usage: "Optional input for the workflow from JSON file, - for stdin. If there are multiple JSON, concatenate them and separate by space or newline. " +
 			"Input from file will be overwrite by input from command line",
 	},
 	&cli.StringFlag{
 		Name:  FlagMemoKey,
 		Usage: "Optional key of memo. If there are multiple keys, concatenate them and separate by space",
 	},
-	&cli.StringFlag{
+	&cli.StringSliceFlag{
 		Name: FlagMemo,
-		Usage: "Optional info that can be showed when list workflow, in JSON format. If there are multiple JSON, concatenate them and separate by space. " +
-			"The order must be same as memo-key",
+		Usage: "Optional info that can be showed when list workflow, as Key=Value where the value is JSON or a string. Can be repeated. " +
+			"With memo-key, the values in JSON format. If there are multiple JSON, concatenate them and separate by space. The order must be same as memo-key",
 	},
 	&cli.BoolFlag{
 		Name:  FlagShowDetailWithAlias,
 		Usage: "Show event details",
 	},
-	&cli.IntFlag{
-		Name:  FlagMaxFieldLengthWithAlias,
-		Usage: "Maximum length for each attribute field",
-	},
 	&cli.StringFlag{
 		Name: FlagMemoFile,
 		Usage: "Optional info that can be listed in list workflow, from JSON format file. If there are multiple JSON, concatenate them and separate by space or newline. " +
 			"The order must be same as memo-key",
 	},
+	&cli.StringSliceFlag{
+		Name: FlagSearchAttribute,
+		Usage: "Optional search attribute as Key=Value, the value is parsed as the type the attribute is registered with, ex. CustomIntField=5. " +
+			"Use a JSON array for multiple values. Can be repeated",
+	},
 	&cli.StringFlag{
 		Name: FlagSearchAttributesKey,
 		Usage: "Optional search attributes keys that can be be used in list query. If there are multiple keys, concatenate them and separate by |. " +
@@ -405,6 +652,15 @@ var flagsForWorkflowFiltering = []cli.Flag{
 		Usage: "Optional SQL like query for use of search attributes. NOTE: using query will ignore all other filter flags including: " +
 			"[open, earliest_time, latest_time, workflow_id, workflow_type]",
 	},
+	&cli.StringFlag{
+		Name:  FlagSavedQuery,
+		Usage: "Name of a query saved with tctl config save-query, also ignores the other filter flags and is combined with --query by AND",
+	},
+	&cli.BoolFlag{
+		Name: FlagBuildQuery,
+		Usage: "Interactively enter the attribute, operator and value of each condition of the query, with completion of " +
+			"the registered search attributes. Combined with --query by AND",
+	},
 }
 
 var flagsForScan = []cli.Flag{
@@ -412,6 +668,10 @@ var flagsForScan = []cli.Flag{
 		Name:  FlagListQueryWithAlias,
 		Usage: "Optional SQL like query",
 	},
+	&cli.StringFlag{
+		Name:  FlagSavedQuery,
+		Usage: "Name of a query saved with tctl config save-query, combined with --query by AND",
+	},
 }
 
 var flagsForListArchived = []cli.Flag{
@@ -427,6 +687,15 @@ func getFlagsForCount() []cli.Flag {
 			Name:  FlagListQueryWithAlias,
 			Usage: "Optional SQL like query. e.g count all open workflows 'CloseTime = missing'; 'WorkflowType=\"wtype\" and CloseTime > 0'",
 		},
+		&cli.StringFlag{
+			Name:  FlagSavedQuery,
+			Usage: "Name of a query saved with tctl config save-query, combined with --query by AND",
+		},
+		&cli.StringFlag{
+			Name: FlagGroupBy,
+			Usage: "Count the workflows of each value of the attribute, ex. ExecutionStatus, WorkflowType, TaskQueue or a search attribute. " +
+				"Other than ExecutionStatus, the matching workflows are scanned",
+		},
 	}
 }
 
@@ -442,16 +711,18 @@ var flagsForStackTraceQuery = []cli.Flag{
 	},
 	&cli.StringFlag{
 		Name:  FlagInputWithAlias,
-		Usage: "Optional input for the query, in JSON format. If there are multiple parameters, concatenate them and separate by space.",
+		Usage: "Optional input for the query, in JSON format. If there are multiple parameters, concatenate them and separate by space. Pass - to read from stdin",
 	},
 	&cli.StringFlag{
 		Name: FlagInputFileWithAlias,
-		Usage: "Optional input for the query from JSON file. If there are multiple JSON, concatenate them and separate by space or newline. " +
+		Usage: "Optional input for the query from JSON file, - for stdin. If there are multiple JSON, concatenate them and separate by space or newline. " +
 			"Input from file will be overwrite by input from command line",
 	},
 	&cli.StringFlag{
-		Name:  FlagQueryRejectConditionWithAlias,
-		Usage: "Optional flag to reject queries based on workflow state. Valid values are \"not_open\" and \"not_completed_cleanly\"",
+		Name:    FlagQueryRejectConditionWithAlias,
+		Aliases: []string{"reject-condition"},
+		Usage: "Optional flag to reject queries based on workflow state. Valid values are \"not_open\" and \"not_completed_cleanly\". " +
+			"A rejection is printed to stderr and exits with code 8",
 	},
 }
 
@@ -470,6 +741,11 @@ var flagsForDescribeWorkflow = append(flagsForExecution, []cli.Flag{
 		Name:  FlagResetPointsOnly,
 		Usage: "Only show auto-reset points",
 	},
+	&cli.BoolFlag{
+		Name: FlagPendingTables,
+		Usage: "Print the pending activities and children as tables after the JSON instead of in it, and the pending workflow task. " +
+			"The output is no longer valid JSON and the whole history is read to find the workflow task",
+	},
 }...)
 
 var flagsForObserveHistory = append(flagsForExecution, []cli.Flag{
@@ -481,6 +757,26 @@ var flagsForObserveHistory = append(flagsForExecution, []cli.Flag{
 		Name:  FlagMaxFieldLengthWithAlias,
 		Usage: "Optional maximum length for each attribute field when show details",
 	},
+	&cli.StringFlag{
+		Name: FlagWorkflowStatus,
+		Usage: "Poll the workflow until it has the status, or one of comma separated statuses, ex. Completed. " +
+			"Exits with code 7 if it failed, timed out, was canceled or terminated before meeting the condition",
+	},
+	&cli.StringFlag{
+		Name: FlagUntil,
+		Usage: "Poll the workflow until the condition over the fields of workflow describe is true, ex. " +
+			"'PendingActivities = 0 and WorkflowExecutionInfo.HistoryLength > 10'. Comparisons are joined by and, or. " +
+			"The value of a list field is its length",
+	},
+	&cli.DurationFlag{
+		Name:  FlagInterval,
+		Usage: "How often to poll the workflow with --" + FlagWorkflowStatus + " or --" + FlagUntil,
+		Value: defaultObserveInterval,
+	},
+	&cli.DurationFlag{
+		Name:  FlagTimeout,
+		Usage: "How long to wait for the condition, ex. 5m. Waits until the workflow closes by default. Exits with 9 when it expires",
+	},
 }...)
 
 func getDBFlags() []cli.Flag {
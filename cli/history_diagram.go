@@ -0,0 +1,222 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+const (
+	diagramMermaid = "mermaid"
+	diagramDot     = "dot"
+
+	diagramWorkflow = "Workflow"
+	diagramClient   = "Client"
+)
+
+// diagramStep is an arrow of the diagram, a reply is drawn dashed back to the workflow
+type diagramStep struct {
+	from  string
+	to    string
+	label string
+	reply bool
+}
+
+// historyDiagram collects the participants and the steps between them from the events
+type historyDiagram struct {
+	participants []string
+	steps        []diagramStep
+	byID         map[int64]string // participant by the event id of the scheduling event
+}
+
+func newHistoryDiagram() *historyDiagram {
+	return &historyDiagram{byID: make(map[int64]string)}
+}
+
+func showHistoryDiagram(c *cli.Context, wid, rid string) {
+	kind := c.String(FlagDiagram)
+	if kind != diagramMermaid && kind != diagramDot {
+		process.UsageErrorAndExit(fmt.Sprintf("Invalid %s %q, expected %s or %s", FlagDiagram, kind, diagramMermaid, diagramDot))
+	}
+
+	diagram := newHistoryDiagram()
+	for _, e := range getHistoryEvents(c, wid, rid) {
+		diagram.add(e)
+	}
+	if kind == diagramDot {
+		fmt.Print(diagram.dot())
+	} else {
+		fmt.Print(diagram.mermaid())
+	}
+}
+
+func (d *historyDiagram) participant(name string) string {
+	for _, p := range d.participants {
+		if p == name {
+			return p
+		}
+	}
+	d.participants = append(d.participants, name)
+	return name
+}
+
+// call draws an arrow from the workflow, the participant is remembered by the id of the event
+func (d *historyDiagram) call(e *historypb.HistoryEvent, to, label string) {
+	d.byID[e.GetEventId()] = d.participant(to)
+	d.steps = append(d.steps, diagramStep{from: diagramWorkflow, to: to, label: label})
+}
+
+func (d *historyDiagram) reply(id int64, label string) {
+	if from, ok := d.byID[id]; ok {
+		d.steps = append(d.steps, diagramStep{from: from, to: diagramWorkflow, label: label, reply: true})
+	}
+}
+
+func (d *historyDiagram) note(label string) {
+	d.steps = append(d.steps, diagramStep{from: diagramWorkflow, to: diagramWorkflow, label: label})
+}
+
+func (d *historyDiagram) add(e *historypb.HistoryEvent) {
+	switch e.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+		d.participant(diagramClient)
+		d.participant(diagramWorkflow)
+		a := e.GetWorkflowExecutionStartedEventAttributes()
+		d.steps = append(d.steps, diagramStep{from: diagramClient, to: diagramWorkflow, label: "start " + a.GetWorkflowType().GetName()})
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_SIGNALED:
+		d.steps = append(d.steps, diagramStep{from: diagramClient, to: diagramWorkflow, label: "signal " + e.GetWorkflowExecutionSignaledEventAttributes().GetSignalName()})
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCEL_REQUESTED:
+		d.steps = append(d.steps, diagramStep{from: diagramClient, to: diagramWorkflow, label: "cancel"})
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		d.note("Completed")
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		d.note("Failed")
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		d.note("TimedOut")
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		d.note("Canceled")
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		d.note("Terminated")
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		d.note("ContinuedAsNew")
+
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+		d.call(e, "Activity "+e.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName(), "schedule")
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+		d.reply(e.GetActivityTaskCompletedEventAttributes().GetScheduledEventId(), "completed")
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+		d.reply(e.GetActivityTaskFailedEventAttributes().GetScheduledEventId(), "failed")
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+		d.reply(e.GetActivityTaskTimedOutEventAttributes().GetScheduledEventId(), "timed out")
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		d.reply(e.GetActivityTaskCanceledEventAttributes().GetScheduledEventId(), "canceled")
+
+	case enumspb.EVENT_TYPE_TIMER_STARTED:
+		a := e.GetTimerStartedEventAttributes()
+		d.call(e, "Timer", "start timer "+a.GetTimerId()+" "+timestamp.DurationValue(a.GetStartToFireTimeout()).String())
+	case enumspb.EVENT_TYPE_TIMER_FIRED:
+		a := e.GetTimerFiredEventAttributes()
+		d.reply(a.GetStartedEventId(), "timer "+a.GetTimerId()+" fired")
+	case enumspb.EVENT_TYPE_TIMER_CANCELED:
+		a := e.GetTimerCanceledEventAttributes()
+		d.reply(a.GetStartedEventId(), "timer "+a.GetTimerId()+" canceled")
+
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+		d.call(e, "Child "+e.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetWorkflowType().GetName(), "start")
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED:
+		d.reply(e.GetStartChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventId(), "start failed")
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
+		d.reply(e.GetChildWorkflowExecutionCompletedEventAttributes().GetInitiatedEventId(), "completed")
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+		d.reply(e.GetChildWorkflowExecutionFailedEventAttributes().GetInitiatedEventId(), "failed")
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:
+		d.reply(e.GetChildWorkflowExecutionCanceledEventAttributes().GetInitiatedEventId(), "canceled")
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT:
+		d.reply(e.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventId(), "timed out")
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED:
+		d.reply(e.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventId(), "terminated")
+
+	case enumspb.EVENT_TYPE_SIGNAL_EXTERNAL_WORKFLOW_EXECUTION_INITIATED:
+		a := e.GetSignalExternalWorkflowExecutionInitiatedEventAttributes()
+		d.call(e, "External "+a.GetWorkflowExecution().GetWorkflowId(), "signal "+a.GetSignalName())
+	}
+}
+
+// alias is the identifier of the participant, as the names may have spaces and dots
+func (d *historyDiagram) alias(name string) string {
+	for i, p := range d.participants {
+		if p == name {
+			return "p" + strconv.Itoa(i)
+		}
+	}
+	return name
+}
+
+// mermaid renders a Mermaid sequence diagram
+func (d *historyDiagram) mermaid() string {
+	var b strings.Builder
+	b.WriteString("sequenceDiagram\n")
+	for _, p := range d.participants {
+		fmt.Fprintf(&b, "    participant %s as %s\n", d.alias(p), p)
+	}
+	for _, s := range d.steps {
+		switch {
+		case s.from == s.to:
+			fmt.Fprintf(&b, "    Note over %s: %s\n", d.alias(s.from), s.label)
+		case s.reply:
+			fmt.Fprintf(&b, "    %s-->>%s: %s\n", d.alias(s.from), d.alias(s.to), s.label)
+		default:
+			fmt.Fprintf(&b, "    %s->>%s: %s\n", d.alias(s.from), d.alias(s.to), s.label)
+		}
+	}
+	return b.String()
+}
+
+// dot renders a Graphviz digraph of the participants, the edges are numbered in the order of the steps
+func (d *historyDiagram) dot() string {
+	var b strings.Builder
+	b.WriteString("digraph workflow {\n")
+	for _, p := range d.participants {
+		fmt.Fprintf(&b, "    %s [label=%s shape=box];\n", d.alias(p), strconv.Quote(p))
+	}
+	for i, s := range d.steps {
+		label := strconv.Quote(strconv.Itoa(i+1) + ". " + s.label)
+		style := ""
+		if s.reply {
+			style = " style=dashed"
+		}
+		fmt.Fprintf(&b, "    %s -> %s [label=%s%s];\n", d.alias(s.from), d.alias(s.to), label, style)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
@@ -0,0 +1,331 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/server/common/codec"
+
+	"github.com/temporalio/tctl/pkg/color"
+	"github.com/temporalio/tctl/pkg/output"
+)
+
+const (
+	diffSame    = "same"
+	diffChanged = "changed"
+	diffMissing = "missing" // only in the source history
+	diffAdded   = "added"   // only in the target history
+
+	// histories larger than this (source events x target events) are compared by position instead of aligned
+	maxDiffAlignCells = 1 << 22
+	maxDiffValueLen   = 32
+)
+
+// diffVolatileFields differ between any two runs and are left out of the comparison
+var diffVolatileFields = map[string]bool{
+	"eventId":                         true,
+	"eventTime":                       true,
+	"eventType":                       true,
+	"version":                         true,
+	"taskId":                          true,
+	"identity":                        true,
+	"requestId":                       true,
+	"runId":                           true,
+	"originalExecutionRunId":          true,
+	"firstExecutionRunId":             true,
+	"continuedExecutionRunId":         true,
+	"newExecutionRunId":               true,
+	"prevAutoResetPoints":             true,
+	"workflowExecutionExpirationTime": true,
+}
+
+// diffRow is one aligned pair of events of workflow diff
+type diffRow struct {
+	SourceID int64
+	TargetID int64
+	Status   diffStatus
+	Source   string
+	Target   string
+	Changes  string
+}
+
+// DiffHistory compares the histories of two workflow runs event by event
+func DiffHistory(c *cli.Context) {
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	targetWid := wid
+	if c.IsSet(FlagTargetWorkflowID) {
+		targetWid = c.String(FlagTargetWorkflowID)
+	}
+	targetRid := getRequiredOption(c, FlagTargetRunID)
+
+	source := getHistoryEvents(c, wid, rid)
+	target := getHistoryEvents(c, targetWid, targetRid)
+
+	opts := &output.PrintOptions{
+		Fields:       []string{"SourceID", "TargetID", "Status", "Source", "Target", "Changes"},
+		ItemTemplate: diffRow{},
+	}
+	output.PrintItems(c, diffHistories(source, target), opts)
+}
+
+func getHistoryEvents(c *cli.Context, wid, rid string) []*historypb.HistoryEvent {
+	var events []*historypb.HistoryEvent
+	scanHistoryEvents(c, wid, rid, func(e *historypb.HistoryEvent) {
+		events = append(events, e)
+	})
+	return events
+}
+
+// scanHistoryEvents calls fn with the events of the history one page at a time, so that the whole
+// history is not held in memory
+func scanHistoryEvents(c *cli.Context, wid, rid string, fn func(*historypb.HistoryEvent)) {
+	req := newHistoryRequest(c, wid, rid)
+	for {
+		resp, err := getHistoryPage(c, req)
+		if err != nil {
+			ErrorAndExit("Unable to get workflow history.", err)
+		}
+		for _, e := range resp.GetHistory().GetEvents() {
+			fn(e)
+		}
+		if len(resp.NextPageToken) == 0 {
+			return
+		}
+		req.NextPageToken = resp.NextPageToken
+	}
+}
+
+// diffStatus is the status of an aligned pair. The first divergence is highlighted when rendered,
+// it is a plain string in json and the other structured outputs
+type diffStatus struct {
+	status string
+	first  bool
+}
+
+func (d diffStatus) String() string {
+	return d.status
+}
+
+func (d diffStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.status)
+}
+
+func init() {
+	output.RegisterFormatter(diffStatus{}, formatDiffStatus)
+}
+
+func formatDiffStatus(c *cli.Context, value interface{}) (string, bool) {
+	d := value.(diffStatus)
+	if !d.first {
+		return d.status, true
+	}
+	return color.Colorize(c, color.RoleFailed, "%s", d.status), true
+}
+
+// diffHistories aligns the events by type and compares the attributes of the aligned pairs.
+// The status of the first divergence is highlighted
+func diffHistories(source, target []*historypb.HistoryEvent) []interface{} {
+	var rows []interface{}
+	diverged := false
+	add := func(row diffRow) {
+		if row.Status.status != diffSame && !diverged {
+			diverged = true
+			row.Status.first = true
+		}
+		rows = append(rows, row)
+	}
+
+	for _, pair := range alignEvents(source, target) {
+		s, t := pair[0], pair[1]
+		switch {
+		case t == nil:
+			add(diffRow{SourceID: s.GetEventId(), Status: diffStatus{status: diffMissing}, Source: s.GetEventType().String()})
+		case s == nil:
+			add(diffRow{TargetID: t.GetEventId(), Status: diffStatus{status: diffAdded}, Target: t.GetEventType().String()})
+		default:
+			row := diffRow{
+				SourceID: s.GetEventId(),
+				TargetID: t.GetEventId(),
+				Status:   diffStatus{status: diffSame},
+				Source:   s.GetEventType().String(),
+				Target:   t.GetEventType().String(),
+			}
+			if changes := diffEvents(s, t); len(changes) > 0 || s.GetEventType() != t.GetEventType() {
+				row.Status.status = diffChanged
+				row.Changes = strings.Join(changes, "; ")
+			}
+			add(row)
+		}
+	}
+	return rows
+}
+
+// alignEvents pairs the events with the longest common subsequence of the event types, an event
+// without a pair has nil on the other side
+func alignEvents(source, target []*historypb.HistoryEvent) [][2]*historypb.HistoryEvent {
+	var pairs [][2]*historypb.HistoryEvent
+	n, m := len(source), len(target)
+	if n*m > maxDiffAlignCells {
+		for i := 0; i < n || i < m; i++ {
+			var pair [2]*historypb.HistoryEvent
+			if i < n {
+				pair[0] = source[i]
+			}
+			if i < m {
+				pair[1] = target[i]
+			}
+			pairs = append(pairs, pair)
+		}
+		return pairs
+	}
+
+	// lcs[i][j] is the length of the common subsequence of source[i:] and target[j:]
+	lcs := make([][]int32, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if source[i].GetEventType() == target[j].GetEventType() {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && source[i].GetEventType() == target[j].GetEventType():
+			pairs = append(pairs, [2]*historypb.HistoryEvent{source[i], target[j]})
+			i++
+			j++
+		case j == m || i < n && lcs[i+1][j] >= lcs[i][j+1]:
+			pairs = append(pairs, [2]*historypb.HistoryEvent{source[i], nil})
+			i++
+		default:
+			pairs = append(pairs, [2]*historypb.HistoryEvent{nil, target[j]})
+			j++
+		}
+	}
+	return pairs
+}
+
+// diffEvents lists the attributes that differ, payloads are compared by their hashes
+func diffEvents(source, target *historypb.HistoryEvent) []string {
+	s, t := flattenEvent(source), flattenEvent(target)
+	keys := make(map[string]bool)
+	for k := range s {
+		keys[k] = true
+	}
+	for k := range t {
+		keys[k] = true
+	}
+	var changes []string
+	for k := range keys {
+		if s[k] != t[k] {
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", k, trimDiffValue(s[k]), trimDiffValue(t[k])))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func flattenEvent(e *historypb.HistoryEvent) map[string]string {
+	out := make(map[string]string)
+	data, err := codec.NewJSONPBEncoder().Encode(e)
+	if err != nil {
+		return out
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return out
+	}
+	for k, v := range fields {
+		if diffVolatileFields[k] {
+			continue
+		}
+		if strings.HasSuffix(k, "EventAttributes") {
+			flattenJSON("", v, out)
+		} else {
+			flattenJSON(k, v, out)
+		}
+	}
+	return out
+}
+
+func flattenJSON(prefix string, v interface{}, out map[string]string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if data, ok := v["data"].(string); ok {
+			if _, ok := v["metadata"]; ok {
+				out[prefix] = payloadHash(data)
+				return
+			}
+		}
+		for k, f := range v {
+			if diffVolatileFields[k] {
+				continue
+			}
+			key := k
+			if prefix != "" {
+				key = prefix + "." + k
+			}
+			flattenJSON(key, f, out)
+		}
+	case []interface{}:
+		for i, f := range v {
+			flattenJSON(prefix+"["+strconv.Itoa(i)+"]", f, out)
+		}
+	default:
+		out[prefix] = fmt.Sprint(v)
+	}
+}
+
+func payloadHash(data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return "sha256:" + hex.EncodeToString(sum[:])[:12]
+}
+
+func trimDiffValue(v string) string {
+	if v == "" {
+		return "<none>"
+	}
+	if len(v) > maxDiffValueLen {
+		return v[:maxDiffValueLen] + "..."
+	}
+	return v
+}
@@ -0,0 +1,108 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/server/common/payloads"
+
+	"github.com/temporalio/tctl/pkg/color"
+)
+
+type historyDiffSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestHistoryDiffSuite(t *testing.T) {
+	suite.Run(t, new(historyDiffSuite))
+}
+
+func (s *historyDiffSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func activityScheduled(id int64, name string, input string) *historypb.HistoryEvent {
+	return &historypb.HistoryEvent{EventId: id, EventType: enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED, Attributes: &historypb.HistoryEvent_ActivityTaskScheduledEventAttributes{
+		ActivityTaskScheduledEventAttributes: &historypb.ActivityTaskScheduledEventAttributes{
+			ActivityType: &commonpb.ActivityType{Name: name},
+			Input:        payloads.EncodeString(input),
+		}}}
+}
+
+func timerStarted(id int64) *historypb.HistoryEvent {
+	return &historypb.HistoryEvent{EventId: id, EventType: enumspb.EVENT_TYPE_TIMER_STARTED, Attributes: &historypb.HistoryEvent_TimerStartedEventAttributes{
+		TimerStartedEventAttributes: &historypb.TimerStartedEventAttributes{TimerId: "1"}}}
+}
+
+func (s *historyDiffSuite) TestDiffHistories() {
+	source := []*historypb.HistoryEvent{activityScheduled(1, "Charge", "a"), timerStarted(2), activityScheduled(3, "Ship", "b")}
+	target := []*historypb.HistoryEvent{activityScheduled(1, "Charge", "a"), activityScheduled(2, "Ship", "c")}
+
+	rows := diffHistories(source, target)
+	s.Len(rows, 3)
+	s.Equal(diffStatus{status: diffSame}, rows[0].(diffRow).Status)
+
+	missing := rows[1].(diffRow)
+	s.Equal(diffStatus{status: diffMissing, first: true}, missing.Status)
+	s.Equal(int64(2), missing.SourceID)
+
+	changed := rows[2].(diffRow)
+	s.Equal(diffStatus{status: diffChanged}, changed.Status)
+	s.Equal(int64(3), changed.SourceID)
+	s.Equal(int64(2), changed.TargetID)
+	s.Contains(changed.Changes, "input.payloads[0]: sha256:")
+	s.NotContains(changed.Changes, "activityType")
+}
+
+func (s *historyDiffSuite) TestDiffStatus() {
+	status := diffStatus{status: diffChanged, first: true}
+	b, err := json.Marshal(diffRow{Status: status})
+	s.NoError(err)
+	s.Contains(string(b), `"Status":"changed"`)
+
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(color.FlagColor, "", "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+
+	s.NoError(set.Set(color.FlagColor, string(color.Never)))
+	text, ok := formatDiffStatus(c, status)
+	s.True(ok)
+	s.Equal(diffChanged, text)
+
+	s.NoError(set.Set(color.FlagColor, string(color.Always)))
+	text, _ = formatDiffStatus(c, status)
+	s.Equal("\x1b[31mchanged\x1b[0m", text)
+	text, _ = formatDiffStatus(c, diffStatus{status: diffSame})
+	s.Equal(diffSame, text, "only the first divergence is highlighted")
+}
@@ -0,0 +1,46 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/urfave/cli/v2"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/server/common/codec"
+)
+
+// exportHistory writes the whole history to the file in the JSON the SDK WorkflowReplayer reads
+func exportHistory(c *cli.Context, wid, rid, filename string) {
+	history := &historypb.History{Events: getHistoryEvents(c, wid, rid)}
+	data, err := codec.NewJSONPBIndentEncoder("  ").Encode(history)
+	if err != nil {
+		ErrorAndExit("Unable to serialize workflow history.", err)
+	}
+	if err := ioutil.WriteFile(filename, data, 0666); err != nil {
+		ErrorAndExit("Unable to write workflow history to file.", err)
+	}
+	fmt.Printf("Exported %d events to %s\n", len(history.Events), filename)
+}
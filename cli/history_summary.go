@@ -0,0 +1,225 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/server/common/payloads"
+	"go.temporal.io/server/common/primitives/timestamp"
+
+	"github.com/temporalio/tctl/pkg/output"
+)
+
+// summaryRow is one line of workflow show --summary: an activity, timer or child workflow from its
+// scheduling to its completion, or the workflow itself
+type summaryRow struct {
+	ID       int64
+	Kind     string
+	Name     string
+	Status   string
+	Attempt  int32
+	Duration time.Duration
+	Result   string
+
+	start  time.Time
+	closed bool
+}
+
+// historySummary collapses the events of each activity, timer and child workflow into a row
+type historySummary struct {
+	rows []*summaryRow
+	byID map[int64]*summaryRow // by the event id of the scheduling event
+}
+
+func newHistorySummary() *historySummary {
+	return &historySummary{byID: make(map[int64]*summaryRow)}
+}
+
+func showHistorySummary(c *cli.Context, wid, rid string) {
+	sdkClient := getSDKClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+
+	summary := newHistorySummary()
+	iter := sdkClient.GetWorkflowHistory(ctx, wid, rid, false, enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT)
+	for iter.HasNext() {
+		event, err := iter.Next()
+		if err != nil {
+			ErrorAndExit("Unable to show workflow history.", err)
+		}
+		summary.add(event)
+	}
+
+	opts := &output.PrintOptions{
+		Fields:       []string{"ID", "Kind", "Name", "Status", "Duration", "Result"},
+		FieldsLong:   []string{"Attempt"},
+		ItemTemplate: summaryRow{},
+	}
+	output.PrintItems(c, summary.items(time.Now()), opts)
+}
+
+func (s *historySummary) open(event *historypb.HistoryEvent, kind, name string) {
+	row := &summaryRow{
+		ID:     event.GetEventId(),
+		Kind:   kind,
+		Name:   name,
+		Status: "Scheduled",
+		start:  timestamp.TimeValue(event.GetEventTime()),
+	}
+	s.rows = append(s.rows, row)
+	s.byID[row.ID] = row
+}
+
+func (s *historySummary) update(id int64, status string, attempt int32) {
+	if row, ok := s.byID[id]; ok {
+		row.Status = status
+		if attempt > 0 {
+			row.Attempt = attempt
+		}
+	}
+}
+
+func (s *historySummary) close(event *historypb.HistoryEvent, id int64, status, result string) {
+	row, ok := s.byID[id]
+	if !ok {
+		return
+	}
+	row.Status = status
+	row.Result = result
+	row.Duration = timestamp.TimeValue(event.GetEventTime()).Sub(row.start)
+	row.closed = true
+}
+
+func (s *historySummary) add(e *historypb.HistoryEvent) {
+	switch e.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED:
+		s.open(e, "Workflow", e.GetWorkflowExecutionStartedEventAttributes().GetWorkflowType().GetName())
+		s.update(e.GetEventId(), "Running", e.GetWorkflowExecutionStartedEventAttributes().GetAttempt())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		s.closeWorkflow(e, "Completed", summaryPayloads(e.GetWorkflowExecutionCompletedEventAttributes().GetResult()))
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		s.closeWorkflow(e, "Failed", summaryFailure(e.GetWorkflowExecutionFailedEventAttributes().GetFailure()))
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		s.closeWorkflow(e, "TimedOut", "")
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		s.closeWorkflow(e, "Canceled", summaryPayloads(e.GetWorkflowExecutionCanceledEventAttributes().GetDetails()))
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		s.closeWorkflow(e, "Terminated", e.GetWorkflowExecutionTerminatedEventAttributes().GetReason())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+		s.closeWorkflow(e, "ContinuedAsNew", e.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId())
+
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_SCHEDULED:
+		s.open(e, "Activity", e.GetActivityTaskScheduledEventAttributes().GetActivityType().GetName())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_STARTED:
+		a := e.GetActivityTaskStartedEventAttributes()
+		s.update(a.GetScheduledEventId(), "Started", a.GetAttempt())
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_COMPLETED:
+		a := e.GetActivityTaskCompletedEventAttributes()
+		s.close(e, a.GetScheduledEventId(), "Completed", summaryPayloads(a.GetResult()))
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_FAILED:
+		a := e.GetActivityTaskFailedEventAttributes()
+		s.close(e, a.GetScheduledEventId(), "Failed", summaryFailure(a.GetFailure()))
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_TIMED_OUT:
+		a := e.GetActivityTaskTimedOutEventAttributes()
+		s.close(e, a.GetScheduledEventId(), "TimedOut", summaryFailure(a.GetFailure()))
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCEL_REQUESTED:
+		s.update(e.GetActivityTaskCancelRequestedEventAttributes().GetScheduledEventId(), "CancelRequested", 0)
+	case enumspb.EVENT_TYPE_ACTIVITY_TASK_CANCELED:
+		a := e.GetActivityTaskCanceledEventAttributes()
+		s.close(e, a.GetScheduledEventId(), "Canceled", summaryPayloads(a.GetDetails()))
+
+	case enumspb.EVENT_TYPE_TIMER_STARTED:
+		s.open(e, "Timer", e.GetTimerStartedEventAttributes().GetTimerId())
+		s.update(e.GetEventId(), "Started", 0)
+	case enumspb.EVENT_TYPE_TIMER_FIRED:
+		s.close(e, e.GetTimerFiredEventAttributes().GetStartedEventId(), "Fired", "")
+	case enumspb.EVENT_TYPE_TIMER_CANCELED:
+		s.close(e, e.GetTimerCanceledEventAttributes().GetStartedEventId(), "Canceled", "")
+
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_INITIATED:
+		s.open(e, "ChildWorkflow", e.GetStartChildWorkflowExecutionInitiatedEventAttributes().GetWorkflowType().GetName())
+	case enumspb.EVENT_TYPE_START_CHILD_WORKFLOW_EXECUTION_FAILED:
+		a := e.GetStartChildWorkflowExecutionFailedEventAttributes()
+		s.close(e, a.GetInitiatedEventId(), "StartFailed", a.GetCause().String())
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+		s.update(e.GetChildWorkflowExecutionStartedEventAttributes().GetInitiatedEventId(), "Started", 0)
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_COMPLETED:
+		a := e.GetChildWorkflowExecutionCompletedEventAttributes()
+		s.close(e, a.GetInitiatedEventId(), "Completed", summaryPayloads(a.GetResult()))
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_FAILED:
+		a := e.GetChildWorkflowExecutionFailedEventAttributes()
+		s.close(e, a.GetInitiatedEventId(), "Failed", summaryFailure(a.GetFailure()))
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_CANCELED:
+		a := e.GetChildWorkflowExecutionCanceledEventAttributes()
+		s.close(e, a.GetInitiatedEventId(), "Canceled", summaryPayloads(a.GetDetails()))
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TIMED_OUT:
+		s.close(e, e.GetChildWorkflowExecutionTimedOutEventAttributes().GetInitiatedEventId(), "TimedOut", "")
+	case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_TERMINATED:
+		s.close(e, e.GetChildWorkflowExecutionTerminatedEventAttributes().GetInitiatedEventId(), "Terminated", "")
+	}
+}
+
+// closeWorkflow closes the row of the workflow, started by the first event
+func (s *historySummary) closeWorkflow(event *historypb.HistoryEvent, status, result string) {
+	s.close(event, 1, status, result)
+}
+
+// items returns the rows to print, the ones still open last until now
+func (s *historySummary) items(now time.Time) []interface{} {
+	items := make([]interface{}, len(s.rows))
+	for i, row := range s.rows {
+		if !row.closed {
+			row.Duration = now.Sub(row.start)
+		}
+		items[i] = *row
+	}
+	return items
+}
+
+func summaryPayloads(p *commonpb.Payloads) string {
+	if p == nil {
+		return ""
+	}
+	return trimSummary(payloads.ToString(p))
+}
+
+func summaryFailure(f *failurepb.Failure) string {
+	if f == nil {
+		return ""
+	}
+	return trimSummary(f.GetMessage())
+}
+
+func trimSummary(s string) string {
+	if len(s) > maxOutputStringLength {
+		return s[:maxOutputStringLength] + "..."
+	}
+	return s
+}
@@ -28,6 +28,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/temporalio/tctl/pkg/flags"
 	"github.com/urfave/cli/v2"
 )
 
@@ -102,11 +103,46 @@ func newNamespaceCommands() []*cli.Command {
 			Name:    "list",
 			Aliases: []string{"l"},
 			Usage:   "List all namespaces",
-			Flags:   listNamespacesFlags,
+			Flags:   append(listNamespacesFlags, flags.FlagsForListing...),
 			Action: func(c *cli.Context) error {
 				ListNamespaces(c)
 				return nil
 			},
 		},
+		{
+			Name:  "failover",
+			Usage: "Make another cluster of a global namespace active",
+			Flags: failoverNamespaceFlags,
+			Action: func(c *cli.Context) error {
+				FailoverNamespace(c)
+				return nil
+			},
+		},
+		{
+			Name:  "list-replication",
+			Usage: "List the active cluster and failover version of the namespaces",
+			Flags: append(listNamespaceReplicationFlags, flags.FlagsForListing...),
+			Action: func(c *cli.Context) error {
+				ListNamespaceReplication(c)
+				return nil
+			},
+		},
+		{
+			Name:  "export",
+			Usage: "Print the namespace configuration as YAML, ex. tctl namespace export -n foo > foo.yaml",
+			Action: func(c *cli.Context) error {
+				ExportNamespace(c)
+				return nil
+			},
+		},
+		{
+			Name:  "apply",
+			Usage: "Update or register the namespace to match a configuration file written by export",
+			Flags: applyNamespaceFlags,
+			Action: func(c *cli.Context) error {
+				ApplyNamespace(c)
+				return nil
+			},
+		},
 	}
 }
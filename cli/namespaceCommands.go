@@ -30,6 +30,7 @@ import (
 	"strconv"
 
 	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
 	"github.com/urfave/cli/v2"
 	enumspb "go.temporal.io/api/enums/v1"
 	namespacepb "go.temporal.io/api/namespace/v1"
@@ -134,9 +135,6 @@ func UpdateNamespace(c *cli.Context) {
 	client := cFactory.FrontendClient(c)
 
 	var updateRequest *workflowservice.UpdateNamespaceRequest
-	ctx, cancel := newContext(c)
-	defer cancel()
-
 	if c.IsSet(FlagActiveClusterName) {
 		activeCluster := c.String(FlagActiveClusterName)
 		fmt.Printf("Will set active cluster name to: %s, other flag will be omitted.\n", activeCluster)
@@ -148,9 +146,11 @@ func UpdateNamespace(c *cli.Context) {
 			ReplicationConfig: replicationConfig,
 		}
 	} else {
+		ctx, cancel := newContext(c)
 		resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
 			Namespace: namespace,
 		})
+		cancel()
 		if err != nil {
 			if _, ok := err.(*serviceerror.NotFound); !ok {
 				ErrorAndExit("Operation UpdateNamespace failed.", err)
@@ -200,7 +200,7 @@ func UpdateNamespace(c *cli.Context) {
 		var binBinaries *namespacepb.BadBinaries
 		if c.IsSet(FlagAddBadBinary) {
 			if !c.IsSet(FlagReason) {
-				ErrorAndExit("Must provide a reason.", nil)
+				process.UsageErrorAndExit("Must provide a reason.")
 			}
 			binChecksum := c.String(FlagAddBadBinary)
 			reason := c.String(FlagReason)
@@ -243,8 +243,19 @@ func UpdateNamespace(c *cli.Context) {
 			ReplicationConfig: replicationConfig,
 			DeleteBadBinary:   badBinaryToDelete,
 		}
+
+		changes := diffNamespaceUpdate(resp, updateRequest)
+		if len(changes) == 0 {
+			fmt.Printf("Namespace %s is already up to date.\n", namespace)
+			return
+		}
+		printNamespaceChanges(c, changes)
+		prompt("Update namespace? [y/N]", c.Bool(FlagYes) || c.Bool(FlagAutoConfirm))
 	}
 
+	// the context is created after the confirmation, which may take longer than its timeout
+	ctx, cancel := newContext(c)
+	defer cancel()
 	_, err := client.UpdateNamespace(ctx, updateRequest)
 	if err != nil {
 		if _, ok := err.(*serviceerror.NotFound); !ok {
@@ -263,10 +274,10 @@ func DescribeNamespace(c *cli.Context) {
 	namespaceID := c.String(FlagNamespaceID)
 
 	if namespaceID == "" && namespace == "" {
-		ErrorAndExit("At least namespace_id or namespace must be provided.", nil)
+		process.UsageErrorAndExit("At least namespace_id or namespace must be provided.")
 	}
 	if c.IsSet(FlagNamespace) && namespaceID != "" {
-		ErrorAndExit("Only one of namespace_id or namespace must be provided.", nil)
+		process.UsageErrorAndExit("Only one of namespace_id or namespace must be provided.")
 	}
 	if namespaceID != "" {
 		namespace = ""
@@ -290,15 +301,98 @@ func DescribeNamespace(c *cli.Context) {
 	printNamespace(c, resp)
 }
 
+func describeNamespaceOrExit(c *cli.Context, namespace string) *workflowservice.DescribeNamespaceResponse {
+	client := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		if _, ok := err.(*serviceerror.NotFound); !ok {
+			ErrorAndExit("Operation DescribeNamespace failed.", err)
+		}
+		ErrorAndExit(fmt.Sprintf("Namespace %s does not exist.", namespace), err)
+	}
+	return resp
+}
+
 // ListNamespaces list all namespaces
 func ListNamespaces(c *cli.Context) {
+	filter := newNamespaceFilter(c)
+	paginationFunc := namespacesPaginationFunc(c, func(ns *workflowservice.DescribeNamespaceResponse) interface{} {
+		if !filter.matches(ns) {
+			return nil
+		}
+		return ns
+	})
 
+	iter := output.NewPagingIterator(c, paginationFunc)
+	opts := &output.PrintOptions{
+		Fields:       []string{"NamespaceInfo.Name", "NamespaceInfo.State", "Config.WorkflowExecutionRetentionTtl", "ReplicationConfig.ActiveClusterName"},
+		FieldsLong:   []string{"NamespaceInfo.Id", "NamespaceInfo.OwnerEmail", "Config.HistoryArchivalState", "Config.VisibilityArchivalState", "IsGlobalNamespace"},
+		ItemTemplate: &workflowservice.DescribeNamespaceResponse{},
+	}
+	if err := output.Pager(c, iter, opts); err != nil {
+		ErrorAndExit("Unable to list namespaces.", err)
+	}
+}
+
+// namespacesPaginationFunc lists the namespaces page by page, converting each with toItem. Namespaces converted to nil are skipped
+func namespacesPaginationFunc(c *cli.Context, toItem func(*workflowservice.DescribeNamespaceResponse) interface{}) output.PaginationFunc {
 	client := cFactory.FrontendClient(c)
-	for _, ns := range getAllNamespaces(c, client) {
-		printNamespace(c, ns)
+	return func(npt []byte) ([]interface{}, []byte, error) {
+		ctx, cancel := newContext(c)
+		defer cancel()
+		resp, err := client.ListNamespaces(ctx, &workflowservice.ListNamespacesRequest{
+			PageSize:      200,
+			NextPageToken: npt,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		var items []interface{}
+		for _, ns := range resp.GetNamespaces() {
+			if item := toItem(ns); item != nil {
+				items = append(items, item)
+			}
+		}
+		return items, resp.GetNextPageToken(), nil
+	}
+}
+
+// namespaceFilter keeps the namespaces matching the state flags of namespace list. Unspecified values match any namespace
+type namespaceFilter struct {
+	state                   enumspb.NamespaceState
+	historyArchivalState    enumspb.ArchivalState
+	visibilityArchivalState enumspb.ArchivalState
+}
+
+func newNamespaceFilter(c *cli.Context) namespaceFilter {
+	state, err := stringToEnum(c.String(FlagNamespaceState), enumspb.NamespaceState_value)
+	if err != nil {
+		ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", FlagNamespaceState), err)
+	}
+	return namespaceFilter{
+		state:                   enumspb.NamespaceState(state),
+		historyArchivalState:    archivalState(c, FlagHistoryArchivalState),
+		visibilityArchivalState: archivalState(c, FlagVisibilityArchivalState),
 	}
 }
 
+func (f namespaceFilter) matches(ns *workflowservice.DescribeNamespaceResponse) bool {
+	if f.state != enumspb.NAMESPACE_STATE_UNSPECIFIED && ns.GetNamespaceInfo().GetState() != f.state {
+		return false
+	}
+	if f.historyArchivalState != enumspb.ARCHIVAL_STATE_UNSPECIFIED && ns.GetConfig().GetHistoryArchivalState() != f.historyArchivalState {
+		return false
+	}
+	if f.visibilityArchivalState != enumspb.ARCHIVAL_STATE_UNSPECIFIED && ns.GetConfig().GetVisibilityArchivalState() != f.visibilityArchivalState {
+		return false
+	}
+	return true
+}
+
 func printNamespace(c *cli.Context, resp *workflowservice.DescribeNamespaceResponse) {
 	opts := &output.PrintOptions{
 		Fields:     []string{"NamespaceInfo.Name", "NamespaceInfo.Id", "NamespaceInfo.Description", "NamespaceInfo.OwnerEmail", "NamespaceInfo.State", "Config.WorkflowExecutionRetentionTtl", "ReplicationConfig.ActiveClusterName", "ReplicationConfig.Clusters", "Config.HistoryArchivalState", "Config.VisibilityArchivalState"},
@@ -332,27 +426,6 @@ func printNamespace(c *cli.Context, resp *workflowservice.DescribeNamespaceRespo
 	output.PrintItems(c, badBinaries, bOpts)
 }
 
-func getAllNamespaces(c *cli.Context, tClient workflowservice.WorkflowServiceClient) []*workflowservice.DescribeNamespaceResponse {
-	var res []*workflowservice.DescribeNamespaceResponse
-	pagesize := int32(200)
-	var token []byte
-	ctx, cancel := newContext(c)
-	defer cancel()
-	for more := true; more; more = len(token) > 0 {
-		listRequest := &workflowservice.ListNamespacesRequest{
-			PageSize:      pagesize,
-			NextPageToken: token,
-		}
-		listResp, err := tClient.ListNamespaces(ctx, listRequest)
-		if err != nil {
-			ErrorAndExit("Error when list namespaces info", err)
-		}
-		token = listResp.GetNextPageToken()
-		res = append(res, listResp.GetNamespaces()...)
-	}
-	return res
-}
-
 func clustersToString(clusters []*replicationpb.ClusterReplicationConfig) string {
 	var res string
 	for i, cluster := range clusters {
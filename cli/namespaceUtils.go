@@ -45,6 +45,8 @@ import (
 	"go.temporal.io/server/common/persistence"
 	"go.temporal.io/server/common/persistence/client"
 	"go.temporal.io/server/common/resolver"
+
+	"github.com/temporalio/tctl/pkg/process"
 )
 
 const (
@@ -158,6 +160,10 @@ var (
 			Name:  FlagReason,
 			Usage: "Reason for the operation",
 		},
+		&cli.BoolFlag{
+			Name:  FlagYes,
+			Usage: "Update the namespace without the confirmation prompt",
+		},
 	}
 
 	describeNamespaceFlags = []cli.Flag{
@@ -167,7 +173,53 @@ var (
 		},
 	}
 
-	listNamespacesFlags = []cli.Flag{}
+	listNamespacesFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:  FlagNamespaceState,
+			Usage: "List only the namespaces in the given state, valid values are \"registered\", \"deprecated\" and \"deleted\"",
+		},
+		&cli.StringFlag{
+			Name:    FlagHistoryArchivalState,
+			Aliases: []string{"has"},
+			Usage:   "List only the namespaces with the given history archival state, valid values are \"disabled\" and \"enabled\"",
+		},
+		&cli.StringFlag{
+			Name:    FlagVisibilityArchivalState,
+			Aliases: []string{"vas"},
+			Usage:   "List only the namespaces with the given visibility archival state, valid values are \"disabled\" and \"enabled\"",
+		},
+	}
+
+	applyNamespaceFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:    FlagNamespaceFile,
+			Aliases: []string{"f"},
+			Usage:   "Namespace configuration file in the format of namespace export",
+		},
+		&cli.BoolFlag{
+			Name:  FlagYes,
+			Usage: "Apply the configuration without the confirmation prompt",
+		},
+	}
+
+	failoverNamespaceFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:    FlagActiveClusterName,
+			Aliases: []string{"ac"},
+			Usage:   "Cluster to make active for the namespace",
+		},
+		&cli.BoolFlag{
+			Name:  FlagYes,
+			Usage: "Fail over without the confirmation prompt",
+		},
+	}
+
+	listNamespaceReplicationFlags = []cli.Flag{
+		&cli.BoolFlag{
+			Name:  FlagGlobalOnly,
+			Usage: "List only the global namespaces",
+		},
+	}
 
 	adminNamespaceCommonFlags = []cli.Flag{
 		&cli.StringFlag{
@@ -403,7 +455,7 @@ func getZone(c *cli.Context) string {
 func getConfigDir(c *cli.Context) string {
 	dirPath := c.String(FlagServiceConfigDir)
 	if len(dirPath) == 0 {
-		ErrorAndExit("Must provide service configuration dir path.", nil)
+		process.UsageErrorAndExit("Must provide service configuration dir path.")
 	}
 	return dirPath
 }
@@ -0,0 +1,117 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/urfave/cli/v2"
+	replicationpb "go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+type namespaceReplicationRow struct {
+	Name              string
+	IsGlobalNamespace bool
+	ActiveClusterName string
+	Clusters          string
+	FailoverVersion   int64
+}
+
+// FailoverNamespace makes another cluster of a global namespace active
+func FailoverNamespace(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	activeCluster := getRequiredOption(c, FlagActiveClusterName)
+	resp := describeNamespaceOrExit(c, namespace)
+	if err := validateFailover(resp, activeCluster); err != nil {
+		ErrorAndExit(fmt.Sprintf("Unable to fail over namespace %s.", namespace), err)
+	}
+	current := resp.GetReplicationConfig().GetActiveClusterName()
+	if current == activeCluster {
+		fmt.Printf("Cluster %s is already active for namespace %s.\n", activeCluster, namespace)
+		return
+	}
+
+	printNamespaceChanges(c, []namespaceChangeRow{{Field: "ActiveClusterName", Before: current, After: activeCluster}})
+	prompt(fmt.Sprintf("Fail over namespace %s? [y/N]", namespace), c.Bool(FlagYes) || c.Bool(FlagAutoConfirm))
+
+	client := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := client.UpdateNamespace(ctx, &workflowservice.UpdateNamespaceRequest{
+		Namespace: namespace,
+		ReplicationConfig: &replicationpb.NamespaceReplicationConfig{
+			ActiveClusterName: activeCluster,
+		},
+	})
+	if err != nil {
+		ErrorAndExit("Operation UpdateNamespace failed.", err)
+	}
+
+	resp = describeNamespaceOrExit(c, namespace)
+	fmt.Printf("Namespace %s failed over to %s, failover version %d.\n", namespace,
+		resp.GetReplicationConfig().GetActiveClusterName(), resp.GetFailoverVersion())
+}
+
+// ListNamespaceReplication lists the active cluster and failover version of every namespace
+func ListNamespaceReplication(c *cli.Context) {
+	paginationFunc := namespacesPaginationFunc(c, func(ns *workflowservice.DescribeNamespaceResponse) interface{} {
+		if c.Bool(FlagGlobalOnly) && !ns.GetIsGlobalNamespace() {
+			return nil
+		}
+		return newNamespaceReplicationRow(ns)
+	})
+
+	iter := output.NewPagingIterator(c, paginationFunc)
+	opts := &output.PrintOptions{
+		Fields:       []string{"Name", "IsGlobalNamespace", "ActiveClusterName", "Clusters", "FailoverVersion"},
+		ItemTemplate: &namespaceReplicationRow{},
+	}
+	if err := output.Pager(c, iter, opts); err != nil {
+		ErrorAndExit("Unable to list namespaces.", err)
+	}
+}
+
+func newNamespaceReplicationRow(ns *workflowservice.DescribeNamespaceResponse) namespaceReplicationRow {
+	return namespaceReplicationRow{
+		Name:              ns.GetNamespaceInfo().GetName(),
+		IsGlobalNamespace: ns.GetIsGlobalNamespace(),
+		ActiveClusterName: ns.GetReplicationConfig().GetActiveClusterName(),
+		Clusters:          clustersToString(ns.GetReplicationConfig().GetClusters()),
+		FailoverVersion:   ns.GetFailoverVersion(),
+	}
+}
+
+func validateFailover(ns *workflowservice.DescribeNamespaceResponse, activeCluster string) error {
+	if !ns.GetIsGlobalNamespace() {
+		return fmt.Errorf("namespace %s is not a global namespace", ns.GetNamespaceInfo().GetName())
+	}
+	for _, cluster := range ns.GetReplicationConfig().GetClusters() {
+		if cluster.GetClusterName() == activeCluster {
+			return nil
+		}
+	}
+	return fmt.Errorf("cluster %s is not one of the namespace clusters: %s", activeCluster, clustersToString(ns.GetReplicationConfig().GetClusters()))
+}
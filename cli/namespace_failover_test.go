@@ -0,0 +1,74 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	replicationpb "go.temporal.io/api/replication/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+type namespaceFailoverSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestNamespaceFailoverSuite(t *testing.T) {
+	suite.Run(t, new(namespaceFailoverSuite))
+}
+
+func (s *namespaceFailoverSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *namespaceFailoverSuite) TestValidateFailover() {
+	ns := &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespacepb.NamespaceInfo{Name: "orders"},
+		ReplicationConfig: &replicationpb.NamespaceReplicationConfig{
+			ActiveClusterName: "clusterA",
+			Clusters: []*replicationpb.ClusterReplicationConfig{
+				{ClusterName: "clusterA"},
+				{ClusterName: "clusterB"},
+			},
+		},
+		FailoverVersion:   12,
+		IsGlobalNamespace: true,
+	}
+	s.NoError(validateFailover(ns, "clusterB"))
+	s.EqualError(validateFailover(ns, "clusterC"), "cluster clusterC is not one of the namespace clusters: clusterA, clusterB")
+	s.Equal(namespaceReplicationRow{
+		Name:              "orders",
+		IsGlobalNamespace: true,
+		ActiveClusterName: "clusterA",
+		Clusters:          "clusterA, clusterB",
+		FailoverVersion:   12,
+	}, newNamespaceReplicationRow(ns))
+
+	ns.IsGlobalNamespace = false
+	s.EqualError(validateFailover(ns, "clusterB"), "namespace orders is not a global namespace")
+}
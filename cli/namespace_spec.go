@@ -0,0 +1,288 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"gopkg.in/yaml.v3"
+
+	"go.temporal.io/server/common/primitives/timestamp"
+	"go.temporal.io/server/common/searchattribute"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// namespaceSpec is the declarative configuration of a namespace written by namespace export and read by namespace apply
+type namespaceSpec struct {
+	Name               string            `yaml:"name"`
+	Description        string            `yaml:"description,omitempty"`
+	OwnerEmail         string            `yaml:"ownerEmail,omitempty"`
+	Retention          string            `yaml:"retention,omitempty"`
+	HistoryArchival    archivalSpec      `yaml:"historyArchival,omitempty"`
+	VisibilityArchival archivalSpec      `yaml:"visibilityArchival,omitempty"`
+	Data               map[string]string `yaml:"data,omitempty"`
+	SearchAttributes   map[string]string `yaml:"searchAttributes,omitempty"`
+}
+
+type archivalSpec struct {
+	State string `yaml:"state,omitempty"`
+	URI   string `yaml:"uri,omitempty"`
+}
+
+// ExportNamespace prints the configuration of a namespace as YAML that can be passed to namespace apply
+func ExportNamespace(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	resp := describeNamespaceOrExit(c, namespace)
+
+	spec := newNamespaceSpec(resp, getCustomSearchAttributes(c))
+	encoder := yaml.NewEncoder(os.Stdout)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(spec); err != nil {
+		ErrorAndExit("Unable to encode namespace configuration.", err)
+	}
+	encoder.Close()
+}
+
+// ApplyNamespace updates the namespace to match the configuration file, registering it when it does not exist
+func ApplyNamespace(c *cli.Context) {
+	spec := readNamespaceSpec(getRequiredOption(c, FlagNamespaceFile))
+	client := cFactory.FrontendClient(c)
+	autoConfirm := c.Bool(FlagYes) || c.Bool(FlagAutoConfirm)
+
+	checkSearchAttributesRegistered(spec.SearchAttributes, getCustomSearchAttributes(c))
+
+	ctx, cancel := newContext(c)
+	resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: spec.Name,
+	})
+	cancel()
+	if _, ok := err.(*serviceerror.NotFound); ok {
+		empty := &workflowservice.DescribeNamespaceResponse{}
+		request := spec.updateRequest(empty)
+		printNamespaceChanges(c, diffNamespaceUpdate(empty, request))
+		prompt(fmt.Sprintf("Namespace %s does not exist. Register it? [y/N]", spec.Name), autoConfirm)
+
+		// the contexts are created after the confirmation, which may take longer than their timeout
+		ctx, cancel := newContext(c)
+		defer cancel()
+		if _, err := client.RegisterNamespace(ctx, spec.registerRequest(request)); err != nil {
+			ErrorAndExit("Register namespace operation failed.", err)
+		}
+		fmt.Printf("Namespace %s successfully registered.\n", spec.Name)
+		return
+	}
+	if err != nil {
+		ErrorAndExit("Operation DescribeNamespace failed.", err)
+	}
+
+	request := spec.updateRequest(resp)
+	changes := diffNamespaceUpdate(resp, request)
+	if len(changes) == 0 {
+		fmt.Printf("Namespace %s is already up to date.\n", spec.Name)
+		return
+	}
+	printNamespaceChanges(c, changes)
+	prompt("Update namespace? [y/N]", autoConfirm)
+
+	ctx, cancel = newContext(c)
+	defer cancel()
+	if _, err := client.UpdateNamespace(ctx, request); err != nil {
+		ErrorAndExit("Operation UpdateNamespace failed.", err)
+	}
+	fmt.Printf("Namespace %s successfully updated.\n", spec.Name)
+}
+
+func newNamespaceSpec(resp *workflowservice.DescribeNamespaceResponse, searchAttributes map[string]enumspb.IndexedValueType) *namespaceSpec {
+	info := resp.GetNamespaceInfo()
+	config := resp.GetConfig()
+	spec := &namespaceSpec{
+		Name:        info.GetName(),
+		Description: info.GetDescription(),
+		OwnerEmail:  info.GetOwnerEmail(),
+		Retention:   formatRetention(timestamp.DurationValue(config.GetWorkflowExecutionRetentionTtl())),
+		HistoryArchival: archivalSpec{
+			State: formatArchivalState(config.GetHistoryArchivalState()),
+			URI:   config.GetHistoryArchivalUri(),
+		},
+		VisibilityArchival: archivalSpec{
+			State: formatArchivalState(config.GetVisibilityArchivalState()),
+			URI:   config.GetVisibilityArchivalUri(),
+		},
+		Data: info.GetData(),
+	}
+	if len(searchAttributes) > 0 {
+		spec.SearchAttributes = make(map[string]string, len(searchAttributes))
+		for name, t := range searchAttributes {
+			spec.SearchAttributes[name] = t.String()
+		}
+	}
+	return spec
+}
+
+func readNamespaceSpec(path string) *namespaceSpec {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		ErrorAndExit("Unable to read namespace configuration.", err)
+	}
+	var spec namespaceSpec
+	if err := yaml.Unmarshal(b, &spec); err != nil {
+		ErrorAndExit(fmt.Sprintf("Namespace configuration %s is invalid.", path), err)
+	}
+	if spec.Name == "" {
+		process.UsageErrorAndExit(fmt.Sprintf("Namespace configuration %s is missing the name.", path))
+	}
+	return &spec
+}
+
+// updateRequest builds the request changing the current namespace to the spec. Values missing from the spec are kept
+func (s *namespaceSpec) updateRequest(current *workflowservice.DescribeNamespaceResponse) *workflowservice.UpdateNamespaceRequest {
+	info := current.GetNamespaceInfo()
+	config := current.GetConfig()
+
+	description := info.GetDescription()
+	if s.Description != "" {
+		description = s.Description
+	}
+	ownerEmail := info.GetOwnerEmail()
+	if s.OwnerEmail != "" {
+		ownerEmail = s.OwnerEmail
+	}
+	retention := timestamp.DurationValue(config.GetWorkflowExecutionRetentionTtl())
+	if s.Retention != "" {
+		var err error
+		retention, err = timestamp.ParseDurationDefaultDays(s.Retention)
+		if err != nil {
+			ErrorAndExit("Namespace retention is invalid.", err)
+		}
+	}
+
+	return &workflowservice.UpdateNamespaceRequest{
+		Namespace: s.Name,
+		UpdateInfo: &namespacepb.UpdateNamespaceInfo{
+			Description: description,
+			OwnerEmail:  ownerEmail,
+			Data:        s.Data,
+		},
+		Config: &namespacepb.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: &retention,
+			HistoryArchivalState:          parseArchivalState("historyArchival.state", s.HistoryArchival.State),
+			HistoryArchivalUri:            s.HistoryArchival.URI,
+			VisibilityArchivalState:       parseArchivalState("visibilityArchival.state", s.VisibilityArchival.State),
+			VisibilityArchivalUri:         s.VisibilityArchival.URI,
+		},
+	}
+}
+
+func (s *namespaceSpec) registerRequest(update *workflowservice.UpdateNamespaceRequest) *workflowservice.RegisterNamespaceRequest {
+	retention := defaultNamespaceRetention
+	if s.Retention != "" {
+		retention = timestamp.DurationValue(update.GetConfig().GetWorkflowExecutionRetentionTtl())
+	}
+	return &workflowservice.RegisterNamespaceRequest{
+		Namespace:                        s.Name,
+		Description:                      update.GetUpdateInfo().GetDescription(),
+		OwnerEmail:                       update.GetUpdateInfo().GetOwnerEmail(),
+		Data:                             update.GetUpdateInfo().GetData(),
+		WorkflowExecutionRetentionPeriod: &retention,
+		HistoryArchivalState:             update.GetConfig().GetHistoryArchivalState(),
+		HistoryArchivalUri:               update.GetConfig().GetHistoryArchivalUri(),
+		VisibilityArchivalState:          update.GetConfig().GetVisibilityArchivalState(),
+		VisibilityArchivalUri:            update.GetConfig().GetVisibilityArchivalUri(),
+	}
+}
+
+// getCustomSearchAttributes returns the search attributes of the cluster without the system and predefined ones
+func getCustomSearchAttributes(c *cli.Context) map[string]enumspb.IndexedValueType {
+	sdkClient := getSDKClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := sdkClient.GetSearchAttributes(ctx)
+	if err != nil {
+		ErrorAndExit("Unable to get search attributes.", err)
+	}
+
+	custom := make(map[string]enumspb.IndexedValueType)
+	for name, t := range resp.GetKeys() {
+		if !searchattribute.IsReserved(name) {
+			custom[name] = t
+		}
+	}
+	return custom
+}
+
+// checkSearchAttributesRegistered exits when the cluster misses any of the search attributes of the spec.
+// Search attributes are registered per cluster by its administrator, so apply cannot add them
+func checkSearchAttributesRegistered(want map[string]string, registered map[string]enumspb.IndexedValueType) {
+	var problems []string
+	for name, t := range want {
+		got, ok := registered[name]
+		if !ok {
+			problems = append(problems, fmt.Sprintf("%s is not registered", name))
+		} else if !strings.EqualFold(got.String(), t) {
+			problems = append(problems, fmt.Sprintf("%s is %s, not %s", name, got.String(), t))
+		}
+	}
+	if len(problems) > 0 {
+		sort.Strings(problems)
+		process.UsageErrorAndExit("Search attributes do not match the cluster: " + strings.Join(problems, ", ") + ".")
+	}
+}
+
+func formatRetention(d time.Duration) string {
+	if d > 0 && d%(24*time.Hour) == 0 {
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	}
+	return d.String()
+}
+
+func formatArchivalState(state enumspb.ArchivalState) string {
+	if state == enumspb.ARCHIVAL_STATE_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(state.String())
+}
+
+func parseArchivalState(field, value string) enumspb.ArchivalState {
+	switch strings.ToLower(value) {
+	case "":
+		return enumspb.ARCHIVAL_STATE_UNSPECIFIED
+	case "disabled":
+		return enumspb.ARCHIVAL_STATE_DISABLED
+	case "enabled":
+		return enumspb.ARCHIVAL_STATE_ENABLED
+	}
+	ErrorAndExit(fmt.Sprintf("Namespace configuration %s is invalid.", field), fmt.Errorf("invalid state %q, valid values are \"disabled\" and \"enabled\"", value))
+	return enumspb.ARCHIVAL_STATE_UNSPECIFIED
+}
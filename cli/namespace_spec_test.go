@@ -0,0 +1,94 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"gopkg.in/yaml.v3"
+)
+
+type namespaceSpecSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestNamespaceSpecSuite(t *testing.T) {
+	suite.Run(t, new(namespaceSpecSuite))
+}
+
+func (s *namespaceSpecSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *namespaceSpecSuite) TestExportApplyRoundTrip() {
+	retention := 72 * time.Hour
+	resp := &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespacepb.NamespaceInfo{
+			Name:        "orders",
+			Description: "order processing",
+			Data:        map[string]string{"team": "billing"},
+		},
+		Config: &namespacepb.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: &retention,
+			HistoryArchivalState:          enumspb.ARCHIVAL_STATE_ENABLED,
+			HistoryArchivalUri:            "file:///tmp/archival",
+			VisibilityArchivalState:       enumspb.ARCHIVAL_STATE_DISABLED,
+		},
+	}
+	spec := newNamespaceSpec(resp, map[string]enumspb.IndexedValueType{"CustomerTier": enumspb.INDEXED_VALUE_TYPE_KEYWORD})
+
+	b, err := yaml.Marshal(spec)
+	s.NoError(err)
+	s.Equal(`name: orders
+description: order processing
+retention: 3d
+historyArchival:
+    state: enabled
+    uri: file:///tmp/archival
+visibilityArchival:
+    state: disabled
+data:
+    team: billing
+searchAttributes:
+    CustomerTier: Keyword
+`, string(b))
+
+	var applied namespaceSpec
+	s.NoError(yaml.Unmarshal(b, &applied))
+	s.Empty(diffNamespaceUpdate(resp, applied.updateRequest(resp)))
+
+	applied.Retention = "7d"
+	applied.Data = map[string]string{"tier": "gold"}
+	s.Equal([]namespaceChangeRow{
+		{Field: "Retention", Before: "72h0m0s", After: "168h0m0s"},
+		{Field: "Data.tier", Before: "", After: "gold"},
+	}, diffNamespaceUpdate(resp, applied.updateRequest(resp)))
+}
@@ -0,0 +1,122 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+type namespaceChangeRow struct {
+	Field  string
+	Before string
+	After  string
+}
+
+// diffNamespaceUpdate lists the fields of the namespace that the update request would change
+func diffNamespaceUpdate(current *workflowservice.DescribeNamespaceResponse, req *workflowservice.UpdateNamespaceRequest) []namespaceChangeRow {
+	var rows []namespaceChangeRow
+	add := func(field, before, after string) {
+		if before != after {
+			rows = append(rows, namespaceChangeRow{Field: field, Before: before, After: after})
+		}
+	}
+
+	info := current.GetNamespaceInfo()
+	config := current.GetConfig()
+	updateInfo := req.GetUpdateInfo()
+	updateConfig := req.GetConfig()
+
+	if updateInfo != nil {
+		add("Description", info.GetDescription(), updateInfo.GetDescription())
+		add("OwnerEmail", info.GetOwnerEmail(), updateInfo.GetOwnerEmail())
+	}
+	if updateConfig.GetWorkflowExecutionRetentionTtl() != nil {
+		add("Retention",
+			timestamp.DurationValue(config.GetWorkflowExecutionRetentionTtl()).String(),
+			timestamp.DurationValue(updateConfig.GetWorkflowExecutionRetentionTtl()).String())
+	}
+	if state := updateConfig.GetHistoryArchivalState(); state != enumspb.ARCHIVAL_STATE_UNSPECIFIED {
+		add("HistoryArchivalState", config.GetHistoryArchivalState().String(), state.String())
+	}
+	if uri := updateConfig.GetHistoryArchivalUri(); uri != "" {
+		add("HistoryArchivalUri", config.GetHistoryArchivalUri(), uri)
+	}
+	if state := updateConfig.GetVisibilityArchivalState(); state != enumspb.ARCHIVAL_STATE_UNSPECIFIED {
+		add("VisibilityArchivalState", config.GetVisibilityArchivalState().String(), state.String())
+	}
+	if uri := updateConfig.GetVisibilityArchivalUri(); uri != "" {
+		add("VisibilityArchivalUri", config.GetVisibilityArchivalUri(), uri)
+	}
+
+	// namespace data is merged on the server, so only the given keys can change
+	var keys []string
+	for k := range updateInfo.GetData() {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		add("Data."+k, info.GetData()[k], updateInfo.GetData()[k])
+	}
+
+	var checksums []string
+	for cs := range updateConfig.GetBadBinaries().GetBinaries() {
+		checksums = append(checksums, cs)
+	}
+	sort.Strings(checksums)
+	for _, cs := range checksums {
+		before := config.GetBadBinaries().GetBinaries()[cs].GetReason()
+		add("BadBinaries."+cs, before, updateConfig.GetBadBinaries().GetBinaries()[cs].GetReason())
+	}
+	if cs := req.GetDeleteBadBinary(); cs != "" {
+		add("BadBinaries."+cs, config.GetBadBinaries().GetBinaries()[cs].GetReason(), "")
+	}
+
+	if clusters := req.GetReplicationConfig().GetClusters(); len(clusters) > 0 {
+		add("Clusters", clustersToString(current.GetReplicationConfig().GetClusters()), clustersToString(clusters))
+	}
+
+	return rows
+}
+
+func printNamespaceChanges(c *cli.Context, rows []namespaceChangeRow) {
+	var items []interface{}
+	for _, r := range rows {
+		items = append(items, r)
+	}
+	opts := &output.PrintOptions{
+		Fields:      []string{"Field", "Before", "After"},
+		IgnoreFlags: true,
+		NoPager:     true,
+	}
+	output.PrintItems(c, items, opts)
+	fmt.Println()
+}
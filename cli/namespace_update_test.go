@@ -0,0 +1,92 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	namespacepb "go.temporal.io/api/namespace/v1"
+	"go.temporal.io/api/workflowservice/v1"
+)
+
+type namespaceUpdateSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestNamespaceUpdateSuite(t *testing.T) {
+	suite.Run(t, new(namespaceUpdateSuite))
+}
+
+func (s *namespaceUpdateSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *namespaceUpdateSuite) TestDiffNamespaceUpdate() {
+	retention := 24 * time.Hour
+	current := &workflowservice.DescribeNamespaceResponse{
+		NamespaceInfo: &namespacepb.NamespaceInfo{
+			Description: "orders",
+			Data:        map[string]string{"team": "billing"},
+		},
+		Config: &namespacepb.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: &retention,
+			HistoryArchivalState:          enumspb.ARCHIVAL_STATE_DISABLED,
+			BadBinaries: &namespacepb.BadBinaries{Binaries: map[string]*namespacepb.BadBinaryInfo{
+				"abc": {Reason: "crash"},
+			}},
+		},
+	}
+
+	newRetention := 72 * time.Hour
+	req := &workflowservice.UpdateNamespaceRequest{
+		UpdateInfo: &namespacepb.UpdateNamespaceInfo{
+			Description: "orders",
+			Data:        map[string]string{"team": "billing", "tier": "gold"},
+		},
+		Config: &namespacepb.NamespaceConfig{
+			WorkflowExecutionRetentionTtl: &newRetention,
+			HistoryArchivalState:          enumspb.ARCHIVAL_STATE_ENABLED,
+			HistoryArchivalUri:            "file:///tmp/archival",
+		},
+		DeleteBadBinary: "abc",
+	}
+
+	s.Equal([]namespaceChangeRow{
+		{Field: "Retention", Before: "24h0m0s", After: "72h0m0s"},
+		{Field: "HistoryArchivalState", Before: "Disabled", After: "Enabled"},
+		{Field: "HistoryArchivalUri", Before: "", After: "file:///tmp/archival"},
+		{Field: "Data.tier", Before: "", After: "gold"},
+		{Field: "BadBinaries.abc", Before: "crash", After: ""},
+	}, diffNamespaceUpdate(current, req))
+
+	req.Config = &namespacepb.NamespaceConfig{WorkflowExecutionRetentionTtl: &retention}
+	req.UpdateInfo.Data = nil
+	req.DeleteBadBinary = ""
+	s.Empty(diffNamespaceUpdate(current, req))
+}
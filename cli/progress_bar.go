@@ -0,0 +1,122 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/temporalio/tctl/pkg/pager"
+)
+
+const (
+	progressBarWidth    = 30
+	progressBarInterval = 100 * time.Millisecond
+)
+
+// progressBar redraws the number of the processed items with an ETA on a line of stderr.
+// It is nil when stderr is not a terminal, and all its methods do nothing then
+type progressBar struct {
+	mu         sync.Mutex
+	out        io.Writer
+	done       int64
+	total      int64
+	start      time.Time
+	lastRender time.Time
+	now        func() time.Time
+}
+
+func newProgressBar(total int64) *progressBar {
+	if !pager.IsTerminal(os.Stderr) {
+		return nil
+	}
+	return &progressBar{out: os.Stderr, total: total, start: time.Now(), now: time.Now}
+}
+
+// Add counts n more processed items
+func (b *progressBar) Add(n int64) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done += n
+	b.render(false)
+}
+
+// Set replaces the counts, for the progress reported by a server side job
+func (b *progressBar) Set(done, total int64) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.done, b.total = done, total
+	b.render(false)
+}
+
+// Finish draws the final counts and ends the line
+func (b *progressBar) Finish() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.render(true)
+	fmt.Fprintln(b.out)
+}
+
+func (b *progressBar) render(force bool) {
+	now := b.now()
+	if !force && b.done < b.total && now.Sub(b.lastRender) < progressBarInterval {
+		return
+	}
+	b.lastRender = now
+	fmt.Fprint(b.out, "\r"+b.line(now))
+}
+
+func (b *progressBar) line(now time.Time) string {
+	if b.total <= 0 {
+		return fmt.Sprintf("%d processed", b.done)
+	}
+	done := b.done
+	if done > b.total {
+		done = b.total
+	}
+	filled := int(done * progressBarWidth / b.total)
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", progressBarWidth-filled)
+
+	eta := "?"
+	if done == b.total {
+		eta = "0s"
+	} else if done > 0 {
+		elapsed := now.Sub(b.start)
+		eta = (elapsed * time.Duration(b.total-done) / time.Duration(done)).Round(time.Second).String()
+	}
+	return fmt.Sprintf("[%s] %d/%d %3d%% ETA %s", bar, b.done, b.total, done*100/b.total, eta)
+}
@@ -0,0 +1,257 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/searchattribute"
+)
+
+// queryBuilder assembles a visibility query from the attribute, operator and value of each condition
+// the user enters. An entry is completed when it is a unique prefix of the candidates
+type queryBuilder struct {
+	in    *bufio.Reader
+	out   io.Writer
+	types map[string]enumspb.IndexedValueType
+}
+
+var (
+	queryJoins              = []string{"AND", "OR"}
+	queryEqualityOperators  = []string{"=", "!="}
+	queryComparisonOperator = []string{"=", "!=", ">", ">=", "<", "<=", "BETWEEN"}
+)
+
+// buildQueryInteractively prompts for the conditions of a visibility query with the search attributes
+// registered in the cluster. Prompts are written to stderr to keep stdout for the results
+func buildQueryInteractively(c *cli.Context) string {
+	sdkClient := getSDKClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := sdkClient.GetSearchAttributes(ctx)
+	if err != nil {
+		ErrorAndExit("Unable to get search attributes.", err)
+	}
+
+	b := &queryBuilder{
+		in:    bufio.NewReader(os.Stdin),
+		out:   os.Stderr,
+		types: resp.GetKeys(),
+	}
+	query, err := b.run()
+	if err != nil {
+		ErrorAndExit("Unable to build the query.", err)
+	}
+	fmt.Fprintf(os.Stderr, "Query: %s\n", query)
+	return query
+}
+
+func (b *queryBuilder) run() (string, error) {
+	var query string
+	join := ""
+	for {
+		cond, err := b.condition()
+		if err != nil {
+			return "", err
+		}
+		if cond == "" {
+			break
+		}
+		if query == "" {
+			query = cond
+		} else {
+			query = fmt.Sprintf("%s %s %s", query, join, cond)
+		}
+
+		join, err = b.complete("Combine with another condition", queryJoins, strings.Join(queryJoins, ", ")+", empty to finish", true)
+		if err != nil {
+			return "", err
+		}
+		if join == "" {
+			break
+		}
+	}
+
+	if query == "" {
+		return "", errors.New("no conditions entered")
+	}
+	return query, nil
+}
+
+// condition returns a condition of the query, empty if the user finished the query
+func (b *queryBuilder) condition() (string, error) {
+	names := make([]string, 0, len(b.types))
+	for name := range b.types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	attr, err := b.complete("Attribute", names, "? to list, empty to finish", true)
+	if err != nil || attr == "" {
+		return "", err
+	}
+	t := b.types[attr]
+
+	operators := queryComparisonOperator
+	if t == enumspb.INDEXED_VALUE_TYPE_KEYWORD || t == enumspb.INDEXED_VALUE_TYPE_STRING || t == enumspb.INDEXED_VALUE_TYPE_BOOL {
+		operators = queryEqualityOperators
+	}
+	op, err := b.complete("Operator", operators, strings.Join(operators, " "), false)
+	if err != nil {
+		return "", err
+	}
+
+	if op == "BETWEEN" {
+		from, err := b.value("From", attr, t)
+		if err != nil {
+			return "", err
+		}
+		to, err := b.value("To", attr, t)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s BETWEEN %s AND %s", attr, from, to), nil
+	}
+
+	val, err := b.value("Value", attr, t)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s %s %s", attr, op, val), nil
+}
+
+// value reads a value of the attribute until it matches the type and returns it formatted for the query
+func (b *queryBuilder) value(label, attr string, t enumspb.IndexedValueType) (string, error) {
+	if attr == searchattribute.ExecutionStatus {
+		var statuses []string
+		for s := enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING; s <= enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT; s++ {
+			statuses = append(statuses, s.String())
+		}
+		status, err := b.complete(label, statuses, strings.Join(statuses, ", "), false)
+		if err != nil {
+			return "", err
+		}
+		return quoteQueryValue(status), nil
+	}
+
+	hint := t.String()
+	if t == enumspb.INDEXED_VALUE_TYPE_DATETIME {
+		hint = "RFC3339, ex. 2021-06-07T17:16:34Z"
+	}
+	for {
+		line, err := b.prompt(label, hint)
+		if err != nil {
+			return "", err
+		}
+		if line == "" {
+			continue
+		}
+		val, err := parseSearchAttributeScalar(line, t)
+		if err != nil {
+			fmt.Fprintf(b.out, "  invalid %s value: %v\n", t, err)
+			continue
+		}
+		return formatQueryValue(val), nil
+	}
+}
+
+// complete reads an entry until it matches exactly one of the candidates, case insensitive and by prefix
+func (b *queryBuilder) complete(label string, candidates []string, hint string, allowEmpty bool) (string, error) {
+	for {
+		line, err := b.prompt(label, hint)
+		if err != nil {
+			return "", err
+		}
+		switch line {
+		case "":
+			if allowEmpty {
+				return "", nil
+			}
+			continue
+		case "?":
+			fmt.Fprintf(b.out, "  %s\n", strings.Join(candidates, ", "))
+			continue
+		}
+
+		matches := completeCandidates(line, candidates)
+		switch len(matches) {
+		case 0:
+			fmt.Fprintf(b.out, "  no match for %s, enter ? to list\n", line)
+		case 1:
+			if matches[0] != line {
+				fmt.Fprintf(b.out, "  %s\n", matches[0])
+			}
+			return matches[0], nil
+		default:
+			fmt.Fprintf(b.out, "  ambiguous: %s\n", strings.Join(matches, ", "))
+		}
+	}
+}
+
+func (b *queryBuilder) prompt(label, hint string) (string, error) {
+	fmt.Fprintf(b.out, "%s (%s): ", label, hint)
+	line, err := b.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// completeCandidates returns the candidate equal to the entry or else all the candidates it prefixes
+func completeCandidates(entry string, candidates []string) []string {
+	var matches []string
+	for _, candidate := range candidates {
+		if strings.EqualFold(candidate, entry) {
+			return []string{candidate}
+		}
+		if strings.HasPrefix(strings.ToLower(candidate), strings.ToLower(entry)) {
+			matches = append(matches, candidate)
+		}
+	}
+	return matches
+}
+
+func formatQueryValue(val interface{}) string {
+	switch v := val.(type) {
+	case string:
+		return quoteQueryValue(v)
+	case time.Time:
+		return quoteQueryValue(v.Format(time.RFC3339Nano))
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func quoteQueryValue(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "\\'") + "'"
+}
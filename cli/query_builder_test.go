@@ -0,0 +1,91 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+)
+
+type queryBuilderSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestQueryBuilderSuite(t *testing.T) {
+	suite.Run(t, new(queryBuilderSuite))
+}
+
+func (s *queryBuilderSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *queryBuilderSuite) build(input string) (string, string, error) {
+	var out bytes.Buffer
+	b := &queryBuilder{
+		in:  bufio.NewReader(strings.NewReader(input)),
+		out: &out,
+		types: map[string]enumspb.IndexedValueType{
+			"ExecutionStatus": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+			"ExecutionTime":   enumspb.INDEXED_VALUE_TYPE_DATETIME,
+			"StartTime":       enumspb.INDEXED_VALUE_TYPE_DATETIME,
+			"WorkflowType":    enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+			"Attempts":        enumspb.INDEXED_VALUE_TYPE_INT,
+		},
+	}
+	query, err := b.run()
+	return query, out.String(), err
+}
+
+func (s *queryBuilderSuite) TestRun() {
+	query, out, err := s.build(strings.Join([]string{
+		"execution", "ExecutionStatus", "=", "run",
+		"and",
+		"Start", ">", "yesterday", "2021-06-07T17:16:34Z",
+		"or",
+		"atT", "between", "1", "3",
+		"", "",
+	}, "\n"))
+	s.NoError(err)
+	s.Equal("ExecutionStatus = 'Running' AND StartTime > '2021-06-07T17:16:34Z' OR Attempts BETWEEN 1 AND 3", query)
+	s.Contains(out, "ambiguous: ExecutionStatus, ExecutionTime")
+	s.Contains(out, "invalid Datetime value")
+}
+
+func (s *queryBuilderSuite) TestRun_Quoting() {
+	query, _, err := s.build("WorkflowType\n!=\nit's\n\n")
+	s.NoError(err)
+	s.Equal(`WorkflowType != 'it\'s'`, query)
+}
+
+func (s *queryBuilderSuite) TestRun_Empty() {
+	_, _, err := s.build("\n")
+	s.Error(err)
+}
@@ -0,0 +1,156 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"strings"
+	"sync"
+)
+
+const (
+	resetDone    = "Reset"
+	resetDryRun  = "DryRun"
+	resetSkipped = "Skipped"
+	resetFailed  = "Failed"
+
+	resetProgressSeparator = "\t"
+)
+
+// resetOutcome is what reset-batch did with a workflow
+type resetOutcome struct {
+	Status    string
+	Reason    string
+	BaseRunId string
+	EventId   int64
+	NewRunId  string
+}
+
+// resetReportLine is a line of the NDJSON --report-file of reset-batch
+type resetReportLine struct {
+	WorkflowId string `json:"workflowId"`
+	RunId      string `json:"runId,omitempty"`
+	Status     string `json:"status"`
+	Reason     string `json:"reason,omitempty"`
+	BaseRunId  string `json:"baseRunId,omitempty"`
+	EventId    int64  `json:"eventId,omitempty"`
+	NewRunId   string `json:"newRunId,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// resetReport records the processed workflows of reset-batch in the progress and report files,
+// any of them may be nil
+type resetReport struct {
+	sync.Mutex
+	progress *os.File
+	report   *json.Encoder
+	files    []*os.File
+}
+
+func newResetReport(progressFile, reportFile string) *resetReport {
+	r := &resetReport{}
+	if progressFile != "" {
+		r.progress = r.open(progressFile, os.O_APPEND)
+	}
+	if reportFile != "" {
+		r.report = json.NewEncoder(r.open(reportFile, os.O_TRUNC))
+	}
+	return r
+}
+
+func (r *resetReport) open(name string, mode int) *os.File {
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|mode, 0666)
+	if err != nil {
+		ErrorAndExit("Unable to open file.", err)
+	}
+	r.files = append(r.files, f)
+	return f
+}
+
+// add records the workflow as processed unless it failed or was a dry run, so that a rerun with the
+// progress file skips it
+func (r *resetReport) add(wid, rid string, outcome resetOutcome, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	if err == nil && outcome.Status != resetDryRun && r.progress != nil {
+		_, _ = r.progress.WriteString(resetProgressKey(wid, rid) + "\n")
+	}
+	if r.report != nil {
+		line := resetReportLine{
+			WorkflowId: wid,
+			RunId:      rid,
+			Status:     outcome.Status,
+			Reason:     outcome.Reason,
+			BaseRunId:  outcome.BaseRunId,
+			EventId:    outcome.EventId,
+			NewRunId:   outcome.NewRunId,
+		}
+		if err != nil {
+			line.Status = resetFailed
+			line.Error = err.Error()
+		}
+		_ = r.report.Encode(line)
+	}
+}
+
+func (r *resetReport) close() {
+	for _, f := range r.files {
+		_ = f.Close()
+	}
+}
+
+func resetProgressKey(wid, rid string) string {
+	return wid + resetProgressSeparator + rid
+}
+
+// readProgressFile returns the lines of the progress file of reset-batch or a bulk command, which may not exist yet
+func readProgressFile(progressFile string) map[string]bool {
+	processed := make(map[string]bool)
+	if progressFile == "" {
+		return processed
+	}
+	// This code is only used in the CLI. The input provided is from a trusted user.
+	// #nosec
+	f, err := os.Open(progressFile)
+	if os.IsNotExist(err) {
+		return processed
+	} else if err != nil {
+		ErrorAndExit("Unable to open progress file.", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), "\r"); line != "" {
+			processed[line] = true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		ErrorAndExit("Unable to read progress file.", err)
+	}
+	return processed
+}
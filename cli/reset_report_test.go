@@ -0,0 +1,69 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"errors"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type resetReportSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestResetReportSuite(t *testing.T) {
+	suite.Run(t, new(resetReportSuite))
+}
+
+func (s *resetReportSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *resetReportSuite) TestProgressAndReport() {
+	dir := s.T().TempDir()
+	progressFile := filepath.Join(dir, "progress")
+	reportFile := filepath.Join(dir, "report.ndjson")
+	s.Empty(readProgressFile(progressFile))
+
+	report := newResetReport(progressFile, reportFile)
+	report.add("wf-1", "", resetOutcome{Status: resetDone, BaseRunId: "r1", EventId: 4, NewRunId: "r2"}, nil)
+	report.add("wf-2", "r3", resetOutcome{}, errors.New("not found"))
+	report.add("wf-3", "", resetOutcome{Status: resetDryRun}, nil)
+	report.close()
+
+	s.Equal(map[string]bool{resetProgressKey("wf-1", ""): true}, readProgressFile(progressFile))
+
+	data, err := ioutil.ReadFile(reportFile)
+	s.NoError(err)
+	s.Equal(`{"workflowId":"wf-1","status":"Reset","baseRunId":"r1","eventId":4,"newRunId":"r2"}
+{"workflowId":"wf-2","runId":"r3","status":"Failed","error":"not found"}
+{"workflowId":"wf-3","status":"DryRun"}
+`, string(data))
+}
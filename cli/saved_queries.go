@@ -0,0 +1,134 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/color"
+	"github.com/temporalio/tctl/pkg/config"
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// savedQueryKey is the config sequence the named visibility queries are kept in, ex. query.stuck
+const savedQueryKey = "query"
+
+type savedQueryRow struct {
+	Name  string
+	Query string
+}
+
+// SaveQuery saves a visibility query under a name to be used with --saved-query
+func SaveQuery(c *cli.Context) error {
+	if c.NArg() != 2 {
+		process.UsageErrorAndExit("invalid number of args, expected 2: query name and query")
+	}
+
+	name := c.Args().Get(0)
+	query := c.Args().Get(1)
+	if strings.Contains(name, ".") {
+		process.UsageErrorAndExit(fmt.Sprintf("invalid query name %v, it must not contain dots", name))
+	}
+
+	if err := config.Set(savedQueryKey+"."+name, query); err != nil {
+		ErrorAndExit(fmt.Sprintf("unable to save query %v.", name), err)
+	}
+
+	fmt.Printf("%v: %v\n", color.Magenta(c, "%v", name), query)
+	return nil
+}
+
+// ListSavedQueries prints the saved visibility queries
+func ListSavedQueries(c *cli.Context) error {
+	queries, _ := config.GetSequence(savedQueryKey)
+
+	names := make([]string, 0, len(queries))
+	for name := range queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	items := make([]interface{}, len(names))
+	for i, name := range names {
+		items[i] = savedQueryRow{Name: name, Query: queries[name]}
+	}
+	opts := &output.PrintOptions{
+		Fields: []string{"Name", "Query"},
+	}
+	output.PrintItems(c, items, opts)
+	return nil
+}
+
+// DeleteSavedQuery removes a saved visibility query
+func DeleteSavedQuery(c *cli.Context) error {
+	if c.NArg() != 1 {
+		process.UsageErrorAndExit("invalid number of args, expected 1: query name")
+	}
+
+	name := c.Args().Get(0)
+	if err := config.Delete(savedQueryKey + "." + name); err != nil {
+		ErrorAndExit(fmt.Sprintf("unable to delete query %v.", name), err)
+	}
+
+	fmt.Printf("Deleted query %v\n", color.Magenta(c, "%v", name))
+	return nil
+}
+
+// getListQuery returns the visibility query of --query, combined with the query saved under the name of --saved-query
+func getListQuery(c *cli.Context) string {
+	query := c.String(FlagListQuery)
+	if !c.IsSet(FlagSavedQuery) {
+		return query
+	}
+
+	name := c.String(FlagSavedQuery)
+	queries, _ := config.GetSequence(savedQueryKey)
+	saved, ok := queries[name]
+	if !ok {
+		process.UsageErrorAndExit(fmt.Sprintf("no saved query %v, see tctl config list-queries", name))
+	}
+	return combineQueries(saved, query)
+}
+
+// combineQueries joins the non empty visibility queries with AND
+func combineQueries(queries ...string) string {
+	var parts []string
+	for _, q := range queries {
+		if strings.TrimSpace(q) != "" {
+			parts = append(parts, q)
+		}
+	}
+	if len(parts) == 1 {
+		return parts[0]
+	}
+	for i, p := range parts {
+		parts[i] = "(" + p + ")"
+	}
+	return strings.Join(parts, " AND ")
+}
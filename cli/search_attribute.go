@@ -0,0 +1,100 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/flags"
+)
+
+func newSearchAttributeCommands() []*cli.Command {
+	return []*cli.Command{
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List the custom and system search attributes of the cluster",
+			Flags: append([]cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "Elasticsearch index name, defaults to the visibility index of the cluster",
+				},
+			}, flags.FlagsForViewing...),
+			Action: func(c *cli.Context) error {
+				ListClusterSearchAttributes(c)
+				return nil
+			},
+		},
+		{
+			Name:  "add",
+			Usage: "Add a custom search attribute and wait until it can be used in queries",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagName,
+					Usage: "Search attribute name, ex. CustomFoo",
+				},
+				&cli.StringFlag{
+					Name:  FlagSearchAttributeType,
+					Usage: "Search attribute type: " + searchAttributeTypeNames(),
+				},
+				&cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "Elasticsearch index name, defaults to the visibility index of the cluster",
+				},
+				&cli.DurationFlag{
+					Name:  FlagTimeout,
+					Value: defaultSearchAttributeTimeout,
+					Usage: "How long to wait for the search attribute to become queryable",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				AddSearchAttribute(c)
+				return nil
+			},
+		},
+		{
+			Name:    "remove",
+			Aliases: []string{"rm"},
+			Usage:   "Remove a custom search attribute. The data indexed for it is kept",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  FlagName,
+					Usage: "Search attribute name",
+				},
+				&cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "Elasticsearch index name, defaults to the visibility index of the cluster",
+				},
+				&cli.BoolFlag{
+					Name:  FlagYes,
+					Usage: "Remove without the confirmation prompt",
+				},
+			},
+			Action: func(c *cli.Context) error {
+				RemoveSearchAttribute(c)
+				return nil
+			},
+		},
+	}
+}
@@ -0,0 +1,194 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/api/adminservice/v1"
+	"go.temporal.io/server/common/rpc"
+
+	"github.com/temporalio/tctl/pkg/output"
+)
+
+const (
+	defaultSearchAttributeTimeout = 2 * time.Minute
+	searchAttributePollInterval   = time.Second
+)
+
+type searchAttributeRow struct {
+	Name string
+	Type string
+	Kind string
+}
+
+// ListClusterSearchAttributes lists the search attributes registered in the cluster
+func ListClusterSearchAttributes(c *cli.Context) {
+	client := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := client.GetSearchAttributes(ctx, &adminservice.GetSearchAttributesRequest{
+		IndexName: c.String(FlagIndex),
+	})
+	if err != nil {
+		ErrorAndExit("Unable to get search attributes.", err)
+	}
+
+	opts := &output.PrintOptions{
+		Fields:       []string{"Name", "Type", "Kind"},
+		ItemTemplate: &searchAttributeRow{},
+		NoPager:      true,
+	}
+	output.PrintItems(c, searchAttributeRows(resp), opts)
+}
+
+// AddSearchAttribute adds a custom search attribute and waits for the frontend to accept it in queries
+func AddSearchAttribute(c *cli.Context) {
+	name := getRequiredOption(c, FlagName)
+	typeInt, err := stringToEnum(getRequiredOption(c, FlagSearchAttributeType), enumspb.IndexedValueType_value)
+	if err != nil || typeInt == int32(enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED) {
+		ErrorAndExit(fmt.Sprintf("Option %s format is invalid, valid values are %s.", FlagSearchAttributeType, searchAttributeTypeNames()), err)
+	}
+	saType := enumspb.IndexedValueType(typeInt)
+	index := c.String(FlagIndex)
+	client := cFactory.AdminClient(c)
+
+	ctx, cancel := rpc.NewContextWithTimeoutAndCLIHeaders(c.Duration(FlagTimeout))
+	defer cancel()
+
+	reqCtx, reqCancel := context.WithTimeout(ctx, defaultContextTimeout)
+	_, err = client.AddSearchAttributes(reqCtx, &adminservice.AddSearchAttributesRequest{
+		SearchAttributes: map[string]enumspb.IndexedValueType{name: saType},
+		IndexName:        index,
+	})
+	reqCancel()
+	if err != nil {
+		ErrorAndExit("Unable to add search attribute.", err)
+	}
+	fmt.Printf("Adding search attribute %s of type %s.\n", name, saType)
+
+	// the attribute is added by a system workflow updating the index mapping and the cluster metadata
+	waitForSearchAttribute(ctx, "Search attribute was not added before the timeout.", func(reqCtx context.Context) (bool, error) {
+		resp, err := client.GetSearchAttributes(reqCtx, &adminservice.GetSearchAttributesRequest{IndexName: index})
+		if err != nil {
+			return false, err
+		}
+		switch resp.GetAddWorkflowExecutionInfo().GetStatus() {
+		case enumspb.WORKFLOW_EXECUTION_STATUS_UNSPECIFIED, enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		default:
+			return false, fmt.Errorf("adding search attributes workflow is %s", resp.GetAddWorkflowExecutionInfo().GetStatus())
+		}
+		return resp.GetCustomAttributes()[name] == saType, nil
+	})
+
+	// the frontend validates the queries against its cache of the search attributes, which is refreshed periodically
+	sdkClient := getSDKClient(c)
+	waitForSearchAttribute(ctx, "Search attribute was added, but is not queryable yet.", func(reqCtx context.Context) (bool, error) {
+		resp, err := sdkClient.GetSearchAttributes(reqCtx)
+		if err != nil {
+			return false, err
+		}
+		return resp.GetKeys()[name] == saType, nil
+	})
+	fmt.Printf("Search attribute %s of type %s is added.\n", name, saType)
+}
+
+// RemoveSearchAttribute removes a custom search attribute from the cluster metadata
+func RemoveSearchAttribute(c *cli.Context) {
+	name := getRequiredOption(c, FlagName)
+	prompt(fmt.Sprintf("Remove search attribute %s? Workflows will not be able to use it [y/N]", name), c.Bool(FlagYes) || c.Bool(FlagAutoConfirm))
+
+	client := cFactory.AdminClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	_, err := client.RemoveSearchAttributes(ctx, &adminservice.RemoveSearchAttributesRequest{
+		SearchAttributes: []string{name},
+		IndexName:        c.String(FlagIndex),
+	})
+	if err != nil {
+		ErrorAndExit("Unable to remove search attribute.", err)
+	}
+	fmt.Printf("Search attribute %s is removed.\n", name)
+}
+
+// waitForSearchAttribute polls done until it returns true, exits with msg if ctx expires first
+func waitForSearchAttribute(ctx context.Context, msg string, done func(ctx context.Context) (bool, error)) {
+	for {
+		reqCtx, reqCancel := context.WithTimeout(ctx, defaultContextTimeout)
+		ok, err := done(reqCtx)
+		reqCancel()
+		if err != nil && ctx.Err() == nil {
+			ErrorAndExit(msg, err)
+		}
+		if ok {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			ErrorAndExit(msg, nil)
+		case <-time.After(searchAttributePollInterval):
+		}
+	}
+}
+
+func searchAttributeRows(resp *adminservice.GetSearchAttributesResponse) []interface{} {
+	var rows []searchAttributeRow
+	for name, t := range resp.GetCustomAttributes() {
+		rows = append(rows, searchAttributeRow{Name: name, Type: t.String(), Kind: "Custom"})
+	}
+	for name, t := range resp.GetSystemAttributes() {
+		rows = append(rows, searchAttributeRow{Name: name, Type: t.String(), Kind: "System"})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Kind != rows[j].Kind {
+			return rows[i].Kind < rows[j].Kind
+		}
+		return rows[i].Name < rows[j].Name
+	})
+
+	items := make([]interface{}, len(rows))
+	for i, r := range rows {
+		items[i] = r
+	}
+	return items
+}
+
+func searchAttributeTypeNames() string {
+	var names []string
+	for t, name := range enumspb.IndexedValueType_name {
+		if t != int32(enumspb.INDEXED_VALUE_TYPE_UNSPECIFIED) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
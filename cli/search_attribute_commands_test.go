@@ -0,0 +1,68 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"go.temporal.io/server/api/adminservice/v1"
+)
+
+type searchAttributeSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestSearchAttributeSuite(t *testing.T) {
+	suite.Run(t, new(searchAttributeSuite))
+}
+
+func (s *searchAttributeSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *searchAttributeSuite) TestSearchAttributeRows() {
+	resp := &adminservice.GetSearchAttributesResponse{
+		CustomAttributes: map[string]enumspb.IndexedValueType{
+			"CustomTier":  enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+			"CustomCount": enumspb.INDEXED_VALUE_TYPE_INT,
+		},
+		SystemAttributes: map[string]enumspb.IndexedValueType{
+			"WorkflowType": enumspb.INDEXED_VALUE_TYPE_KEYWORD,
+		},
+	}
+	s.Equal([]interface{}{
+		searchAttributeRow{Name: "CustomCount", Type: "Int", Kind: "Custom"},
+		searchAttributeRow{Name: "CustomTier", Type: "Keyword", Kind: "Custom"},
+		searchAttributeRow{Name: "WorkflowType", Type: "Keyword", Kind: "System"},
+	}, searchAttributeRows(resp))
+}
+
+func (s *searchAttributeSuite) TestSearchAttributeTypeNames() {
+	s.Equal("Bool, Datetime, Double, Int, Keyword, String", searchAttributeTypeNames())
+}
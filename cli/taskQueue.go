@@ -25,11 +25,7 @@
 package cli
 
 import (
-	"fmt"
-
-	"github.com/temporalio/tctl/pkg/color"
 	"github.com/temporalio/tctl/pkg/flags"
-	"github.com/temporalio/tctl/pkg/output"
 	"github.com/urfave/cli/v2"
 )
 
@@ -38,7 +34,7 @@ func newTaskQueueCommands() []*cli.Command {
 		{
 			Name:    "describe",
 			Aliases: []string{"desc"},
-			Usage:   "Describe pollers info of task queue",
+			Usage:   "Describe pollers, backlog status and partitions of task queue",
 			Flags: append([]cli.Flag{
 				&cli.StringFlag{
 					Name:  FlagTaskQueueWithAlias,
@@ -46,35 +42,23 @@ func newTaskQueueCommands() []*cli.Command {
 				},
 				&cli.StringFlag{
 					Name:  FlagTaskQueueTypeWithAlias,
-					Value: "workflow",
-					Usage: "Optional TaskQueue type [workflow|activity]",
+					Usage: "Optional TaskQueue type [workflow|activity]. Both types are described by default, set it to workflow to only describe the workflow task queue",
 				},
-			}, flags.FlagsForRendering...),
+			}, flags.FlagsForViewing...),
 			Action: func(c *cli.Context) error {
 				return DescribeTaskQueue(c)
 			},
 		},
 		{
 			Name:    "list-partition",
-			Aliases: []string{"lp"},
-			Usage:   "List all the taskqueue partitions and the hostname for partitions.",
-			Flags: []cli.Flag{
+			Aliases: []string{"lp", "list-partitions"},
+			Usage:   "List all the taskqueue partitions and the hostname for partitions, with the number of partitions per host.",
+			Flags: append([]cli.Flag{
 				&cli.StringFlag{
 					Name:  FlagTaskQueueWithAlias,
 					Usage: "TaskQueue description",
 				},
-				&cli.StringFlag{
-					Name:    output.FlagOutput,
-					Aliases: []string{"o"},
-					Usage:   output.UsageText,
-					Value:   string(output.Table),
-				},
-				&cli.StringFlag{
-					Name:  color.FlagColor,
-					Usage: fmt.Sprintf("when to use color: %v, %v, %v.", color.Auto, color.Always, color.Never),
-					Value: string(color.Auto),
-				},
-			},
+			}, flags.FlagsForViewing...),
 			Action: func(c *cli.Context) error {
 				return ListTaskQueuePartitions(c)
 			},
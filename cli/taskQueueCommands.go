@@ -26,6 +26,7 @@ package cli
 
 import (
 	"fmt"
+	"time"
 
 	enumspb "go.temporal.io/api/enums/v1"
 	taskqueuepb "go.temporal.io/api/taskqueue/v1"
@@ -36,76 +37,221 @@ import (
 	"github.com/urfave/cli/v2"
 )
 
-// DescribeTaskQueue show pollers info of a given taskqueue
+type pollerRow struct {
+	Type           string
+	Identity       string
+	LastAccessTime *time.Time
+	RatePerSecond  float64
+}
+
+type taskQueueStatusRow struct {
+	Type             string
+	BacklogCountHint int64
+	ReadLevel        int64
+	AckLevel         int64
+	RatePerSecond    float64
+	TaskIdBlockStart int64
+	TaskIdBlockEnd   int64
+}
+
+type taskQueuePartitionRow struct {
+	Type          string
+	Key           string
+	OwnerHostName string
+}
+
+// taskQueueDescription is the single document of task queue describe with an output other than table
+type taskQueueDescription struct {
+	Pollers    []interface{}
+	Status     []interface{}
+	Partitions []interface{}
+}
+
+// DescribeTaskQueue show pollers info, backlog status and partitions of a given taskqueue, of both
+// task queue types unless --taskqueuetype is set
 func DescribeTaskQueue(c *cli.Context) error {
-	sdkClient := getSDKClient(c)
+	frontendClient := cFactory.FrontendClient(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	taskQueue := getRequiredOption(c, FlagTaskQueue)
-	taskQueueType := strToTaskQueueType(c.String(FlagTaskQueueType))
+	taskQueueTypes := []enumspb.TaskQueueType{enumspb.TASK_QUEUE_TYPE_WORKFLOW, enumspb.TASK_QUEUE_TYPE_ACTIVITY}
+	if c.IsSet(FlagTaskQueueType) {
+		taskQueueTypes = []enumspb.TaskQueueType{strToTaskQueueType(c.String(FlagTaskQueueType))}
+	}
 
-	ctx, cancel := newContext(c)
-	defer cancel()
-	resp, err := sdkClient.DescribeTaskQueue(ctx, taskQueue, taskQueueType)
-	if err != nil {
-		return fmt.Errorf("failed to describe task queue.\n%s", err)
+	pollers, statuses := []interface{}{}, []interface{}{}
+	for _, taskQueueType := range taskQueueTypes {
+		ctx, cancel := newContext(c)
+		resp, err := frontendClient.DescribeTaskQueue(ctx, &workflowservice.DescribeTaskQueueRequest{
+			Namespace: namespace,
+			TaskQueue: &taskqueuepb.TaskQueue{
+				Name: taskQueue,
+				Kind: enumspb.TASK_QUEUE_KIND_NORMAL,
+			},
+			TaskQueueType:          taskQueueType,
+			IncludeTaskQueueStatus: true,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("Failed to describe task queue.", err)
+		}
+
+		for _, p := range resp.GetPollers() {
+			pollers = append(pollers, pollerRow{
+				Type:           taskQueueType.String(),
+				Identity:       p.GetIdentity(),
+				LastAccessTime: p.GetLastAccessTime(),
+				RatePerSecond:  p.GetRatePerSecond(),
+			})
+		}
+		if status := resp.GetTaskQueueStatus(); status != nil {
+			statuses = append(statuses, taskQueueStatusRow{
+				Type:             taskQueueType.String(),
+				BacklogCountHint: status.GetBacklogCountHint(),
+				ReadLevel:        status.GetReadLevel(),
+				AckLevel:         status.GetAckLevel(),
+				RatePerSecond:    status.GetRatePerSecond(),
+				TaskIdBlockStart: status.GetTaskIdBlock().GetStartId(),
+				TaskIdBlockEnd:   status.GetTaskIdBlock().GetEndId(),
+			})
+		}
 	}
 
-	pollers := resp.Pollers
+	partitions := listTaskQueuePartitions(c, namespace, taskQueue, taskQueueTypes)
+	if !tableOutput(c) {
+		output.PrintItems(c, []interface{}{taskQueueDescription{Pollers: pollers, Status: statuses, Partitions: partitions}}, &output.PrintOptions{
+			Fields: []string{"Pollers", "Status", "Partitions"},
+		})
+		return nil
+	}
+
+	fmt.Println(color.Magenta(c, "Pollers"))
 	if len(pollers) == 0 {
-		return fmt.Errorf(color.Magenta(c, "no pollers running for task queue. %v", taskQueue))
+		fmt.Println(color.Magenta(c, "no pollers running for task queue. %v", taskQueue))
+	} else {
+		output.PrintItems(c, pollers, &output.PrintOptions{
+			Fields: []string{"Type", "Identity", "LastAccessTime", "RatePerSecond"},
+		})
 	}
 
-	opts := &output.PrintOptions{
-		Fields: []string{"Identity", "LastAccessTime", "RatePerSecond"},
+	if len(statuses) > 0 {
+		fmt.Println(color.Magenta(c, "\nStatus"))
+		output.PrintItems(c, statuses, &output.PrintOptions{
+			Fields:     []string{"Type", "BacklogCountHint", "ReadLevel", "AckLevel", "RatePerSecond"},
+			FieldsLong: []string{"TaskIdBlockStart", "TaskIdBlockEnd"},
+		})
 	}
-	var items []interface{}
-	for _, e := range resp.Pollers {
-		items = append(items, e)
+
+	if len(partitions) > 0 {
+		fmt.Println(color.Magenta(c, "\nPartitions"))
+		output.PrintItems(c, partitions, &output.PrintOptions{
+			Fields: []string{"Type", "Key", "OwnerHostName"},
+		})
 	}
-	output.PrintItems(c, items, opts)
 
 	return nil
 }
 
+// taskQueuePartitions is the single document of task queue list-partition with an output other than table
+type taskQueuePartitions struct {
+	Partitions        []interface{}
+	PartitionsPerHost []interface{}
+}
+
+type partitionHostRow struct {
+	OwnerHostName string
+	Workflow      int
+	Activity      int
+}
+
 // ListTaskQueuePartitions gets all the taskqueue partition and host information.
 func ListTaskQueuePartitions(c *cli.Context) error {
-	frontendClient := cFactory.FrontendClient(c)
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	taskQueue := getRequiredOption(c, FlagTaskQueue)
 
+	partitions := listTaskQueuePartitions(c, namespace, taskQueue,
+		[]enumspb.TaskQueueType{enumspb.TASK_QUEUE_TYPE_WORKFLOW, enumspb.TASK_QUEUE_TYPE_ACTIVITY})
+	if !tableOutput(c) {
+		output.PrintItems(c, []interface{}{taskQueuePartitions{Partitions: partitions, PartitionsPerHost: partitionsPerHost(partitions)}}, &output.PrintOptions{
+			Fields: []string{"Partitions", "PartitionsPerHost"},
+		})
+		return nil
+	}
+
+	output.PrintItems(c, partitions, &output.PrintOptions{
+		Fields: []string{"Type", "Key", "OwnerHostName"},
+	})
+
+	fmt.Println(color.Magenta(c, "\nPartitions per host"))
+	output.PrintItems(c, partitionsPerHost(partitions), &output.PrintOptions{
+		Fields: []string{"OwnerHostName", "Workflow", "Activity"},
+	})
+	return nil
+}
+
+// tableOutput tells whether the output is a table, which the commands printing several lists print
+// one after another instead of as a single document
+func tableOutput(c *cli.Context) bool {
+	switch output.OutputOption(c.String(output.FlagOutput)) {
+	case output.Table, output.Wide:
+		return true
+	}
+	return false
+}
+
+func listTaskQueuePartitions(c *cli.Context, namespace, taskQueue string, taskQueueTypes []enumspb.TaskQueueType) []interface{} {
+	frontendClient := cFactory.FrontendClient(c)
 	ctx, cancel := newContext(c)
 	defer cancel()
-	request := &workflowservice.ListTaskQueuePartitionsRequest{
+	resp, err := frontendClient.ListTaskQueuePartitions(ctx, &workflowservice.ListTaskQueuePartitionsRequest{
 		Namespace: namespace,
 		TaskQueue: &taskqueuepb.TaskQueue{
 			Name: taskQueue,
 			Kind: enumspb.TASK_QUEUE_KIND_NORMAL,
 		},
-	}
-
-	resp, err := frontendClient.ListTaskQueuePartitions(ctx, request)
+	})
 	if err != nil {
-		return fmt.Errorf("failed to list task queues.\n%s", err)
+		ErrorAndExit("Failed to list task queue partitions.", err)
 	}
 
-	optsW := &output.PrintOptions{
-		Fields: []string{"Key", "OwnerHostName"},
+	partitions := []interface{}{}
+	for _, taskQueueType := range taskQueueTypes {
+		metadata := resp.GetWorkflowTaskQueuePartitions()
+		if taskQueueType == enumspb.TASK_QUEUE_TYPE_ACTIVITY {
+			metadata = resp.GetActivityTaskQueuePartitions()
+		}
+		for _, m := range metadata {
+			partitions = append(partitions, taskQueuePartitionRow{
+				Type:          taskQueueType.String(),
+				Key:           m.GetKey(),
+				OwnerHostName: m.GetOwnerHostName(),
+			})
+		}
 	}
+	return partitions
+}
 
-	var items []interface{}
-	fmt.Println(color.Magenta(c, "Workflow TaskQueue Partitions\n"))
-	for _, e := range resp.WorkflowTaskQueuePartitions {
-		items = append(items, e)
+// partitionsPerHost counts the partitions each matching host owns, in the order the hosts first appear
+func partitionsPerHost(partitions []interface{}) []interface{} {
+	var hosts []string
+	byHost := make(map[string]*partitionHostRow)
+	for _, p := range partitions {
+		partition := p.(taskQueuePartitionRow)
+		row, ok := byHost[partition.OwnerHostName]
+		if !ok {
+			row = &partitionHostRow{OwnerHostName: partition.OwnerHostName}
+			byHost[partition.OwnerHostName] = row
+			hosts = append(hosts, partition.OwnerHostName)
+		}
+		if partition.Type == enumspb.TASK_QUEUE_TYPE_ACTIVITY.String() {
+			row.Activity++
+		} else {
+			row.Workflow++
+		}
 	}
-	output.PrintItems(c, items, optsW)
 
-	optsA := &output.PrintOptions{
-		Fields: []string{"Key", "OwnerHostName"},
+	items := make([]interface{}, len(hosts))
+	for i, host := range hosts {
+		items[i] = *byHost[host]
 	}
-	items = items[:0]
-	fmt.Println(color.Magenta(c, "\nActivity TaskQueue Partitions\n"))
-	for _, e := range resp.ActivityTaskQueuePartitions {
-		items = append(items, e)
-	}
-	output.PrintItems(c, items, optsA)
-	return nil
+	return items
 }
@@ -0,0 +1,146 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/searchattribute"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// processTypedSearchAttributes encodes the --search-attribute Key=Value pairs with the types the
+// attributes are registered with in the cluster
+func processTypedSearchAttributes(c *cli.Context) map[string]*commonpb.Payload {
+	pairs := c.StringSlice(FlagSearchAttribute)
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	sdkClient := getSDKClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := sdkClient.GetSearchAttributes(ctx)
+	if err != nil {
+		ErrorAndExit("Unable to get search attributes.", err)
+	}
+	types := resp.GetKeys()
+
+	fields := make(map[string]*commonpb.Payload, len(pairs))
+	for _, pair := range pairs {
+		key, value := parseKeyValue(FlagSearchAttribute, pair)
+		t, ok := types[key]
+		if !ok {
+			process.UsageErrorAndExit(fmt.Sprintf("Search attribute %s is not registered, use 'cluster list-search-attributes' to list them.", key))
+		}
+		val, err := parseSearchAttributeValue(value, t)
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Invalid %s value of search attribute %s.", t, key), err)
+		}
+		p, err := searchattribute.EncodeValue(val, t)
+		if err != nil {
+			ErrorAndExit("Unable to encode search attribute.", err)
+		}
+		fields[key] = p
+	}
+	return fields
+}
+
+// processMemoPairs encodes the --memo Key=Value pairs, a value is stored decoded if it is JSON and as
+// a string otherwise
+func processMemoPairs(pairs []string) map[string]*commonpb.Payload {
+	fields := make(map[string]*commonpb.Payload, len(pairs))
+	for _, pair := range pairs {
+		key, value := parseKeyValue(FlagMemo, pair)
+		var v interface{}
+		if err := json.Unmarshal([]byte(value), &v); err != nil {
+			fields[key] = payload.EncodeString(value)
+			continue
+		}
+		p, err := payload.Encode(v)
+		if err != nil {
+			ErrorAndExit("Unable to encode memo.", err)
+		}
+		fields[key] = p
+	}
+	return fields
+}
+
+func parseKeyValue(flag, pair string) (string, string) {
+	i := strings.Index(pair, "=")
+	if i <= 0 {
+		process.UsageErrorAndExit(fmt.Sprintf("Invalid %s %q, expected Key=Value", flag, pair))
+	}
+	return pair[:i], pair[i+1:]
+}
+
+// parseSearchAttributeValue parses the value as the type, a JSON array is parsed as an array of the type
+func parseSearchAttributeValue(value string, t enumspb.IndexedValueType) (interface{}, error) {
+	trimmed := strings.TrimSpace(value)
+	if !strings.HasPrefix(trimmed, "[") || !strings.HasSuffix(trimmed, "]") {
+		return parseSearchAttributeScalar(value, t)
+	}
+
+	var elements []json.RawMessage
+	if err := json.Unmarshal([]byte(trimmed), &elements); err != nil {
+		return nil, err
+	}
+	values := make([]interface{}, len(elements))
+	for i, element := range elements {
+		var s string
+		if err := json.Unmarshal(element, &s); err != nil {
+			s = string(element)
+		}
+		v, err := parseSearchAttributeScalar(s, t)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+func parseSearchAttributeScalar(value string, t enumspb.IndexedValueType) (interface{}, error) {
+	switch t {
+	case enumspb.INDEXED_VALUE_TYPE_INT:
+		return strconv.ParseInt(value, 10, 64)
+	case enumspb.INDEXED_VALUE_TYPE_DOUBLE:
+		return strconv.ParseFloat(value, 64)
+	case enumspb.INDEXED_VALUE_TYPE_BOOL:
+		return strconv.ParseBool(value)
+	case enumspb.INDEXED_VALUE_TYPE_DATETIME:
+		return time.Parse(time.RFC3339Nano, value)
+	default:
+		return value, nil
+	}
+}
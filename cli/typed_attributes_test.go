@@ -0,0 +1,83 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/server/common/payload"
+)
+
+type typedAttributesSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestTypedAttributesSuite(t *testing.T) {
+	suite.Run(t, new(typedAttributesSuite))
+}
+
+func (s *typedAttributesSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *typedAttributesSuite) TestParseSearchAttributeValue() {
+	v, err := parseSearchAttributeValue("42", enumspb.INDEXED_VALUE_TYPE_INT)
+	s.NoError(err)
+	s.Equal(int64(42), v)
+
+	v, err = parseSearchAttributeValue("42", enumspb.INDEXED_VALUE_TYPE_KEYWORD)
+	s.NoError(err)
+	s.Equal("42", v)
+
+	v, err = parseSearchAttributeValue("2021-06-07T17:16:34Z", enumspb.INDEXED_VALUE_TYPE_DATETIME)
+	s.NoError(err)
+	s.Equal(time.Date(2021, 6, 7, 17, 16, 34, 0, time.UTC), v)
+
+	v, err = parseSearchAttributeValue(`[1, "2"]`, enumspb.INDEXED_VALUE_TYPE_INT)
+	s.NoError(err)
+	s.Equal([]interface{}{int64(1), int64(2)}, v)
+
+	_, err = parseSearchAttributeValue("yes please", enumspb.INDEXED_VALUE_TYPE_BOOL)
+	s.Error(err)
+}
+
+func (s *typedAttributesSuite) TestProcessMemoPairs() {
+	fields := processMemoPairs([]string{`owner=team-a`, `limits={"cpu":2}`, `note=a=b`})
+	s.Len(fields, 3)
+
+	var owner, note string
+	s.NoError(payload.Decode(fields["owner"], &owner))
+	s.Equal("team-a", owner)
+	s.NoError(payload.Decode(fields["note"], &note))
+	s.Equal("a=b", note)
+
+	var limits map[string]int
+	s.NoError(payload.Decode(fields["limits"], &limits))
+	s.Equal(map[string]int{"cpu": 2}, limits)
+}
@@ -49,6 +49,7 @@ import (
 
 	"github.com/temporalio/tctl/cli/dataconverter"
 	"github.com/temporalio/tctl/cli/stringify"
+	"github.com/temporalio/tctl/pkg/process"
 	"go.temporal.io/server/common/codec"
 	"go.temporal.io/server/common/payloads"
 	"go.temporal.io/server/common/rpc"
@@ -359,6 +360,9 @@ func mapKeysToArray(m map[string]string) []string {
 }
 
 func printError(msg string, err error) {
+	if process.JSONErrors() {
+		process.ErrorAndExit(msg, err)
+	}
 	if err != nil {
 		fmt.Printf("%s %s\n%s %+v\n", color.RedString("Error:"), msg, color.MagentaString("Error Details:"), err)
 		if os.Getenv(showErrorStackEnv) != `` {
@@ -375,7 +379,7 @@ func printError(msg string, err error) {
 // ErrorAndExit print easy to understand error msg first then error detail in a new line
 func ErrorAndExit(msg string, err error) {
 	printError(msg, err)
-	os.Exit(1)
+	process.ExitOnError(process.ExitCode(err))
 }
 
 func getWorkflowClient(c *cli.Context) sdkclient.Client {
@@ -398,21 +402,21 @@ func getSDKClient(c *cli.Context) sdkclient.Client {
 func getRequiredOption(c *cli.Context, optionName string) string {
 	value := c.String(optionName)
 	if len(value) == 0 {
-		ErrorAndExit(fmt.Sprintf("Option %s is required", optionName), nil)
+		process.UsageErrorAndExit(fmt.Sprintf("Option %s is required", optionName))
 	}
 	return value
 }
 
 func getRequiredInt64Option(c *cli.Context, optionName string) int64 {
 	if !c.IsSet(optionName) {
-		ErrorAndExit(fmt.Sprintf("Option %s is required", optionName), nil)
+		process.UsageErrorAndExit(fmt.Sprintf("Option %s is required", optionName))
 	}
 	return c.Int64(optionName)
 }
 
 func getRequiredIntOption(c *cli.Context, optionName string) int {
 	if !c.IsSet(optionName) {
-		ErrorAndExit(fmt.Sprintf("Option %s is required", optionName), nil)
+		process.UsageErrorAndExit(fmt.Sprintf("Option %s is required", optionName))
 	}
 	return c.Int(optionName)
 }
@@ -420,7 +424,7 @@ func getRequiredIntOption(c *cli.Context, optionName string) int {
 func getRequiredGlobalOption(c *cli.Context, optionName string) string {
 	value := c.String(optionName)
 	if len(value) == 0 {
-		ErrorAndExit(fmt.Sprintf("Global option %s is required", optionName), nil)
+		process.UsageErrorAndExit(fmt.Sprintf("Global option %s is required", optionName))
 	}
 	return value
 }
@@ -576,6 +580,10 @@ func newContextForLongPoll(c *cli.Context) (context.Context, context.CancelFunc)
 	return newContextWithTimeout(c, defaultContextTimeoutForLongPoll)
 }
 
+func newContextForArchival(c *cli.Context) (context.Context, context.CancelFunc) {
+	return newContextWithTimeout(c, defaultContextTimeoutForListArchivedWorkflow)
+}
+
 func newIndefiniteContext(c *cli.Context) (context.Context, context.CancelFunc) {
 	if c.IsSet(FlagContextTimeout) {
 		timeout := time.Duration(c.Int(FlagContextTimeout)) * time.Second
@@ -595,20 +603,29 @@ func newContextWithTimeout(c *cli.Context, timeout time.Duration) (context.Conte
 
 // process and validate input provided through cmd or file
 func processJSONInput(c *cli.Context) *commonpb.Payloads {
-	jsonsRaw := readJSONInputs(c, jsonTypeInput)
+	return processJSONInputOfType(c, jsonTypeInput)
+}
+
+func processJSONInputOfType(c *cli.Context, jType jsonType) *commonpb.Payloads {
+	jsonsRaw := readJSONInputs(c, jType)
 
 	var jsons []interface{}
 	for _, jsonRaw := range jsonsRaw {
 		if jsonRaw == nil {
 			jsons = append(jsons, nil)
-		} else {
+			continue
+		}
+		// each value of JSONs concatenated with spaces/newlines is an argument
+		dec := json.NewDecoder(bytes.NewReader(jsonRaw))
+		for {
 			var j interface{}
-			if err := json.Unmarshal(jsonRaw, &j); err != nil {
+			if err := dec.Decode(&j); err == io.EOF {
+				break
+			} else if err != nil {
 				ErrorAndExit("Input is not a valid JSON.", err)
 			}
 			jsons = append(jsons, j)
 		}
-
 	}
 	p, err := payloads.Encode(jsons...)
 	if err != nil {
@@ -630,6 +647,9 @@ func readJSONInputs(c *cli.Context, jType jsonType) [][]byte {
 	case jsonTypeMemo:
 		flagRawInput = FlagMemo
 		flagInputFileName = FlagMemoFile
+	case jsonTypeSignalInput:
+		flagRawInput = FlagSignalInput
+		flagInputFileName = FlagSignalInputFile
 	default:
 		return nil
 	}
@@ -648,7 +668,9 @@ func readJSONInputs(c *cli.Context, jType jsonType) [][]byte {
 
 		var inputsRaw [][]byte
 		for _, i := range inputs.Value() {
-			if strings.EqualFold(i, "null") {
+			if i == stdinInput {
+				inputsRaw = append(inputsRaw, readInputFile(i))
+			} else if strings.EqualFold(i, "null") {
 				inputsRaw = append(inputsRaw, []byte(nil))
 			} else {
 				inputsRaw = append(inputsRaw, []byte(i))
@@ -657,16 +679,26 @@ func readJSONInputs(c *cli.Context, jType jsonType) [][]byte {
 
 		return inputsRaw
 	} else if c.IsSet(flagInputFileName) {
-		inputFile := c.String(flagInputFileName)
+		return [][]byte{readInputFile(c.String(flagInputFileName))}
+	}
+	return nil
+}
+
+// readInputFile reads the input file, or stdin if the name is -
+func readInputFile(inputFile string) []byte {
+	var data []byte
+	var err error
+	if inputFile == stdinInput {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
 		// This method is purely used to parse input from the CLI. The input comes from a trusted user
 		// #nosec
-		data, err := ioutil.ReadFile(inputFile)
-		if err != nil {
-			ErrorAndExit("Error reading input file", err)
-		}
-		return [][]byte{data}
+		data, err = ioutil.ReadFile(inputFile)
 	}
-	return nil
+	if err != nil {
+		ErrorAndExit("Error reading input file", err)
+	}
+	return data
 }
 
 // validate whether str is a valid json or multi valid json concatenated with spaces/newlines
@@ -25,10 +25,15 @@
 package cli
 
 import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/server/common/payloads"
 )
 
 func (s *utilSuite) SetupTest() {
@@ -89,3 +94,23 @@ func (s *utilSuite) TestStringToEnum_MapEmptyEnum() {
 	s.Error(err)
 	s.Equal(result, int32(0))
 }
+
+func (s *utilSuite) TestProcessJSONInput_FileWithMultipleValues() {
+	file := filepath.Join(s.T().TempDir(), "input.json")
+	s.NoError(ioutil.WriteFile(file, []byte("{\"id\": 1}\n\"name\" null"), 0644))
+
+	set := flag.NewFlagSet("test", 0)
+	set.String(FlagInputFile, "", "")
+	s.NoError(set.Set(FlagInputFile, file))
+	c := cli.NewContext(nil, set, nil)
+
+	input := processJSONInput(c)
+	s.Len(input.GetPayloads(), 3)
+	s.Equal(`[{"id":1}, "name", nil]`, payloads.ToString(input))
+}
+
+func (s *utilSuite) TestCombineQueries() {
+	s.Equal("", combineQueries("", " "))
+	s.Equal("ExecutionStatus='Running'", combineQueries("ExecutionStatus='Running'", ""))
+	s.Equal("(ExecutionStatus='Running') AND (WorkflowType='order')", combineQueries("ExecutionStatus='Running'", "WorkflowType='order'"))
+}
@@ -42,6 +42,33 @@ func newWorkflowCommands() []*cli.Command {
 				return nil
 			},
 		},
+		{
+			Name:  "start-bulk",
+			Usage: "start a workflow execution per line of a NDJSON file",
+			Flags: append(flagsForStartBulk, flags.FlagsForPaginationAndRendering...),
+			Action: func(c *cli.Context) error {
+				StartWorkflowsInBulk(c)
+				return nil
+			},
+		},
+		{
+			Name:  "signal-bulk",
+			Usage: "signal each workflow execution of a CSV or NDJSON file",
+			Flags: append(flagsForSignalBulk, flags.FlagsForPaginationAndRendering...),
+			Action: func(c *cli.Context) error {
+				SignalWorkflowsInBulk(c)
+				return nil
+			},
+		},
+		{
+			Name:  "signal-with-start",
+			Usage: "signal the workflow execution, starting it first if it is not running",
+			Flags: append(flagsForSignalWithStart, flags.FlagsForPaginationAndRendering...),
+			Action: func(c *cli.Context) error {
+				SignalWithStartWorkflow(c)
+				return nil
+			},
+		},
 		{
 			Name:    "describe",
 			Aliases: []string{"desc"},
@@ -57,16 +84,17 @@ func newWorkflowCommands() []*cli.Command {
 			Aliases:     []string{"l"},
 			Usage:       "list open or closed workflow executions",
 			Description: "list one page (default size 10 items) by default, use flag --pagesize to change page size",
-			Flags:       append(flagsForWorkflowFiltering, flags.FlagsForPaginationAndRendering...),
+			Flags:       append(flagsForWorkflowFiltering, flags.FlagsForListing...),
 			Action: func(c *cli.Context) error {
 				ListWorkflow(c)
 				return nil
 			},
 		},
 		{
-			Name:  "listarchived",
-			Usage: "list archived workflow executions",
-			Flags: append(flagsForListArchived, flags.FlagsForPaginationAndRendering...),
+			Name:    "listarchived",
+			Aliases: []string{"list-archived"},
+			Usage:   "list archived workflow executions",
+			Flags:   append(flagsForListArchived, flags.FlagsForListing...),
 			Action: func(c *cli.Context) error {
 				ListArchivedWorkflow(c)
 				return nil
@@ -75,7 +103,7 @@ func newWorkflowCommands() []*cli.Command {
 		{
 			Name:  "show",
 			Usage: "show workflow history",
-			Flags: append(append(flagsForExecution, flagsForShowWorkflow...), flags.FlagsForPaginationAndRendering...),
+			Flags: append(append(flagsForExecution, flagsForShowWorkflow...), flags.FlagsForListing...),
 			Action: func(c *cli.Context) error {
 				ShowHistory(c)
 				return nil
@@ -84,7 +112,7 @@ func newWorkflowCommands() []*cli.Command {
 		{
 			Name:    "observe",
 			Aliases: []string{"ob"},
-			Usage:   "show the progress of workflow history",
+			Usage:   "show the progress of workflow history, or with --status or --until wait for the workflow to reach a state",
 			Flags:   flagsForObserveHistory,
 			Action: func(c *cli.Context) error {
 				ObserveHistory(c)
@@ -92,17 +120,54 @@ func newWorkflowCommands() []*cli.Command {
 			},
 		},
 		{
-			Name:  "query",
-			Usage: "query workflow execution",
-			Flags: flagsForQuery,
+			Name:  "result",
+			Usage: "wait for the workflow execution to close and print its result, exits with 7 unless it completed",
+			Flags: append(append(flagsForExecution, flagsForResult...), flags.FlagsForViewing...),
+			Action: func(c *cli.Context) error {
+				WorkflowResult(c)
+				return nil
+			},
+		},
+		{
+			Name:      "query",
+			Usage:     "query workflow execution",
+			ArgsUsage: "[arg...] each argument is a query argument, decoded if it is JSON",
+			Flags:     flagsForQuery,
 			Action: func(c *cli.Context) error {
 				QueryWorkflow(c)
 				return nil
 			},
 		},
+		{
+			Name:  "trace",
+			Usage: "show the tree of child workflows and continue-as-new runs with their status and duration",
+			Flags: append(append(flagsForExecution, flagsForTrace...), flags.FlagsForViewing...),
+			Action: func(c *cli.Context) error {
+				TraceWorkflow(c)
+				return nil
+			},
+		},
+		{
+			Name:  "diff",
+			Usage: "compare the histories of two workflow runs and show where they diverge",
+			Flags: append(append(flagsForExecution, flagsForDiff...), flags.FlagsForViewing...),
+			Action: func(c *cli.Context) error {
+				DiffHistory(c)
+				return nil
+			},
+		},
+		{
+			Name:  "replay",
+			Usage: "replay workflow histories against the workflow code to detect non-determinism",
+			Flags: append(flagsForReplay, flags.FlagsForViewing...),
+			Action: func(c *cli.Context) error {
+				ReplayWorkflow(c)
+				return nil
+			},
+		},
 		{
 			Name:  "stack",
-			Usage: "query workflow execution with __stack_trace as query type",
+			Usage: "print the stack traces of the coroutines of a Go workflow, queried with __stack_trace",
 			Flags: flagsForStackTraceQuery,
 			Action: func(c *cli.Context) error {
 				QueryWorkflowUsingStackTrace(c)
@@ -113,7 +178,7 @@ func newWorkflowCommands() []*cli.Command {
 			Name:    "signal",
 			Aliases: []string{"s"},
 			Usage:   "signal a workflow execution",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				&cli.StringFlag{
 					Name:  FlagWorkflowIDWithAlias,
 					Usage: "WorkflowId",
@@ -126,15 +191,15 @@ func newWorkflowCommands() []*cli.Command {
 					Name:  FlagNameWithAlias,
 					Usage: "SignalName",
 				},
-				&cli.StringFlag{
+				&cli.StringSliceFlag{
 					Name:  FlagInputWithAlias,
-					Usage: "Input for the signal, in JSON format.",
+					Usage: "Input for the signal, in JSON format. If there are multiple parameters, pass each as a separate input flag. Pass - to read from stdin",
 				},
 				&cli.StringFlag{
 					Name:  FlagInputFileWithAlias,
-					Usage: "Input for the signal from JSON file.",
+					Usage: "Input for the signal from JSON file, - for stdin. If there are multiple JSON, concatenate them and separate by space or newline",
 				},
-			},
+			}, flagsForBatchByQuery...),
 			Action: func(c *cli.Context) error {
 				SignalWorkflow(c)
 				return nil
@@ -144,7 +209,7 @@ func newWorkflowCommands() []*cli.Command {
 			Name: "scan",
 			Usage: "scan workflow executions (need to enable Temporal server on ElasticSearch). " +
 				"It will be faster than listall, but result are not sorted.",
-			Flags: append(flagsForScan, flags.FlagsForPaginationAndRendering...),
+			Flags: append(flagsForScan, flags.FlagsForListing...),
 			Action: func(c *cli.Context) error {
 				ScanAllWorkflow(c)
 				return nil
@@ -154,7 +219,7 @@ func newWorkflowCommands() []*cli.Command {
 			Name:    "count",
 			Aliases: []string{"cnt"},
 			Usage:   "count number of workflow executions (need to enable Temporal server on ElasticSearch)",
-			Flags:   getFlagsForCount(),
+			Flags:   append(getFlagsForCount(), flags.FlagsForViewing...),
 			Action: func(c *cli.Context) error {
 				CountWorkflow(c)
 				return nil
@@ -164,7 +229,10 @@ func newWorkflowCommands() []*cli.Command {
 			Name:    "cancel",
 			Aliases: []string{"c"},
 			Usage:   "cancel a workflow execution",
-			Flags:   flagsForExecution,
+			Flags: append(append(flagsForExecution, &cli.StringFlag{
+				Name:  FlagReasonWithAlias,
+				Usage: "With query, the reason of the batch job",
+			}), flagsForBatchByQuery...),
 			Action: func(c *cli.Context) error {
 				CancelWorkflow(c)
 				return nil
@@ -174,7 +242,7 @@ func newWorkflowCommands() []*cli.Command {
 			Name:    "terminate",
 			Aliases: []string{"term"},
 			Usage:   "terminate a new workflow execution",
-			Flags: []cli.Flag{
+			Flags: append([]cli.Flag{
 				&cli.StringFlag{
 					Name:  FlagWorkflowIDWithAlias,
 					Usage: "WorkflowId",
@@ -187,7 +255,7 @@ func newWorkflowCommands() []*cli.Command {
 					Name:  FlagReasonWithAlias,
 					Usage: "The reason you want to terminate the workflow",
 				},
-			},
+			}, flagsForBatchByQuery...),
 			Action: func(c *cli.Context) error {
 				TerminateWorkflow(c)
 				return nil
@@ -215,13 +283,16 @@ func newWorkflowCommands() []*cli.Command {
 					Usage: "reason to do the reset",
 				},
 				&cli.StringFlag{
-					Name:  FlagResetType,
-					Usage: "where to reset. Support one of these: " + strings.Join(mapKeysToArray(resetTypesMap), ","),
+					Name:    FlagResetType,
+					Aliases: []string{"type"},
+					Usage:   "where to reset. Support one of these: " + strings.Join(mapKeysToArray(resetTypesMap), ","),
 				},
 				&cli.StringFlag{
 					Name:  FlagResetBadBinaryChecksum,
 					Usage: "Binary checksum for resetType of BadBinary",
 				},
+				flagResetBuildID,
+				flagReapplyType,
 			},
 			Action: func(c *cli.Context) error {
 				ResetWorkflow(c)
@@ -235,7 +306,15 @@ func newWorkflowCommands() []*cli.Command {
 			Flags: []cli.Flag{
 				&cli.StringFlag{
 					Name:  FlagInputFileWithAlias,
-					Usage: "Input file to use for resetting, one workflow per line of WorkflowId and RunId. RunId is optional, default to current runId if not specified. ",
+					Usage: "Input file to use for resetting, one workflow per line of WorkflowId and RunId, - for stdin. RunId is optional, default to current runId if not specified. ",
+				},
+				&cli.StringFlag{
+					Name:  FlagProgressFile,
+					Usage: "File to record the processed workflows in, the ones already in it are skipped. Rerun with the same file to resume",
+				},
+				&cli.StringFlag{
+					Name:  FlagReportFile,
+					Usage: "NDJSON file to write the result of each workflow to, with its status of Reset, DryRun, Skipped or Failed",
 				},
 				&cli.StringFlag{
 					Name:  FlagListQueryWithAlias,
@@ -287,6 +366,8 @@ func newWorkflowCommands() []*cli.Command {
 					Name:  FlagResetBadBinaryChecksum,
 					Usage: "Binary checksum for resetType of BadBinary",
 				},
+				flagResetBuildID,
+				flagReapplyType,
 			},
 			Action: func(c *cli.Context) error {
 				ResetInBatch(c)
@@ -26,7 +26,9 @@ package cli
 
 import (
 	"bufio"
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/rand"
 	"os"
@@ -54,48 +56,90 @@ import (
 
 	"github.com/temporalio/tctl/pkg/color"
 	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
 	clispb "go.temporal.io/server/api/cli/v1"
 	"go.temporal.io/server/common/clock"
-	"go.temporal.io/server/common/collection"
 	"go.temporal.io/server/common/convert"
 	"go.temporal.io/server/common/payload"
 	"go.temporal.io/server/common/payloads"
 	"go.temporal.io/server/common/primitives/timestamp"
 	"go.temporal.io/server/common/searchattribute"
 	"go.temporal.io/server/service/history/workflow"
+	"go.temporal.io/server/service/worker/batcher"
 )
 
+// checkHistoryArchival verifies the archived history of the run can be read. The server reads the
+// history from the archival provider when the run is no longer in the persistence
+func checkHistoryArchival(c *cli.Context, namespace, rid string) {
+	if rid == "" {
+		process.UsageErrorAndExit(fmt.Sprintf("Option %s is required with --%s, archived histories are looked up by run id", FlagRunID, FlagArchived))
+	}
+	if c.Bool(FlagFollow) {
+		process.UsageErrorAndExit(fmt.Sprintf("Option %s can't be used with --%s", FlagFollow, FlagArchived))
+	}
+
+	client := cFactory.FrontendClient(c)
+	ctx, cancel := newContext(c)
+	defer cancel()
+	resp, err := client.DescribeNamespace(ctx, &workflowservice.DescribeNamespaceRequest{
+		Namespace: namespace,
+	})
+	if err != nil {
+		ErrorAndExit("Unable to describe namespace.", err)
+	}
+	if resp.GetConfig().GetHistoryArchivalState() != enumspb.ARCHIVAL_STATE_ENABLED {
+		ErrorAndExit(fmt.Sprintf("History archival is not enabled for namespace %s.", namespace), nil)
+	}
+}
+
 // ShowHistory shows the history of given workflow execution based on workflowID and runID.
 func ShowHistory(c *cli.Context) {
 	wid, rid := getWorkflowParams(c)
 
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
-	var maxFieldLength int
-	if c.IsSet(FlagMaxFieldLength) || true {
+	maxFieldLength := defaultMaxFieldLength
+	if c.IsSet(FlagMaxFieldLength) {
 		maxFieldLength = c.Int(FlagMaxFieldLength)
 	}
-	client := cFactory.FrontendClient(c)
 
-	paginationFunc := func(npt []byte) ([]interface{}, []byte, error) {
-		ctx, cancel := newContext(c)
-		defer cancel()
-		var err error
+	if c.Bool(FlagArchived) {
+		checkHistoryArchival(c, namespace, rid)
+	}
+	if c.Bool(FlagSummary) {
+		showHistorySummary(c, wid, rid)
+		return
+	}
+	if c.IsSet(FlagDiagram) {
+		showHistoryDiagram(c, wid, rid)
+		return
+	}
+	if c.IsSet(FlagOutputFilename) {
+		exportHistory(c, wid, rid, c.String(FlagOutputFilename))
+		return
+	}
 
-		req := &workflowservice.GetWorkflowExecutionHistoryRequest{
-			Namespace: namespace,
-			Execution: &commonpb.WorkflowExecution{
-				WorkflowId: wid,
-				RunId:      rid,
-			},
-			NextPageToken:          npt,
-			HistoryEventFilterType: enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT,
+	follow := c.Bool(FlagFollow)
+	include := parseEventTypes(c, FlagEventType)
+	exclude := parseEventTypes(c, FlagExcludeEventType)
+
+	paginationFunc := func(npt []byte) ([]interface{}, []byte, error) {
+		req := newHistoryRequest(c, wid, rid)
+		req.NextPageToken = npt
+		// the server holds the request until new events are appended, and ends the pages when the workflow closes
+		req.WaitNewEvent = follow
+		if npt == nil {
+			// a small first page is printed right away
+			req.MaximumPageSize = output.BatchPrintSize
 		}
-		res, err := client.GetWorkflowExecutionHistory(ctx, req)
+		res, err := getHistoryPage(c, req)
 		if err != nil {
 			return nil, nil, err
 		}
 		var items []interface{}
 		for _, e := range res.History.Events {
+			if len(include) > 0 && !include[e.GetEventType()] || exclude[e.GetEventType()] {
+				continue
+			}
 			item := eventRow{
 				ID:      convert.Int64ToString(e.GetEventId()),
 				Type:    ColorEvent(e),
@@ -103,22 +147,119 @@ func ShowHistory(c *cli.Context) {
 			}
 			items = append(items, item)
 		}
-		if err != nil {
-			return nil, nil, err
-		}
 
 		return items, res.NextPageToken, nil
 	}
 
-	iter := collection.NewPagingIterator(paginationFunc)
-	opts := &output.PrintOptions{Fields: []string{"ID", "Type", "Details"}}
-	output.Pager(c, iter, opts)
+	iter := output.NewPagingIterator(c, paginationFunc)
+	opts := &output.PrintOptions{Fields: []string{"ID", "Type", "Details"}, ItemTemplate: eventRow{}}
+	if follow {
+		// the events are printed as they come until the workflow closes or Ctrl+C, a pager would wait for them all
+		opts.NoPager = true
+	}
+	if err := output.Pager(c, iter, opts); err != nil {
+		ErrorAndExit("Unable to show workflow history.", err)
+	}
+}
+
+// newHistoryRequest is the request of the first page of all the events of the run's history
+func newHistoryRequest(c *cli.Context, wid, rid string) *workflowservice.GetWorkflowExecutionHistoryRequest {
+	return &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace: getRequiredGlobalOption(c, FlagNamespace),
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		HistoryEventFilterType: enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT,
+		MaximumPageSize:        historyPageSize,
+	}
+}
+
+// getHistoryPage gets a page of the history with a context of its own, the whole history of a long
+// workflow does not fit in one RPC timeout. Archived histories, read with --archived, and pages waiting
+// for new events get the longer timeouts
+func getHistoryPage(c *cli.Context, req *workflowservice.GetWorkflowExecutionHistoryRequest) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+	newCtx := newContext
+	if req.WaitNewEvent {
+		newCtx = newContextForLongPoll
+	} else if c.Bool(FlagArchived) {
+		newCtx = newContextForArchival
+	}
+	ctx, cancel := newCtx(c)
+	defer cancel()
+	return cFactory.FrontendClient(c).GetWorkflowExecutionHistory(ctx, req)
+}
+
+// parseEventTypes returns the event types listed in the flag, ex. --event-type ActivityTaskFailed
+func parseEventTypes(c *cli.Context, flagName string) map[enumspb.EventType]bool {
+	types := make(map[enumspb.EventType]bool)
+	for _, name := range c.StringSlice(flagName) {
+		for _, n := range strings.Split(name, ",") {
+			t, err := stringToEnum(strings.TrimSpace(n), enumspb.EventType_value)
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("Invalid --%s", flagName), err)
+			}
+			types[enumspb.EventType(t)] = true
+		}
+	}
+	return types
 }
 
 // RunWorkflow starts a new workflow execution and print workflow progress and result
 func RunWorkflow(c *cli.Context) {
 	serviceClient := cFactory.FrontendClient(c)
+	startRequest := newStartWorkflowRequest(c)
+
+	tcCtx, cancel := newContextForLongPoll(c)
+	defer cancel()
+	resp, err := serviceClient.StartWorkflowExecution(tcCtx, startRequest)
 
+	if err != nil {
+		ErrorAndExit("Failed to run workflow.", err)
+	}
+
+	fmt.Println(color.Magenta(c, "Running execution:"))
+	printExecutionDetails(c, startRequest, resp.GetRunId())
+
+	printWorkflowProgress(c, startRequest.GetWorkflowId(), resp.GetRunId())
+}
+
+// SignalWithStartWorkflow signals a workflow execution, starting it first if it is not running
+func SignalWithStartWorkflow(c *cli.Context) {
+	serviceClient := cFactory.FrontendClient(c)
+	startRequest := newStartWorkflowRequest(c)
+	signalName := getRequiredOption(c, FlagSignalName)
+
+	tcCtx, cancel := newContext(c)
+	defer cancel()
+	resp, err := serviceClient.SignalWithStartWorkflowExecution(tcCtx, &workflowservice.SignalWithStartWorkflowExecutionRequest{
+		Namespace:                startRequest.Namespace,
+		WorkflowId:               startRequest.WorkflowId,
+		WorkflowType:             startRequest.WorkflowType,
+		TaskQueue:                startRequest.TaskQueue,
+		Input:                    startRequest.Input,
+		WorkflowExecutionTimeout: startRequest.WorkflowExecutionTimeout,
+		WorkflowTaskTimeout:      startRequest.WorkflowTaskTimeout,
+		Identity:                 startRequest.Identity,
+		RequestId:                startRequest.RequestId,
+		WorkflowIdReusePolicy:    startRequest.WorkflowIdReusePolicy,
+		SignalName:               signalName,
+		SignalInput:              processJSONInputOfType(c, jsonTypeSignalInput),
+		CronSchedule:             startRequest.CronSchedule,
+		Memo:                     startRequest.Memo,
+		SearchAttributes:         startRequest.SearchAttributes,
+	})
+
+	if err != nil {
+		ErrorAndExit("Signal with start workflow failed.", err)
+	}
+
+	fmt.Println(color.Magenta(c, "Signaled execution:"))
+	printExecutionDetails(c, startRequest, resp.GetRunId())
+}
+
+// newStartWorkflowRequest builds the request from the flags of flagsForRunWorkflow
+func newStartWorkflowRequest(c *cli.Context) *workflowservice.StartWorkflowExecutionRequest {
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	taskQueue := getRequiredOption(c, FlagTaskQueue)
 	workflowType := getRequiredOption(c, FlagWorkflowType)
@@ -165,15 +306,10 @@ func RunWorkflow(c *cli.Context) {
 	}
 
 	startRequest.SearchAttributes = processSearchAttr(c)
+	return startRequest
+}
 
-	tcCtx, cancel := newContextForLongPoll(c)
-	defer cancel()
-	resp, err := serviceClient.StartWorkflowExecution(tcCtx, startRequest)
-
-	if err != nil {
-		ErrorAndExit("Failed to run workflow.", err)
-	}
-
+func printExecutionDetails(c *cli.Context, startRequest *workflowservice.StartWorkflowExecutionRequest, runID string) {
 	executionDetails := struct {
 		WorkflowId string
 		RunId      string
@@ -183,17 +319,16 @@ func RunWorkflow(c *cli.Context) {
 		Args       string
 	}{
 
-		WorkflowId: wid,
-		RunId:      resp.GetRunId(),
-		Type:       workflowType,
-		Namespace:  namespace,
-		TaskQueue:  taskQueue,
-		Args:       truncate(payloads.ToString(input)),
+		WorkflowId: startRequest.GetWorkflowId(),
+		RunId:      runID,
+		Type:       startRequest.GetWorkflowType().GetName(),
+		Namespace:  startRequest.GetNamespace(),
+		TaskQueue:  startRequest.GetTaskQueue().GetName(),
+		Args:       truncate(payloads.ToString(startRequest.GetInput())),
 	}
 	data := []interface{}{
 		executionDetails,
 	}
-	fmt.Println(color.Magenta(c, "Running execution:"))
 	opts := &output.PrintOptions{
 		Fields:      []string{"WorkflowId", "RunId", "Type", "Namespace", "TaskQueue", "Args"},
 		IgnoreFlags: true,
@@ -201,8 +336,6 @@ func RunWorkflow(c *cli.Context) {
 		Separator:   "",
 	}
 	output.PrintItems(c, data, opts)
-
-	printWorkflowProgress(c, wid, resp.GetRunId())
 }
 
 func processSearchAttr(c *cli.Context) *commonpb.SearchAttributes {
@@ -219,17 +352,18 @@ func processSearchAttr(c *cli.Context) *commonpb.SearchAttributes {
 		return result
 	}
 
+	typed := processTypedSearchAttributes(c)
 	searchAttrKeys := sanitize(c.String(FlagSearchAttributesKey))
-	if len(searchAttrKeys) == 0 {
-		return nil
-	}
 	searchAttrVals := sanitize(c.String(FlagSearchAttributesVal))
-	if len(searchAttrVals) == 0 {
-		return nil
+	if len(searchAttrKeys) == 0 || len(searchAttrVals) == 0 {
+		if len(typed) == 0 {
+			return nil
+		}
+		return &commonpb.SearchAttributes{IndexedFields: typed}
 	}
 
 	if len(searchAttrKeys) != len(searchAttrVals) {
-		ErrorAndExit(fmt.Sprintf("Uneven number of search attributes keys (%d): %v and values(%d): %v.", len(searchAttrKeys), searchAttrKeys, len(searchAttrVals), searchAttrVals), nil)
+		process.UsageErrorAndExit(fmt.Sprintf("Uneven number of search attributes keys (%d): %v and values(%d): %v.", len(searchAttrKeys), searchAttrKeys, len(searchAttrVals), searchAttrVals))
 	}
 
 	searchAttributesStr := make(map[string]string, len(searchAttrKeys))
@@ -241,11 +375,18 @@ func processSearchAttr(c *cli.Context) *commonpb.SearchAttributes {
 	if err != nil {
 		ErrorAndExit("Unable to parse search attributes.", err)
 	}
+	for k, v := range typed {
+		searchAttributes.IndexedFields[k] = v
+	}
 
 	return searchAttributes
 }
 
 func processMemo(c *cli.Context) map[string]*commonpb.Payload {
+	if !c.IsSet(FlagMemoKey) && c.IsSet(FlagMemo) {
+		return processMemoPairs(c.StringSlice(FlagMemo))
+	}
+
 	rawMemoKey := c.String(FlagMemoKey)
 	var memoKeys []string
 	if strings.TrimSpace(rawMemoKey) != "" {
@@ -256,7 +397,7 @@ func processMemo(c *cli.Context) map[string]*commonpb.Payload {
 	if len(jsonsRaw) == 0 {
 		return nil
 	}
-	rawMemoValue := string(jsonsRaw[0]) // StringFlag may contain up to one json
+	rawMemoValue := string(bytes.Join(jsonsRaw, []byte(" ")))
 
 	if err := validateJSONs(rawMemoValue); err != nil {
 		ErrorAndExit("Input is not valid JSON, or JSONs concatenated with spaces/newlines.", err)
@@ -273,7 +414,7 @@ func processMemo(c *cli.Context) map[string]*commonpb.Payload {
 		ErrorAndExit("Parse json error.", err)
 	}
 	if len(memoKeys) != len(memoValues) {
-		ErrorAndExit("Number of memo keys and values are not equal.", nil)
+		process.UsageErrorAndExit("Number of memo keys and values are not equal.")
 	}
 
 	fields := map[string]*commonpb.Payload{}
@@ -355,6 +496,13 @@ func printWorkflowProgress(c *cli.Context, wid, rid string) {
 			fmt.Println(color.Magenta(c, "\nResult:"))
 			fmt.Printf("  Run Time: %d seconds\n", timeElapse)
 			printRunStatus(c, &lastEvent)
+			switch lastEvent.GetEventType() {
+			case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED,
+				enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT,
+				enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED,
+				enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+				process.Exit(process.ExitCodeWorkflowFailed)
+			}
 			return
 		}
 	}
@@ -362,6 +510,10 @@ func printWorkflowProgress(c *cli.Context, wid, rid string) {
 
 // TerminateWorkflow terminates a workflow execution
 func TerminateWorkflow(c *cli.Context) {
+	if c.IsSet(FlagListQuery) {
+		startBatchByQuery(c, batcher.BatchTypeTerminate, batcher.SignalParams{})
+		return
+	}
 	sdkClient := getSDKClient(c)
 
 	wid := getRequiredOption(c, FlagWorkflowID)
@@ -381,6 +533,10 @@ func TerminateWorkflow(c *cli.Context) {
 
 // CancelWorkflow cancels a workflow execution
 func CancelWorkflow(c *cli.Context) {
+	if c.IsSet(FlagListQuery) {
+		startBatchByQuery(c, batcher.BatchTypeCancel, batcher.SignalParams{})
+		return
+	}
 	sdkClient := getSDKClient(c)
 
 	wid := getRequiredOption(c, FlagWorkflowID)
@@ -399,6 +555,13 @@ func CancelWorkflow(c *cli.Context) {
 
 // SignalWorkflow signals a workflow execution
 func SignalWorkflow(c *cli.Context) {
+	if c.IsSet(FlagListQuery) {
+		startBatchByQuery(c, batcher.BatchTypeSignal, batcher.SignalParams{
+			SignalName: getRequiredOption(c, FlagName),
+			Input:      processJSONInput(c),
+		})
+		return
+	}
 	serviceClient := cFactory.FrontendClient(c)
 
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
@@ -438,16 +601,67 @@ func QueryWorkflow(c *cli.Context) {
 
 // QueryWorkflowUsingStackTrace query workflow execution using __stack_trace as query type
 func QueryWorkflowUsingStackTrace(c *cli.Context) {
-	queryWorkflowHelper(c, "__stack_trace")
+	queryResponse := queryWorkflow(c, "__stack_trace")
+	if queryResponse.QueryRejected != nil {
+		printQueryRejectedAndExit(c, queryResponse.QueryRejected)
+	}
+
+	var trace string
+	if err := payloads.Decode(queryResponse.QueryResult, &trace); err != nil {
+		trace = payloads.ToString(queryResponse.QueryResult)
+	}
+	fmt.Println(renderStackTrace(trace, newStackTraceColors(c)))
 }
 
 func queryWorkflowHelper(c *cli.Context, queryType string) {
+	queryResponse := queryWorkflow(c, queryType)
+	if queryResponse.QueryRejected != nil {
+		printQueryRejectedAndExit(c, queryResponse.QueryRejected)
+	}
+	queryResult := payloads.ToString(queryResponse.QueryResult)
+	fmt.Printf("Query result:\n%v\n", queryResult)
+}
+
+// encodeJSONArgs encodes each argument as a payload, decoded if it is JSON and as a string otherwise
+func encodeJSONArgs(args []string) *commonpb.Payloads {
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		var v interface{}
+		if err := json.Unmarshal([]byte(arg), &v); err != nil {
+			v = arg
+		}
+		values[i] = v
+	}
+	p, err := payloads.Encode(values...)
+	if err != nil {
+		ErrorAndExit("Unable to encode arguments.", err)
+	}
+	return p
+}
+
+// printQueryRejectedAndExit prints the rejection to stderr, so it is not mistaken for a query result,
+// and exits with ExitCodeQueryRejected
+func printQueryRejectedAndExit(c *cli.Context, rejected *querypb.QueryRejected) {
+	fmt.Fprintf(os.Stderr, "%s: the workflow has status %v and %s is %s\n",
+		color.Red(c, "Query was rejected"), rejected.GetStatus(), FlagQueryRejectCondition, c.String(FlagQueryRejectCondition))
+	process.Exit(process.ExitCodeQueryRejected)
+}
+
+func queryWorkflow(c *cli.Context, queryType string) *workflowservice.QueryWorkflowResponse {
 	serviceClient := cFactory.FrontendClient(c)
 
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
 	wid := getRequiredOption(c, FlagWorkflowID)
 	rid := c.String(FlagRunID)
 	input := processJSONInput(c)
+	if c.NArg() > 0 {
+		args := encodeJSONArgs(c.Args().Slice())
+		if input == nil {
+			input = args
+		} else {
+			input.Payloads = append(input.Payloads, args.Payloads...)
+		}
+	}
 
 	tcCtx, cancel := newContext(c)
 	defer cancel()
@@ -472,22 +686,15 @@ func queryWorkflowHelper(c *cli.Context, queryType string) {
 		case "not_completed_cleanly":
 			rejectCondition = enumspb.QUERY_REJECT_CONDITION_NOT_COMPLETED_CLEANLY
 		default:
-			ErrorAndExit(fmt.Sprintf("invalid reject condition %v, valid values are \"not_open\" and \"not_completed_cleanly\"", c.String(FlagQueryRejectCondition)), nil)
+			process.UsageErrorAndExit(fmt.Sprintf("invalid reject condition %v, valid values are \"not_open\" and \"not_completed_cleanly\"", c.String(FlagQueryRejectCondition)))
 		}
 		queryRequest.QueryRejectCondition = rejectCondition
 	}
 	queryResponse, err := serviceClient.QueryWorkflow(tcCtx, queryRequest)
 	if err != nil {
 		ErrorAndExit("Query workflow failed.", err)
-		return
-	}
-
-	if queryResponse.QueryRejected != nil {
-		fmt.Printf("Query was rejected, workflow has status: %v\n", queryResponse.QueryRejected.GetStatus())
-	} else {
-		queryResult := payloads.ToString(queryResponse.QueryResult)
-		fmt.Printf("Query result:\n%v\n", queryResult)
 	}
+	return queryResponse
 }
 
 // ListWorkflow list workflow executions based on filters
@@ -503,6 +710,14 @@ func ListWorkflow(c *cli.Context) {
 		ErrorAndExit("Failed to parse Workflow Status", err)
 	}
 	wfStatus := enumspb.WorkflowExecutionStatus(wfStatusInt)
+	useQuery := c.IsSet(FlagListQuery) || c.IsSet(FlagSavedQuery) || c.Bool(FlagBuildQuery)
+	var query string
+	if useQuery {
+		query = getListQuery(c)
+	}
+	if c.Bool(FlagBuildQuery) {
+		query = combineQueries(query, buildQueryInteractively(c))
+	}
 	client := cFactory.FrontendClient(c)
 
 	paginationFunc := func(npt []byte) ([]interface{}, []byte, error) {
@@ -510,8 +725,7 @@ func ListWorkflow(c *cli.Context) {
 		defer cancel()
 		var items []interface{}
 		var err error
-		if c.IsSet(FlagListQuery) {
-			query := c.String(FlagListQuery)
+		if useQuery {
 			items, npt, err = listWorkflows(ctx, client, npt, namespace, query)
 		} else if queryOpen {
 			items, npt, err = listOpenWorkflows(ctx, client, npt, namespace, earliestTime, latestTime, workflowID, workflowType)
@@ -525,18 +739,21 @@ func ListWorkflow(c *cli.Context) {
 		return items, npt, nil
 	}
 
-	iter := collection.NewPagingIterator(paginationFunc)
+	iter := output.NewPagingIterator(c, paginationFunc)
 	opts := &output.PrintOptions{
-		Fields:     []string{"Execution.WorkflowId", "Execution.RunId", "StartTime"},
-		FieldsLong: []string{"Type.Name", "TaskQueue", "ExecutionTime", "CloseTime"},
+		Fields:       []string{"Execution.WorkflowId", "Execution.RunId", "StartTime"},
+		FieldsLong:   []string{"Type.Name", "TaskQueue", "ExecutionTime", "CloseTime"},
+		ItemTemplate: &workflowpb.WorkflowExecutionInfo{},
+	}
+	if err := output.Pager(c, iter, opts); err != nil {
+		ErrorAndExit("Unable to list workflows.", err)
 	}
-	output.Pager(c, iter, opts)
 }
 
 // ScanAllWorkflow list all workflow executions using Scan API.
 func ScanAllWorkflow(c *cli.Context) {
 	namespace := getRequiredGlobalOption(c, FlagNamespace)
-	listQuery := c.String(FlagListQuery)
+	listQuery := getListQuery(c)
 	client := cFactory.FrontendClient(c)
 
 	paginationFunc := func(npt []byte) ([]interface{}, []byte, error) {
@@ -565,21 +782,29 @@ func ScanAllWorkflow(c *cli.Context) {
 		return items, resp.NextPageToken, nil
 	}
 
-	iter := collection.NewPagingIterator(paginationFunc)
+	iter := output.NewPagingIterator(c, paginationFunc)
 	opts := &output.PrintOptions{
-		Fields:     []string{"Execution.WorkflowId", "Execution.RunId", "StartTime"},
-		FieldsLong: []string{"Type.Name", "TaskQueue", "ExecutionTime", "CloseTime"},
+		Fields:       []string{"Execution.WorkflowId", "Execution.RunId", "StartTime"},
+		FieldsLong:   []string{"Type.Name", "TaskQueue", "ExecutionTime", "CloseTime"},
+		ItemTemplate: &workflowpb.WorkflowExecutionInfo{},
+	}
+	if err := output.Pager(c, iter, opts); err != nil {
+		ErrorAndExit("Unable to scan workflows.", err)
 	}
-	output.Pager(c, iter, opts)
 }
 
 // CountWorkflow count number of workflows
 func CountWorkflow(c *cli.Context) {
+	if c.IsSet(FlagGroupBy) {
+		countWorkflowsByGroup(c)
+		return
+	}
+
 	client := cFactory.FrontendClient(c)
 
 	request := &workflowservice.CountWorkflowExecutionsRequest{
 		Namespace: getRequiredGlobalOption(c, FlagNamespace),
-		Query:     c.String(FlagListQuery),
+		Query:     getListQuery(c),
 	}
 
 	ctx, cancel := newContextForVisibility(c)
@@ -612,6 +837,7 @@ func ListArchivedWorkflow(c *cli.Context) {
 		// the executions will be empty if the query is still running before timeout
 		// so keep calling the API until some results are returned (query completed)
 		req.NextPageToken = npt
+		resp = nil
 		for resp == nil || (len(resp.Executions) == 0 && resp.NextPageToken != nil) {
 			ctx, cancel := context.WithTimeout(context.Background(), contextTimeout)
 			resp, err = client.ListArchivedWorkflowExecutions(ctx, req)
@@ -630,12 +856,15 @@ func ListArchivedWorkflow(c *cli.Context) {
 		return items, resp.NextPageToken, nil
 	}
 
-	iter := collection.NewPagingIterator(paginationFunc)
+	iter := output.NewPagingIterator(c, paginationFunc)
 	opts := &output.PrintOptions{
-		Fields:     []string{"Execution.WorkflowId", "Execution.RunId", "StartTime"},
-		FieldsLong: []string{"Type.Name", "TaskQueue", "ExecutionTime", "CloseTime"},
+		Fields:       []string{"Execution.WorkflowId", "Execution.RunId", "StartTime"},
+		FieldsLong:   []string{"Type.Name", "TaskQueue", "ExecutionTime", "CloseTime"},
+		ItemTemplate: &workflowpb.WorkflowExecutionInfo{},
+	}
+	if err := output.Pager(c, iter, opts); err != nil {
+		ErrorAndExit("Unable to list archived workflows.", err)
 	}
-	output.Pager(c, iter, opts)
 }
 
 // DescribeWorkflow show information about the specified workflow execution
@@ -669,6 +898,13 @@ func DescribeWorkflow(c *cli.Context) {
 
 	if printRaw {
 		prettyPrintJSONObject(resp)
+	} else if c.Bool(FlagPendingTables) {
+		converted := convertDescribeWorkflowExecutionResponse(c, resp)
+		// the pending activities, children and workflow task are printed as tables
+		converted.PendingActivities = nil
+		converted.PendingChildren = nil
+		prettyPrintJSONObject(converted)
+		printPendingDetails(c, wid, rid, resp)
 	} else {
 		prettyPrintJSONObject(convertDescribeWorkflowExecutionResponse(c, resp))
 	}
@@ -796,6 +1032,9 @@ func printRunStatus(c *cli.Context, event *historypb.HistoryEvent) {
 		fmt.Printf("  Status: %s\n", color.Red(c, "CANCELED"))
 		details := payloads.ToString(event.GetWorkflowExecutionCanceledEventAttributes().GetDetails())
 		fmt.Printf("  Detail: %s\n", details)
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		fmt.Printf("  Status: %s\n", color.Red(c, "TERMINATED"))
+		fmt.Printf("  Reason: %s\n", event.GetWorkflowExecutionTerminatedEventAttributes().GetReason())
 	}
 }
 
@@ -818,6 +1057,10 @@ func scanWorkflowExecutions(sdkClient sdkclient.Client, pageSize int, nextPageTo
 
 // ObserveHistory show the process of running workflow
 func ObserveHistory(c *cli.Context) {
+	if c.IsSet(FlagWorkflowStatus) || c.IsSet(FlagUntil) {
+		ObserveWorkflowUntil(c)
+		return
+	}
 	wid, rid := getWorkflowParams(c)
 
 	printWorkflowProgress(c, wid, rid)
@@ -836,7 +1079,7 @@ func ResetWorkflow(c *cli.Context) {
 	resetType := c.String(FlagResetType)
 	extraForResetType, ok := resetTypesMap[resetType]
 	if !ok && eventID <= 0 {
-		ErrorAndExit(fmt.Sprintf("must specify either valid event_id or reset_type (one of %s)", strings.Join(mapKeysToArray(resetTypesMap), ", ")), nil)
+		process.UsageErrorAndExit(fmt.Sprintf("must specify either valid event_id or reset_type (one of %s)", strings.Join(mapKeysToArray(resetTypesMap), ", ")))
 	}
 	if ok && len(extraForResetType) > 0 {
 		getRequiredOption(c, extraForResetType)
@@ -865,6 +1108,7 @@ func ResetWorkflow(c *cli.Context) {
 		Reason:                    fmt.Sprintf("%v:%v", getCurrentUserFromEnv(), reason),
 		WorkflowTaskFinishEventId: workflowTaskFinishID,
 		RequestId:                 uuid.New(),
+		ResetReapplyType:          getResetReapplyType(c),
 	})
 	if err != nil {
 		ErrorAndExit("reset failed", err)
@@ -872,16 +1116,17 @@ func ResetWorkflow(c *cli.Context) {
 	prettyPrintJSONObject(resp)
 }
 
-func processResets(c *cli.Context, namespace string, wes chan commonpb.WorkflowExecution, done chan bool, wg *sync.WaitGroup, params batchResetParamsType) {
+func processResets(c *cli.Context, namespace string, wes chan commonpb.WorkflowExecution, done chan bool, wg *sync.WaitGroup, params batchResetParamsType, report *resetReport) {
 	for {
 		select {
 		case we := <-wes:
 			fmt.Println("received: ", we.GetWorkflowId(), we.GetRunId())
 			wid := we.GetWorkflowId()
 			rid := we.GetRunId()
+			var outcome resetOutcome
 			var err error
 			for i := 0; i < 3; i++ {
-				err = doReset(c, namespace, wid, rid, params)
+				outcome, err = doReset(c, namespace, wid, rid, params)
 				if err == nil {
 					break
 				}
@@ -895,6 +1140,7 @@ func processResets(c *cli.Context, namespace string, wes chan commonpb.WorkflowE
 			if err != nil {
 				fmt.Println("[ERROR] failed processing: ", wid, rid, err.Error())
 			}
+			report.add(wid, rid, outcome, err)
 		case <-done:
 			wg.Done()
 			return
@@ -909,6 +1155,7 @@ type batchResetParamsType struct {
 	skipBaseNotCurrent   bool
 	dryRun               bool
 	resetType            string
+	reapplyType          enumspb.ResetReapplyType
 }
 
 // ResetInBatch resets workflow in batch
@@ -924,7 +1171,7 @@ func ResetInBatch(c *cli.Context) {
 
 	extraForResetType, ok := resetTypesMap[resetType]
 	if !ok {
-		ErrorAndExit("Not supported reset type", nil)
+		process.UsageErrorAndExit("Not supported reset type")
 	} else if len(extraForResetType) > 0 {
 		getRequiredOption(c, extraForResetType)
 	}
@@ -936,19 +1183,24 @@ func ResetInBatch(c *cli.Context) {
 		skipBaseNotCurrent:   c.Bool(FlagSkipBaseIsNotCurrent),
 		dryRun:               c.Bool(FlagDryRun),
 		resetType:            resetType,
+		reapplyType:          getResetReapplyType(c),
 	}
 
 	if inFileName == "" && query == "" {
-		ErrorAndExit("Must provide input file or list query to get target workflows to reset", nil)
+		process.UsageErrorAndExit("Must provide input file or list query to get target workflows to reset")
 	}
 
+	processed := readProgressFile(c.String(FlagProgressFile))
+	report := newResetReport(c.String(FlagProgressFile), c.String(FlagReportFile))
+	defer report.close()
+
 	wg := &sync.WaitGroup{}
 
 	wes := make(chan commonpb.WorkflowExecution)
 	done := make(chan bool)
 	for i := 0; i < parallel; i++ {
 		wg.Add(1)
-		go processResets(c, namespace, wes, done, wg, batchResetParams)
+		go processResets(c, namespace, wes, done, wg, batchResetParams, report)
 	}
 
 	// read exclude
@@ -982,11 +1234,15 @@ func ResetInBatch(c *cli.Context) {
 	fmt.Println("num of excludes:", len(excludes))
 
 	if len(inFileName) > 0 {
-		inFile, err := os.Open(inFileName)
-		if err != nil {
-			ErrorAndExit("Open failed", err)
+		inFile := os.Stdin
+		if inFileName != stdinInput {
+			var err error
+			inFile, err = os.Open(inFileName)
+			if err != nil {
+				ErrorAndExit("Open failed", err)
+			}
+			defer inFile.Close()
 		}
-		defer inFile.Close()
 		scanner := bufio.NewScanner(inFile)
 		idx := 0
 		for scanner.Scan() {
@@ -1012,6 +1268,10 @@ func ResetInBatch(c *cli.Context) {
 				fmt.Println("skip by exclude file: ", wid, rid)
 				continue
 			}
+			if processed[resetProgressKey(wid, rid)] {
+				fmt.Println("skip by progress file: ", wid, rid)
+				continue
+			}
 
 			wes <- commonpb.WorkflowExecution{
 				WorkflowId: wid,
@@ -1033,6 +1293,10 @@ func ResetInBatch(c *cli.Context) {
 					fmt.Println("skip by exclude file: ", wid, rid)
 					continue
 				}
+				if processed[resetProgressKey(wid, rid)] {
+					fmt.Println("skip by progress file: ", wid, rid)
+					continue
+				}
 
 				wes <- commonpb.WorkflowExecution{
 					WorkflowId: wid,
@@ -1056,7 +1320,7 @@ func printErrorAndReturn(msg string, err error) error {
 	return err
 }
 
-func doReset(c *cli.Context, namespace, wid, rid string, params batchResetParamsType) error {
+func doReset(c *cli.Context, namespace, wid, rid string, params batchResetParamsType) (resetOutcome, error) {
 	ctx, cancel := newContext(c)
 	defer cancel()
 
@@ -1068,13 +1332,13 @@ func doReset(c *cli.Context, namespace, wid, rid string, params batchResetParams
 		},
 	})
 	if err != nil {
-		return printErrorAndReturn("DescribeWorkflowExecution failed", err)
+		return resetOutcome{}, printErrorAndReturn("DescribeWorkflowExecution failed", err)
 	}
 
 	currentRunID := resp.WorkflowExecutionInfo.Execution.GetRunId()
 	if currentRunID != rid && params.skipBaseNotCurrent {
 		fmt.Println("skip because base run is different from current run: ", wid, rid, currentRunID)
-		return nil
+		return resetOutcome{Status: resetSkipped, Reason: "base run is not current"}, nil
 	}
 	if rid == "" {
 		rid = currentRunID
@@ -1084,27 +1348,28 @@ func doReset(c *cli.Context, namespace, wid, rid string, params batchResetParams
 		if params.skipOpen {
 			fmt.Println("skip because current run is open: ", wid, rid, currentRunID)
 			// skip and not terminate current if open
-			return nil
+			return resetOutcome{Status: resetSkipped, Reason: "current run is open"}, nil
 		}
 	}
 
 	if params.nonDeterministicOnly {
 		isLDN, err := isLastEventWorkflowTaskFailedWithNonDeterminism(ctx, namespace, wid, rid, frontendClient)
 		if err != nil {
-			return printErrorAndReturn("check isLastEventWorkflowTaskFailedWithNonDeterminism failed", err)
+			return resetOutcome{}, printErrorAndReturn("check isLastEventWorkflowTaskFailedWithNonDeterminism failed", err)
 		}
 		if !isLDN {
 			fmt.Println("skip because last event is not WorkflowTaskFailedWithNonDeterminism")
-			return nil
+			return resetOutcome{Status: resetSkipped, Reason: "last event is not WorkflowTaskFailedWithNonDeterminism"}, nil
 		}
 	}
 
 	resetBaseRunID, workflowTaskFinishID, err := getResetEventIDByType(ctx, c, params.resetType, namespace, wid, rid, frontendClient)
 	if err != nil {
-		return printErrorAndReturn("getResetEventIDByType failed", err)
+		return resetOutcome{}, printErrorAndReturn("getResetEventIDByType failed", err)
 	}
 	fmt.Println("WorkflowTaskFinishEventId for reset:", wid, rid, resetBaseRunID, workflowTaskFinishID)
 
+	outcome := resetOutcome{Status: resetDryRun, BaseRunId: resetBaseRunID, EventId: workflowTaskFinishID}
 	if params.dryRun {
 		fmt.Printf("dry run to reset wid: %v, rid:%v to baseRunId:%v, eventId:%v \n", wid, rid, resetBaseRunID, workflowTaskFinishID)
 	} else {
@@ -1117,15 +1382,18 @@ func doReset(c *cli.Context, namespace, wid, rid string, params batchResetParams
 			WorkflowTaskFinishEventId: workflowTaskFinishID,
 			RequestId:                 uuid.New(),
 			Reason:                    fmt.Sprintf("%v:%v", getCurrentUserFromEnv(), params.reason),
+			ResetReapplyType:          params.reapplyType,
 		})
 
 		if err != nil {
-			return printErrorAndReturn("ResetWorkflowExecution failed", err)
+			return resetOutcome{}, printErrorAndReturn("ResetWorkflowExecution failed", err)
 		}
 		fmt.Println("new runId for wid/rid is ,", wid, rid, resp2.GetRunId())
+		outcome.Status = resetDone
+		outcome.NewRunId = resp2.GetRunId()
 	}
 
-	return nil
+	return outcome, nil
 }
 
 func isLastEventWorkflowTaskFailedWithNonDeterminism(ctx context.Context, namespace, wid, rid string, frontendClient workflowservice.WorkflowServiceClient) (bool, error) {
@@ -1175,6 +1443,18 @@ func isLastEventWorkflowTaskFailedWithNonDeterminism(ctx context.Context, namesp
 	return false, nil
 }
 
+// getResetReapplyType parses --reapply-type, the events are reapplied by the server default if it is not set
+func getResetReapplyType(c *cli.Context) enumspb.ResetReapplyType {
+	if !c.IsSet(FlagReapplyType) {
+		return enumspb.RESET_REAPPLY_TYPE_UNSPECIFIED
+	}
+	reapplyType, err := stringToEnum(c.String(FlagReapplyType), enumspb.ResetReapplyType_value)
+	if err != nil {
+		ErrorAndExit("Failed to parse Reapply Type", err)
+	}
+	return enumspb.ResetReapplyType(reapplyType)
+}
+
 func getResetEventIDByType(ctx context.Context, c *cli.Context, resetType, namespace, wid, rid string, frontendClient workflowservice.WorkflowServiceClient) (resetBaseRunID string, workflowTaskFinishID int64, err error) {
 	fmt.Println("resetType:", resetType)
 	switch resetType {
@@ -1199,6 +1479,11 @@ func getResetEventIDByType(ctx context.Context, c *cli.Context, resetType, names
 		if err != nil {
 			return
 		}
+	case "BuildId":
+		resetBaseRunID, workflowTaskFinishID, err = getFirstWorkflowTaskCompletedIDOfBuild(ctx, namespace, wid, rid, c.String(FlagResetBuildID), frontendClient)
+		if err != nil {
+			return
+		}
 	default:
 		panic("not supported resetType")
 	}
@@ -1270,6 +1555,39 @@ func getBadWorkflowTaskCompletedID(ctx context.Context, namespace, wid, rid, bin
 	return
 }
 
+// getFirstWorkflowTaskCompletedIDOfBuild finds the first workflow task completed by the worker build,
+// which is reported as the binary checksum
+func getFirstWorkflowTaskCompletedIDOfBuild(ctx context.Context, namespace, wid, rid, buildID string, frontendClient workflowservice.WorkflowServiceClient) (resetBaseRunID string, workflowTaskCompletedID int64, err error) {
+	resetBaseRunID = rid
+	req := &workflowservice.GetWorkflowExecutionHistoryRequest{
+		Namespace: namespace,
+		Execution: &commonpb.WorkflowExecution{
+			WorkflowId: wid,
+			RunId:      rid,
+		},
+		MaximumPageSize: 1000,
+		NextPageToken:   nil,
+	}
+	for {
+		resp, err := frontendClient.GetWorkflowExecutionHistory(ctx, req)
+		if err != nil {
+			return "", 0, printErrorAndReturn("GetWorkflowExecutionHistory failed", err)
+		}
+		for _, e := range resp.GetHistory().GetEvents() {
+			if e.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED &&
+				e.GetWorkflowTaskCompletedEventAttributes().GetBinaryChecksum() == buildID {
+				return resetBaseRunID, e.GetEventId(), nil
+			}
+		}
+		if len(resp.NextPageToken) != 0 {
+			req.NextPageToken = resp.NextPageToken
+		} else {
+			break
+		}
+	}
+	return "", 0, printErrorAndReturn("Get FirstWorkflowTaskCompletedID of build failed", serviceerror.NewInvalidArgument(fmt.Sprintf("no workflow task completed by build %s", buildID)))
+}
+
 // Returns id of the first workflow task completed event or if it doesn't exist then id of the event after task scheduled event.
 func getFirstWorkflowTaskEventID(ctx context.Context, namespace, wid, rid string, frontendClient workflowservice.WorkflowServiceClient) (resetBaseRunID string, workflowTaskEventID int64, err error) {
 	resetBaseRunID = rid
@@ -1380,8 +1698,9 @@ func getWorkflowParams(c *cli.Context) (string, string) {
 
 func listWorkflows(ctx context.Context, client workflowservice.WorkflowServiceClient, npt []byte, namespace string, query string) ([]interface{}, []byte, error) {
 	req := &workflowservice.ListWorkflowExecutionsRequest{
-		Namespace: namespace,
-		Query:     query,
+		Namespace:     namespace,
+		Query:         query,
+		NextPageToken: npt,
 	}
 	resp, err := client.ListWorkflowExecutions(ctx, req)
 	if err != nil {
@@ -1398,7 +1717,8 @@ func listWorkflows(ctx context.Context, client workflowservice.WorkflowServiceCl
 
 func listOpenWorkflows(ctx context.Context, client workflowservice.WorkflowServiceClient, npt []byte, namespace string, earliestTime, latestTime time.Time, wfID, wfType string) ([]interface{}, []byte, error) {
 	req := &workflowservice.ListOpenWorkflowExecutionsRequest{
-		Namespace: namespace,
+		Namespace:     namespace,
+		NextPageToken: npt,
 		StartTimeFilter: &filterpb.StartTimeFilter{
 			EarliestTime: &earliestTime,
 			LatestTime:   &latestTime,
@@ -1425,7 +1745,8 @@ func listOpenWorkflows(ctx context.Context, client workflowservice.WorkflowServi
 func listClosedWorkflows(ctx context.Context, client workflowservice.WorkflowServiceClient, npt []byte, namespace string, earliestTime, latestTime time.Time, wfID, wfType string,
 	wfStatus enumspb.WorkflowExecutionStatus) ([]interface{}, []byte, error) {
 	req := &workflowservice.ListClosedWorkflowExecutionsRequest{
-		Namespace: namespace,
+		Namespace:     namespace,
+		NextPageToken: npt,
 		StartTimeFilter: &filterpb.StartTimeFilter{
 			EarliestTime: &earliestTime,
 			LatestTime:   &latestTime,
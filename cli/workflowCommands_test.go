@@ -0,0 +1,179 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/api/workflowservicemock/v1"
+)
+
+type workflowCommandsSuite struct {
+	*require.Assertions
+	suite.Suite
+	controller *gomock.Controller
+	client     *workflowservicemock.MockWorkflowServiceClient
+}
+
+func TestWorkflowCommandsSuite(t *testing.T) {
+	suite.Run(t, new(workflowCommandsSuite))
+}
+
+func (s *workflowCommandsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.controller = gomock.NewController(s.T())
+	s.client = workflowservicemock.NewMockWorkflowServiceClient(s.controller)
+}
+
+func (s *workflowCommandsSuite) TearDownTest() {
+	s.controller.Finish()
+}
+
+func workflowTaskCompleted(id int64, buildID string) *historypb.HistoryEvent {
+	return &historypb.HistoryEvent{EventId: id, EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED, Attributes: &historypb.HistoryEvent_WorkflowTaskCompletedEventAttributes{
+		WorkflowTaskCompletedEventAttributes: &historypb.WorkflowTaskCompletedEventAttributes{BinaryChecksum: buildID}}}
+}
+
+// expectHistory returns the pages of events from GetWorkflowExecutionHistory, chained by the page tokens
+func (s *workflowCommandsSuite) expectHistory(pages ...[]*historypb.HistoryEvent) {
+	for i, events := range pages {
+		var token, next []byte
+		if i > 0 {
+			token = []byte{byte(i)}
+		}
+		if i < len(pages)-1 {
+			next = []byte{byte(i + 1)}
+		}
+		resp := &workflowservice.GetWorkflowExecutionHistoryResponse{History: &historypb.History{Events: events}, NextPageToken: next}
+		s.client.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req *workflowservice.GetWorkflowExecutionHistoryRequest, _ ...interface{}) (*workflowservice.GetWorkflowExecutionHistoryResponse, error) {
+				s.Equal("orders", req.GetNamespace())
+				s.Equal(&commonpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"}, req.GetExecution())
+				s.Equal(token, req.GetNextPageToken())
+				return resp, nil
+			})
+	}
+}
+
+func (s *workflowCommandsSuite) TestGetFirstWorkflowTaskCompletedIDOfBuild() {
+	s.expectHistory(
+		[]*historypb.HistoryEvent{workflowTaskCompleted(4, "build-1"), workflowTaskCompleted(8, "build-1")},
+		[]*historypb.HistoryEvent{workflowTaskCompleted(12, "build-2"), workflowTaskCompleted(16, "build-2")},
+	)
+	runID, eventID, err := getFirstWorkflowTaskCompletedIDOfBuild(context.Background(), "orders", "wid", "rid", "build-2", s.client)
+	s.NoError(err)
+	s.Equal("rid", runID)
+	s.Equal(int64(12), eventID)
+}
+
+func (s *workflowCommandsSuite) TestGetFirstWorkflowTaskCompletedIDOfBuild_NotFound() {
+	s.expectHistory(
+		[]*historypb.HistoryEvent{workflowTaskCompleted(4, "build-1")},
+		[]*historypb.HistoryEvent{{EventId: 5, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED}},
+	)
+	_, _, err := getFirstWorkflowTaskCompletedIDOfBuild(context.Background(), "orders", "wid", "rid", "build-2", s.client)
+	var invalidArgument *serviceerror.InvalidArgument
+	s.ErrorAs(err, &invalidArgument)
+	s.Contains(err.Error(), "no workflow task completed by build build-2")
+}
+
+func (s *workflowCommandsSuite) TestGetFirstWorkflowTaskCompletedIDOfBuild_HistoryError() {
+	s.client.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any()).Return(nil, serviceerror.NewNotFound("workflow not found"))
+	_, _, err := getFirstWorkflowTaskCompletedIDOfBuild(context.Background(), "orders", "wid", "rid", "build-1", s.client)
+	s.EqualError(err, "workflow not found")
+}
+
+func (s *workflowCommandsSuite) TestGetResetEventIDByType_BuildId() {
+	s.expectHistory([]*historypb.HistoryEvent{workflowTaskCompleted(4, "build-1"), workflowTaskCompleted(8, "build-2")})
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(FlagResetBuildID, "", "")
+	s.NoError(set.Parse([]string{"--" + FlagResetBuildID, "build-2"}))
+	c := cli.NewContext(cli.NewApp(), set, nil)
+
+	runID, eventID, err := getResetEventIDByType(context.Background(), c, "BuildId", "orders", "wid", "rid", s.client)
+	s.NoError(err)
+	s.Equal("rid", runID)
+	s.Equal(int64(8), eventID)
+}
+
+// frontendFactory returns the mock client as the frontend client
+type frontendFactory struct {
+	ClientFactory
+	client workflowservice.WorkflowServiceClient
+}
+
+func (f frontendFactory) FrontendClient(c *cli.Context) workflowservice.WorkflowServiceClient {
+	return f.client
+}
+
+func (s *workflowCommandsSuite) TestGetHistoryEvents() {
+	defer func(factory ClientFactory) { cFactory = factory }(cFactory)
+	cFactory = frontendFactory{client: s.client}
+	for i, token := range [][]byte{nil, {1}} {
+		resp := &workflowservice.GetWorkflowExecutionHistoryResponse{
+			History: &historypb.History{Events: []*historypb.HistoryEvent{workflowTaskCompleted(int64(4*(i+1)), "build-1")}},
+		}
+		if token == nil {
+			resp.NextPageToken = []byte{1}
+		}
+		s.client.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), &workflowservice.GetWorkflowExecutionHistoryRequest{
+			Namespace:              "orders",
+			Execution:              &commonpb.WorkflowExecution{WorkflowId: "wid", RunId: "rid"},
+			NextPageToken:          token,
+			HistoryEventFilterType: enumspb.HISTORY_EVENT_FILTER_TYPE_ALL_EVENT,
+			MaximumPageSize:        historyPageSize,
+		}).Return(resp, nil)
+	}
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(FlagNamespace, "orders", "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+
+	events := getHistoryEvents(c, "wid", "rid")
+	s.Len(events, 2)
+	s.Equal(int64(8), events[1].GetEventId())
+}
+
+func (s *workflowCommandsSuite) TestGetResetReapplyType() {
+	reapplyContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		set.String(FlagReapplyType, "", "")
+		s.NoError(set.Parse(args))
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+
+	s.Equal(enumspb.RESET_REAPPLY_TYPE_UNSPECIFIED, getResetReapplyType(reapplyContext()))
+	s.Equal(enumspb.RESET_REAPPLY_TYPE_SIGNAL, getResetReapplyType(reapplyContext("--"+FlagReapplyType, "Signal")))
+	s.Equal(enumspb.RESET_REAPPLY_TYPE_NONE, getResetReapplyType(reapplyContext("--"+FlagReapplyType, "none")))
+}
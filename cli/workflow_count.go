@@ -0,0 +1,155 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/payload"
+	"go.temporal.io/server/common/searchattribute"
+
+	"github.com/temporalio/tctl/pkg/output"
+)
+
+type countGroupRow struct {
+	Group string
+	Count int64
+}
+
+// countWorkflowsByGroup prints the number of workflows of each value of --group-by.
+// ExecutionStatus is counted with one CountWorkflowExecutions call per status,
+// other attributes are aggregated over the scanned executions
+func countWorkflowsByGroup(c *cli.Context) {
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	query := getListQuery(c)
+	groupBy := c.String(FlagGroupBy)
+
+	var rows []countGroupRow
+	if groupBy == searchattribute.ExecutionStatus {
+		rows = countByExecutionStatus(c, namespace, query)
+	} else {
+		rows = countByScan(c, namespace, query, groupBy)
+	}
+
+	items := make([]interface{}, len(rows))
+	for i, r := range rows {
+		items[i] = r
+	}
+	opts := &output.PrintOptions{
+		Fields: []string{"Group", "Count"},
+	}
+	output.PrintItems(c, items, opts)
+}
+
+func countByExecutionStatus(c *cli.Context, namespace, query string) []countGroupRow {
+	client := cFactory.FrontendClient(c)
+
+	var rows []countGroupRow
+	for s := enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING; s <= enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT; s++ {
+		statusQuery := fmt.Sprintf("%s = '%s'", searchattribute.ExecutionStatus, s)
+		if query != "" {
+			statusQuery = fmt.Sprintf("(%s) AND %s", query, statusQuery)
+		}
+
+		ctx, cancel := newContextForVisibility(c)
+		resp, err := client.CountWorkflowExecutions(ctx, &workflowservice.CountWorkflowExecutionsRequest{
+			Namespace: namespace,
+			Query:     statusQuery,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("Unable to count workflow.", err)
+		}
+		rows = append(rows, countGroupRow{Group: s.String(), Count: resp.GetCount()})
+	}
+	return rows
+}
+
+func countByScan(c *cli.Context, namespace, query, groupBy string) []countGroupRow {
+	client := cFactory.FrontendClient(c)
+
+	counts := make(map[string]int64)
+	var npt []byte
+	for {
+		ctx, cancel := newContextForVisibility(c)
+		resp, err := client.ScanWorkflowExecutions(ctx, &workflowservice.ScanWorkflowExecutionsRequest{
+			Namespace:     namespace,
+			NextPageToken: npt,
+			Query:         query,
+		})
+		cancel()
+		if err != nil {
+			ErrorAndExit("Unable to count workflow.", err)
+		}
+		for _, e := range resp.GetExecutions() {
+			counts[workflowGroupKey(e, groupBy)]++
+		}
+		npt = resp.GetNextPageToken()
+		if len(npt) == 0 {
+			break
+		}
+	}
+	return sortCountGroups(counts)
+}
+
+// workflowGroupKey returns the value of the grouping attribute of the workflow,
+// empty if the workflow does not have it
+func workflowGroupKey(e *workflowpb.WorkflowExecutionInfo, groupBy string) string {
+	switch groupBy {
+	case searchattribute.WorkflowType:
+		return e.GetType().GetName()
+	case searchattribute.TaskQueue:
+		return e.GetTaskQueue()
+	}
+
+	p, ok := e.GetSearchAttributes().GetIndexedFields()[groupBy]
+	if !ok {
+		return ""
+	}
+	var val interface{}
+	if err := payload.Decode(p, &val); err != nil {
+		return string(p.GetData())
+	}
+	return fmt.Sprint(val)
+}
+
+// sortCountGroups orders the groups by count, largest first
+func sortCountGroups(counts map[string]int64) []countGroupRow {
+	rows := make([]countGroupRow, 0, len(counts))
+	for group, count := range counts {
+		rows = append(rows, countGroupRow{Group: group, Count: count})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Group < rows[j].Group
+	})
+	return rows
+}
@@ -0,0 +1,66 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/server/common/payload"
+)
+
+type workflowCountSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestWorkflowCountSuite(t *testing.T) {
+	suite.Run(t, new(workflowCountSuite))
+}
+
+func (s *workflowCountSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *workflowCountSuite) TestWorkflowGroupKey() {
+	e := &workflowpb.WorkflowExecutionInfo{
+		Type:      &commonpb.WorkflowType{Name: "order"},
+		TaskQueue: "orders",
+		SearchAttributes: &commonpb.SearchAttributes{IndexedFields: map[string]*commonpb.Payload{
+			"CustomerTier": payload.EncodeString("gold"),
+		}},
+	}
+	s.Equal("order", workflowGroupKey(e, "WorkflowType"))
+	s.Equal("orders", workflowGroupKey(e, "TaskQueue"))
+	s.Equal("gold", workflowGroupKey(e, "CustomerTier"))
+	s.Equal("", workflowGroupKey(e, "Missing"))
+}
+
+func (s *workflowCountSuite) TestSortCountGroups() {
+	rows := sortCountGroups(map[string]int64{"b": 2, "a": 2, "c": 5})
+	s.Equal([]countGroupRow{{"c", 5}, {"a", 2}, {"b", 2}}, rows)
+}
@@ -0,0 +1,177 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"github.com/temporalio/tctl/cli/dataconverter"
+	"github.com/temporalio/tctl/pkg/output"
+)
+
+type pendingActivityRow struct {
+	ActivityId         string
+	ActivityType       string
+	State              string
+	Attempt            string
+	ScheduledTime      *time.Time
+	LastStartedTime    *time.Time
+	LastHeartbeatTime  *time.Time
+	HeartbeatDetails   string
+	LastFailure        string
+	LastWorkerIdentity string
+}
+
+type pendingChildRow struct {
+	WorkflowId        string
+	RunId             string
+	WorkflowType      string
+	InitiatedId       int64
+	ParentClosePolicy string
+}
+
+// pendingWorkflowTaskRow is the workflow task scheduled after the last completed one. The describe
+// response of this API version does not include it and the history cannot be read backwards, so it
+// is found by scanning the history page by page, keeping only the last task
+type pendingWorkflowTaskRow struct {
+	State         string
+	Attempt       int32
+	ScheduledTime *time.Time
+	StartedTime   *time.Time
+}
+
+// printPendingDetails prints the pending activities, child workflows and workflow task of the
+// described workflow as tables with --pending-tables
+func printPendingDetails(c *cli.Context, wid, rid string, resp *workflowservice.DescribeWorkflowExecutionResponse) {
+	if activities := resp.GetPendingActivities(); len(activities) > 0 {
+		items := make([]interface{}, len(activities))
+		for i, a := range activities {
+			items[i] = newPendingActivityRow(a)
+		}
+		printPendingTable(c, "Pending Activities:", items, []string{
+			"ActivityId", "ActivityType", "State", "Attempt", "LastHeartbeatTime", "HeartbeatDetails", "LastFailure",
+		})
+	}
+
+	if children := resp.GetPendingChildren(); len(children) > 0 {
+		items := make([]interface{}, len(children))
+		for i, ch := range children {
+			items[i] = pendingChildRow{
+				WorkflowId:        ch.GetWorkflowId(),
+				RunId:             ch.GetRunId(),
+				WorkflowType:      ch.GetWorkflowTypeName(),
+				InitiatedId:       ch.GetInitiatedId(),
+				ParentClosePolicy: ch.GetParentClosePolicy().String(),
+			}
+		}
+		printPendingTable(c, "Pending Children:", items, []string{
+			"WorkflowId", "RunId", "WorkflowType", "InitiatedId", "ParentClosePolicy",
+		})
+	}
+
+	if resp.GetWorkflowExecutionInfo().GetStatus() == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		var task *pendingWorkflowTaskRow
+		scanHistoryEvents(c, wid, rid, func(e *historypb.HistoryEvent) {
+			task = updatePendingWorkflowTask(task, e)
+		})
+		if task != nil {
+			printPendingTable(c, "Pending Workflow Task:", []interface{}{*task}, []string{
+				"State", "Attempt", "ScheduledTime", "StartedTime",
+			})
+		}
+	}
+}
+
+func printPendingTable(c *cli.Context, title string, items []interface{}, fields []string) {
+	fmt.Println()
+	fmt.Println(title)
+	opts := &output.PrintOptions{
+		Fields:      fields,
+		IgnoreFlags: true,
+		NoPager:     true,
+	}
+	output.PrintItems(c, items, opts)
+}
+
+func newPendingActivityRow(a *workflowpb.PendingActivityInfo) pendingActivityRow {
+	attempt := fmt.Sprint(a.GetAttempt())
+	if a.GetMaximumAttempts() > 0 {
+		attempt = fmt.Sprintf("%d/%d", a.GetAttempt(), a.GetMaximumAttempts())
+	}
+
+	row := pendingActivityRow{
+		ActivityId:         a.GetActivityId(),
+		ActivityType:       a.GetActivityType().GetName(),
+		State:              a.GetState().String(),
+		Attempt:            attempt,
+		ScheduledTime:      a.GetScheduledTime(),
+		LastStartedTime:    a.GetLastStartedTime(),
+		LastHeartbeatTime:  a.GetLastHeartbeatTime(),
+		LastFailure:        a.GetLastFailure().GetMessage(),
+		LastWorkerIdentity: a.GetLastWorkerIdentity(),
+	}
+	if a.GetHeartbeatDetails() != nil {
+		row.HeartbeatDetails = strings.Join(dataconverter.GetCurrent().ToStrings(a.GetHeartbeatDetails()), ", ")
+	}
+	return row
+}
+
+// findPendingWorkflowTask returns the workflow task that is scheduled or started but not finished, if any
+func findPendingWorkflowTask(events []*historypb.HistoryEvent) *pendingWorkflowTaskRow {
+	var task *pendingWorkflowTaskRow
+	for _, e := range events {
+		task = updatePendingWorkflowTask(task, e)
+	}
+	return task
+}
+
+// updatePendingWorkflowTask returns the pending workflow task after the event
+func updatePendingWorkflowTask(task *pendingWorkflowTaskRow, e *historypb.HistoryEvent) *pendingWorkflowTaskRow {
+	switch e.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED:
+		return &pendingWorkflowTaskRow{
+			State:         "Scheduled",
+			Attempt:       e.GetWorkflowTaskScheduledEventAttributes().GetAttempt(),
+			ScheduledTime: e.GetEventTime(),
+		}
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED:
+		if task != nil {
+			task.State = "Started"
+			task.StartedTime = e.GetEventTime()
+		}
+	case enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED,
+		enumspb.EVENT_TYPE_WORKFLOW_TASK_FAILED,
+		enumspb.EVENT_TYPE_WORKFLOW_TASK_TIMED_OUT:
+		return nil
+	}
+	return task
+}
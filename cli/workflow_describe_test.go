@@ -0,0 +1,107 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	historypb "go.temporal.io/api/history/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/server/common/payloads"
+)
+
+type workflowDescribeSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestWorkflowDescribeSuite(t *testing.T) {
+	suite.Run(t, new(workflowDescribeSuite))
+}
+
+func (s *workflowDescribeSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *workflowDescribeSuite) TestNewPendingActivityRow() {
+	heartbeat := time.Date(2021, 6, 7, 17, 16, 34, 0, time.UTC)
+	details, err := payloads.Encode(map[string]int{"progress": 42})
+	s.NoError(err)
+
+	row := newPendingActivityRow(&workflowpb.PendingActivityInfo{
+		ActivityId:        "5",
+		ActivityType:      &commonpb.ActivityType{Name: "charge"},
+		State:             enumspb.PENDING_ACTIVITY_STATE_STARTED,
+		Attempt:           3,
+		MaximumAttempts:   5,
+		LastHeartbeatTime: &heartbeat,
+		HeartbeatDetails:  details,
+		LastFailure:       &failurepb.Failure{Message: "card declined"},
+	})
+	s.Equal("charge", row.ActivityType)
+	s.Equal("Started", row.State)
+	s.Equal("3/5", row.Attempt)
+	s.Equal(&heartbeat, row.LastHeartbeatTime)
+	s.Equal(`{"progress":42}`, row.HeartbeatDetails)
+	s.Equal("card declined", row.LastFailure)
+
+	row = newPendingActivityRow(&workflowpb.PendingActivityInfo{Attempt: 2})
+	s.Equal("2", row.Attempt)
+	s.Empty(row.HeartbeatDetails)
+}
+
+func (s *workflowDescribeSuite) TestFindPendingWorkflowTask() {
+	scheduled := time.Date(2021, 6, 7, 17, 16, 34, 0, time.UTC)
+	started := scheduled.Add(time.Second)
+	events := []*historypb.HistoryEvent{
+		{EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED},
+		{EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED},
+		{EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED},
+		{EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_COMPLETED},
+	}
+	s.Nil(findPendingWorkflowTask(events))
+
+	events = append(events,
+		&historypb.HistoryEvent{
+			EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_SCHEDULED,
+			EventTime: &scheduled,
+			Attributes: &historypb.HistoryEvent_WorkflowTaskScheduledEventAttributes{
+				WorkflowTaskScheduledEventAttributes: &historypb.WorkflowTaskScheduledEventAttributes{Attempt: 2},
+			},
+		},
+		&historypb.HistoryEvent{EventType: enumspb.EVENT_TYPE_WORKFLOW_TASK_STARTED, EventTime: &started},
+	)
+	s.Equal(&pendingWorkflowTaskRow{
+		State:         "Started",
+		Attempt:       2,
+		ScheduledTime: &scheduled,
+		StartedTime:   &started,
+	}, findPendingWorkflowTask(events))
+}
@@ -0,0 +1,283 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/server/common/rpc"
+
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+const defaultObserveInterval = time.Second
+
+// untilComparison is a condition of --until, a field of the describe response compared with a value
+type untilComparison struct {
+	field    string
+	operator string
+	value    string
+}
+
+// untilCondition is the --until expression, comparisons joined by "and" within each group and
+// the groups joined by "or"
+type untilCondition [][]untilComparison
+
+var untilOperators = []string{"!=", ">=", "<=", "=", ">", "<"}
+
+// parseUntilCondition parses the --until expression, ex.
+// "WorkflowExecutionInfo.Status = Completed or PendingActivities > 0". The value of a list field is its length
+func parseUntilCondition(expr string) (untilCondition, error) {
+	var cond untilCondition
+	for _, group := range splitKeyword(expr, "or") {
+		var comparisons []untilComparison
+		for _, part := range splitKeyword(group, "and") {
+			cmp, err := parseUntilComparison(part)
+			if err != nil {
+				return nil, err
+			}
+			comparisons = append(comparisons, cmp)
+		}
+		cond = append(cond, comparisons)
+	}
+	return cond, nil
+}
+
+func parseUntilComparison(s string) (untilComparison, error) {
+	for _, op := range untilOperators {
+		if i := strings.Index(s, op); i > 0 {
+			cmp := untilComparison{
+				field:    strings.TrimSpace(s[:i]),
+				operator: op,
+				value:    strings.Trim(strings.TrimSpace(s[i+len(op):]), `'"`),
+			}
+			if cmp.field == "" || cmp.value == "" {
+				break
+			}
+			return cmp, nil
+		}
+	}
+	return untilComparison{}, fmt.Errorf("invalid condition %q, expected <field> <%s> <value>", strings.TrimSpace(s), strings.Join(untilOperators, "|"))
+}
+
+// splitKeyword splits the expression by the keyword surrounded with spaces, case insensitive
+func splitKeyword(expr, keyword string) []string {
+	var parts []string
+	lower := strings.ToLower(expr)
+	sep := " " + keyword + " "
+	for {
+		i := strings.Index(lower, sep)
+		if i < 0 {
+			return append(parts, expr)
+		}
+		parts = append(parts, expr[:i])
+		expr, lower = expr[i+len(sep):], lower[i+len(sep):]
+	}
+}
+
+// eval reports whether the describe response meets the condition
+func (cond untilCondition) eval(c *cli.Context, resp *workflowservice.DescribeWorkflowExecutionResponse) (bool, error) {
+	for _, group := range cond {
+		met := true
+		for _, cmp := range group {
+			ok, err := cmp.eval(c, resp)
+			if err != nil {
+				return false, err
+			}
+			if !ok {
+				met = false
+				break
+			}
+		}
+		if met {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (cmp untilComparison) eval(c *cli.Context, resp *workflowservice.DescribeWorkflowExecutionResponse) (bool, error) {
+	v, ok := output.ResolveField(c, resp, cmp.field)
+	if !ok {
+		return false, fmt.Errorf("unknown field %s", cmp.field)
+	}
+
+	var actual string
+	if val := reflect.ValueOf(v); v != nil && (val.Kind() == reflect.Slice || val.Kind() == reflect.Map) {
+		actual = strconv.Itoa(val.Len())
+	} else if v != nil {
+		actual = fmt.Sprint(v)
+	}
+
+	a, aErr := strconv.ParseFloat(actual, 64)
+	b, bErr := strconv.ParseFloat(cmp.value, 64)
+	var order int
+	if aErr == nil && bErr == nil {
+		switch {
+		case a < b:
+			order = -1
+		case a > b:
+			order = 1
+		}
+	} else {
+		order = strings.Compare(strings.ToLower(actual), strings.ToLower(cmp.value))
+	}
+
+	switch cmp.operator {
+	case "=":
+		return order == 0, nil
+	case "!=":
+		return order != 0, nil
+	case ">":
+		return order > 0, nil
+	case ">=":
+		return order >= 0, nil
+	case "<":
+		return order < 0, nil
+	default:
+		return order <= 0, nil
+	}
+}
+
+// observeState is what is printed when it changes while observing
+type observeState struct {
+	Status            enumspb.WorkflowExecutionStatus
+	PendingActivities int
+	PendingChildren   int
+}
+
+// ObserveWorkflowUntil polls the workflow until it has one of the --status statuses or meets the
+// --until condition, printing the changes of its state. Exits with ExitCodeWorkflowFailed if the
+// workflow failed, timed out, was canceled or terminated
+func ObserveWorkflowUntil(c *cli.Context) {
+	wid, rid := getWorkflowParams(c)
+	namespace := getRequiredGlobalOption(c, FlagNamespace)
+	client := cFactory.FrontendClient(c)
+
+	statuses := make(map[enumspb.WorkflowExecutionStatus]bool)
+	if c.IsSet(FlagWorkflowStatus) {
+		for _, s := range strings.Split(c.String(FlagWorkflowStatus), ",") {
+			status, err := stringToEnum(strings.TrimSpace(s), enumspb.WorkflowExecutionStatus_value)
+			if err != nil {
+				process.UsageErrorAndExit(fmt.Sprintf("Invalid %s: %v", FlagWorkflowStatus, err))
+			}
+			statuses[enumspb.WorkflowExecutionStatus(status)] = true
+		}
+	}
+	var until untilCondition
+	if c.IsSet(FlagUntil) {
+		var err error
+		if until, err = parseUntilCondition(c.String(FlagUntil)); err != nil {
+			process.UsageErrorAndExit(fmt.Sprintf("Invalid %s: %v", FlagUntil, err))
+		}
+	}
+	interval := defaultObserveInterval
+	if c.IsSet(FlagInterval) {
+		interval = c.Duration(FlagInterval)
+	}
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if c.IsSet(FlagTimeout) {
+		ctx, cancel = rpc.NewContextWithTimeoutAndCLIHeaders(c.Duration(FlagTimeout))
+	} else {
+		ctx, cancel = newIndefiniteContext(c)
+	}
+	defer cancel()
+
+	var last *observeState
+	for {
+		reqCtx, reqCancel := context.WithTimeout(ctx, defaultContextTimeout)
+		resp, err := client.DescribeWorkflowExecution(reqCtx, &workflowservice.DescribeWorkflowExecutionRequest{
+			Namespace: namespace,
+			Execution: &commonpb.WorkflowExecution{WorkflowId: wid, RunId: rid},
+		})
+		reqCancel()
+		if err != nil {
+			if ctx.Err() != nil {
+				process.TimeoutErrorAndExit("The condition was not met before the timeout.")
+			}
+			ErrorAndExit("Describe workflow execution failed", err)
+		}
+
+		status := resp.GetWorkflowExecutionInfo().GetStatus()
+		state := &observeState{
+			Status:            status,
+			PendingActivities: len(resp.GetPendingActivities()),
+			PendingChildren:   len(resp.GetPendingChildren()),
+		}
+		if last == nil || *last != *state {
+			fmt.Printf("%s  Status: %v, pending activities: %d, pending children: %d\n",
+				time.Now().Format(time.RFC3339), status, state.PendingActivities, state.PendingChildren)
+			last = state
+		}
+
+		met := statuses[status]
+		if !met && until != nil {
+			if met, err = until.eval(c, resp); err != nil {
+				process.UsageErrorAndExit(fmt.Sprintf("Invalid %s: %v", FlagUntil, err))
+			}
+		}
+		closed := status != enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING
+		if met || closed {
+			if !met {
+				fmt.Fprintf(os.Stderr, "The workflow closed with status %v before the condition was met\n", status)
+			}
+			process.Exit(observeExitCode(status, met))
+		}
+
+		select {
+		case <-ctx.Done():
+			process.TimeoutErrorAndExit("The condition was not met before the timeout.")
+		case <-time.After(interval):
+		}
+	}
+}
+
+// observeExitCode returns 0 when the condition is met, ex. --status Failed of a failed workflow,
+// otherwise the exit code of the status the workflow closed with
+func observeExitCode(status enumspb.WorkflowExecutionStatus, met bool) int {
+	if met {
+		return process.ExitCodeOK
+	}
+	switch status {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+		enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT,
+		enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED,
+		enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED:
+		return process.ExitCodeWorkflowFailed
+	}
+	return process.ExitCodeError
+}
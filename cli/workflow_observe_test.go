@@ -0,0 +1,93 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+type workflowObserveSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestWorkflowObserveSuite(t *testing.T) {
+	suite.Run(t, new(workflowObserveSuite))
+}
+
+func (s *workflowObserveSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *workflowObserveSuite) TestUntilCondition() {
+	resp := &workflowservice.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &workflowpb.WorkflowExecutionInfo{
+			Status:        enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING,
+			HistoryLength: 12,
+		},
+		PendingActivities: []*workflowpb.PendingActivityInfo{{ActivityId: "5"}},
+	}
+
+	for expr, expected := range map[string]bool{
+		"WorkflowExecutionInfo.Status = running":                                   true,
+		"WorkflowExecutionInfo.Status = 'Completed'":                               false,
+		"WorkflowExecutionInfo.HistoryLength >= 12":                                true,
+		"WorkflowExecutionInfo.HistoryLength > 9":                                  true,
+		"PendingActivities = 0":                                                    false,
+		"PendingActivities = 0 or WorkflowExecutionInfo.HistoryLength != 3":        true,
+		"PendingActivities > 0 AND WorkflowExecutionInfo.Status = Completed":       false,
+		"PendingActivities.0.ActivityId = 5 and WorkflowExecutionInfo.Status <= z": true,
+	} {
+		cond, err := parseUntilCondition(expr)
+		s.NoError(err, expr)
+		met, err := cond.eval(nil, resp)
+		s.NoError(err, expr)
+		s.Equal(expected, met, expr)
+	}
+
+	_, err := parseUntilCondition("PendingActivities")
+	s.Error(err)
+
+	cond, err := parseUntilCondition("Missing = 1")
+	s.NoError(err)
+	_, err = cond.eval(nil, resp)
+	s.Error(err)
+}
+
+func (s *workflowObserveSuite) TestObserveExitCode() {
+	s.Equal(process.ExitCodeOK, observeExitCode(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, true))
+	s.Equal(process.ExitCodeOK, observeExitCode(enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING, true))
+	s.Equal(process.ExitCodeOK, observeExitCode(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, true), "--status Failed is met")
+	s.Equal(process.ExitCodeWorkflowFailed, observeExitCode(enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, false))
+	s.Equal(process.ExitCodeWorkflowFailed, observeExitCode(enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED, false))
+	s.Equal(process.ExitCodeError, observeExitCode(enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED, false))
+}
@@ -0,0 +1,129 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"fmt"
+	goplugin "plugin"
+
+	"github.com/urfave/cli/v2"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/sdk/worker"
+	"go.temporal.io/sdk/workflow"
+
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// registerWorkflowsSymbol is looked up in the --workflow-plugin
+const registerWorkflowsSymbol = "RegisterWorkflows"
+
+// replayRow is the result of replaying one history
+type replayRow struct {
+	Execution string
+	Result    string
+	Error     string
+}
+
+// ReplayWorkflow replays the histories from files or matching a query with the workflows of the plugin
+func ReplayWorkflow(c *cli.Context) {
+	files := c.StringSlice(FlagHistoryFile)
+	query := c.String(FlagListQuery)
+	if len(files) == 0 && query == "" {
+		process.UsageErrorAndExit(fmt.Sprintf("Option %s or %s is required", FlagHistoryFile, FlagListQuery))
+	}
+
+	replayer := worker.NewWorkflowReplayer()
+	if err := loadWorkflowPlugin(getRequiredOption(c, FlagWorkflowPlugin), replayer); err != nil {
+		ErrorAndExit("Unable to load workflow plugin.", err)
+	}
+
+	var rows []interface{}
+	failed := 0
+	add := func(execution string, err error) {
+		row := replayRow{Execution: execution, Result: "PASS"}
+		if err != nil {
+			row.Result = "FAIL"
+			row.Error = err.Error()
+			failed++
+		}
+		rows = append(rows, row)
+	}
+
+	for _, file := range files {
+		add(file, replayer.ReplayWorkflowHistoryFromJSONFile(nil, file))
+	}
+	if query != "" {
+		namespace := getRequiredGlobalOption(c, FlagNamespace)
+		client := cFactory.FrontendClient(c)
+		var npt []byte
+		for {
+			ctx, cancel := newContextForLongPoll(c)
+			items, nextPageToken, err := listWorkflows(ctx, client, npt, namespace, query)
+			cancel()
+			if err != nil {
+				ErrorAndExit("Unable to list workflows.", err)
+			}
+			for _, item := range items {
+				e := item.(*workflowpb.WorkflowExecutionInfo).GetExecution()
+				ctx, cancel := newContext(c)
+				err := replayer.ReplayWorkflowExecution(ctx, client, nil, namespace, workflow.Execution{ID: e.GetWorkflowId(), RunID: e.GetRunId()})
+				cancel()
+				add(e.GetWorkflowId()+"/"+e.GetRunId(), err)
+			}
+			if len(nextPageToken) == 0 {
+				break
+			}
+			npt = nextPageToken
+		}
+	}
+
+	opts := &output.PrintOptions{
+		Fields:       []string{"Execution", "Result", "Error"},
+		ItemTemplate: replayRow{},
+	}
+	output.PrintItems(c, rows, opts)
+	if failed > 0 {
+		ErrorAndExit("Replay failed.", fmt.Errorf("%d of %d histories are not deterministic with the workflow code", failed, len(rows)))
+	}
+}
+
+// loadWorkflowPlugin registers the workflows of the Go plugin with the registry. The plugin must be
+// built with the same Go and SDK versions as tctl
+func loadWorkflowPlugin(path string, registry worker.WorkflowRegistry) error {
+	p, err := goplugin.Open(path)
+	if err != nil {
+		return err
+	}
+	sym, err := p.Lookup(registerWorkflowsSymbol)
+	if err != nil {
+		return err
+	}
+	register, ok := sym.(func(worker.WorkflowRegistry))
+	if !ok {
+		return fmt.Errorf("%s of %s is %T, expected func(worker.WorkflowRegistry)", registerWorkflowsSymbol, path, sym)
+	}
+	register(registry)
+	return nil
+}
@@ -0,0 +1,117 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"context"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+	"go.temporal.io/server/common/payloads"
+	"go.temporal.io/server/common/rpc"
+
+	"github.com/temporalio/tctl/pkg/output"
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// resultRow is the outcome of workflow result
+type resultRow struct {
+	WorkflowId string
+	RunId      string
+	Status     string
+	Result     string
+	Failure    string
+}
+
+// WorkflowResult waits for the workflow to close, following its continue-as-new runs, and prints the
+// result. Exits with ExitCodeWorkflowFailed unless the workflow completed, with ExitCodeTimeout when it
+// did not close before --timeout
+func WorkflowResult(c *cli.Context) {
+	wid, rid := getWorkflowParams(c)
+	sdkClient := getSDKClient(c)
+
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if c.IsSet(FlagTimeout) {
+		ctx, cancel = rpc.NewContextWithTimeoutAndCLIHeaders(c.Duration(FlagTimeout))
+	} else {
+		ctx, cancel = newIndefiniteContext(c)
+	}
+	defer cancel()
+
+	for {
+		iter := sdkClient.GetWorkflowHistory(ctx, wid, rid, true, enumspb.HISTORY_EVENT_FILTER_TYPE_CLOSE_EVENT)
+		if !iter.HasNext() {
+			ErrorAndExit("Unable to get workflow result.", nil)
+		}
+		event, err := iter.Next()
+		if err != nil {
+			if c.IsSet(FlagTimeout) && ctx.Err() == context.DeadlineExceeded {
+				process.TimeoutErrorAndExit("The workflow did not close before the timeout.")
+			}
+			ErrorAndExit("Unable to get workflow result.", err)
+		}
+		if event.GetEventType() == enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW {
+			rid = event.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId()
+			continue
+		}
+
+		row := newResultRow(wid, rid, event)
+		opts := &output.PrintOptions{
+			Fields:       []string{"WorkflowId", "RunId", "Status", "Result", "Failure"},
+			ItemTemplate: resultRow{},
+			Output:       output.Card,
+		}
+		output.PrintItems(c, []interface{}{row}, opts)
+		if event.GetEventType() != enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED {
+			process.Exit(process.ExitCodeWorkflowFailed)
+		}
+		return
+	}
+}
+
+func newResultRow(wid, rid string, event *historypb.HistoryEvent) resultRow {
+	row := resultRow{WorkflowId: wid, RunId: rid}
+	switch event.GetEventType() {
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_COMPLETED:
+		row.Status = "Completed"
+		row.Result = payloads.ToString(event.GetWorkflowExecutionCompletedEventAttributes().GetResult())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_FAILED:
+		row.Status = "Failed"
+		row.Failure = convertFailure(event.GetWorkflowExecutionFailedEventAttributes().GetFailure()).String()
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TIMED_OUT:
+		row.Status = "TimedOut"
+		row.Failure = "Retry state: " + event.GetWorkflowExecutionTimedOutEventAttributes().GetRetryState().String()
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CANCELED:
+		row.Status = "Canceled"
+		row.Failure = payloads.ToString(event.GetWorkflowExecutionCanceledEventAttributes().GetDetails())
+	case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_TERMINATED:
+		row.Status = "Terminated"
+		row.Failure = event.GetWorkflowExecutionTerminatedEventAttributes().GetReason()
+	default:
+		row.Status = event.GetEventType().String()
+	}
+	return row
+}
@@ -0,0 +1,89 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/color"
+)
+
+const sdkPackagePrefix = "go.temporal.io/sdk/"
+
+// stackTraceColors highlights the parts of a workflow stack trace
+type stackTraceColors struct {
+	header   func(string) string // coroutine name and state
+	function func(string) string // a call of the workflow code
+	sdk      func(string) string // a call inside the SDK
+	file     func(string) string
+}
+
+func newStackTraceColors(c *cli.Context) stackTraceColors {
+	return stackTraceColors{
+		header:   func(s string) string { return color.Colorize(c, color.RoleHeader, "%s", s) },
+		function: func(s string) string { return color.Colorize(c, color.RoleKey, "%s", s) },
+		sdk:      func(s string) string { return s },
+		file:     func(s string) string { return color.Green(c, "%s", s) },
+	}
+}
+
+// renderStackTrace highlights the __stack_trace query result of the Go SDK, which lists each
+// coroutine as a "coroutine <name> [<state>]:" line followed by its calls and their files.
+// The coroutines are separated by a rule
+func renderStackTrace(trace string, colors stackTraceColors) string {
+	coroutines := strings.Split(strings.TrimSpace(trace), "\n\n")
+
+	var sb strings.Builder
+	for i, coroutine := range coroutines {
+		if i > 0 {
+			sb.WriteString("\n" + strings.Repeat("─", 40) + "\n")
+		}
+		inSDK := false
+		for j, line := range strings.Split(coroutine, "\n") {
+			if j > 0 {
+				sb.WriteString("\n")
+			}
+			switch {
+			case strings.HasPrefix(line, "coroutine "):
+				sb.WriteString(colors.header(line))
+			case strings.HasPrefix(line, "\t"):
+				if inSDK {
+					sb.WriteString(colors.sdk(line))
+				} else {
+					sb.WriteString(colors.file(line))
+				}
+			default:
+				inSDK = strings.HasPrefix(line, sdkPackagePrefix)
+				if inSDK {
+					sb.WriteString(colors.sdk(line))
+				} else {
+					sb.WriteString(colors.function(line))
+				}
+			}
+		}
+	}
+	return sb.String()
+}
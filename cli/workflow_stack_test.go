@@ -0,0 +1,71 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type workflowStackSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestWorkflowStackSuite(t *testing.T) {
+	suite.Run(t, new(workflowStackSuite))
+}
+
+func (s *workflowStackSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *workflowStackSuite) TestRenderStackTrace() {
+	trace := "coroutine root [blocked on chan-1.Receive]:\n" +
+		"go.temporal.io/sdk/internal.(*channelImpl).Receive(0xc000, 0x1)\n" +
+		"\t/go/sdk/internal/internal_workflow.go:655\n" +
+		"main.Workflow(0x1)\n" +
+		"\t/app/workflow.go:42 +0x1a\n" +
+		"\n" +
+		"coroutine 2 [blocked on selector-1.Select]:\n" +
+		"main.Workflow.func1(0x1)\n" +
+		"\t/app/workflow.go:30"
+
+	tag := func(name string) func(string) string {
+		return func(line string) string { return name + "|" + line }
+	}
+	colors := stackTraceColors{header: tag("h"), function: tag("fn"), sdk: tag("sdk"), file: tag("file")}
+
+	s.Equal("h|coroutine root [blocked on chan-1.Receive]:\n"+
+		"sdk|go.temporal.io/sdk/internal.(*channelImpl).Receive(0xc000, 0x1)\n"+
+		"sdk|\t/go/sdk/internal/internal_workflow.go:655\n"+
+		"fn|main.Workflow(0x1)\n"+
+		"file|\t/app/workflow.go:42 +0x1a\n"+
+		"────────────────────────────────────────\n"+
+		"h|coroutine 2 [blocked on selector-1.Select]:\n"+
+		"fn|main.Workflow.func1(0x1)\n"+
+		"file|\t/app/workflow.go:30", renderStackTrace(trace, colors))
+}
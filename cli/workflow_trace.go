@@ -0,0 +1,137 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"time"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+
+	"github.com/temporalio/tctl/pkg/output"
+)
+
+// traceRow is one line of workflow trace, Workflow is indented by the depth in the tree
+type traceRow struct {
+	Workflow   string
+	WorkflowId string
+	RunId      string
+	Status     string
+	Duration   time.Duration
+}
+
+// traceNode is a workflow run with its child workflows, ordered by their start
+type traceNode struct {
+	row      traceRow
+	children []*traceNode
+}
+
+// TraceWorkflow prints the tree of the child workflows and continue-as-new runs of a workflow
+func TraceWorkflow(c *cli.Context) {
+	wid, rid := getWorkflowParams(c)
+	depth := c.Int(FlagDepth)
+	if depth == 0 {
+		depth = -1
+	}
+
+	nodes := traceExecution(c, wid, rid, depth, time.Now())
+	opts := &output.PrintOptions{
+		Fields:       []string{"Workflow", "Status", "Duration"},
+		FieldsLong:   []string{"WorkflowId", "RunId"},
+		ItemTemplate: traceRow{},
+	}
+	output.PrintItems(c, renderTrace(nodes), opts)
+}
+
+// traceExecution returns the run and the runs it continued as, with their children up to the depth,
+// any depth if it is negative
+func traceExecution(c *cli.Context, wid, rid string, depth int, now time.Time) []*traceNode {
+	var nodes []*traceNode
+	for {
+		node, children, next := newTraceNode(wid, rid, getHistoryEvents(c, wid, rid), now)
+		if depth != 0 {
+			for _, child := range children {
+				node.children = append(node.children, traceExecution(c, child[0], child[1], depth-1, now)...)
+			}
+		}
+		nodes = append(nodes, node)
+		if next == "" {
+			return nodes
+		}
+		rid = next
+	}
+}
+
+// newTraceNode builds the node of a run from its history, along with the ids of the child workflows
+// and the run id it continued as, if any
+func newTraceNode(wid, rid string, events []*historypb.HistoryEvent, now time.Time) (node *traceNode, children [][2]string, next string) {
+	summary := newHistorySummary()
+	for _, e := range events {
+		summary.add(e)
+		switch e.GetEventType() {
+		case enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED:
+			execution := e.GetChildWorkflowExecutionStartedEventAttributes().GetWorkflowExecution()
+			children = append(children, [2]string{execution.GetWorkflowId(), execution.GetRunId()})
+		case enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW:
+			next = e.GetWorkflowExecutionContinuedAsNewEventAttributes().GetNewExecutionRunId()
+		}
+	}
+
+	node = &traceNode{row: traceRow{WorkflowId: wid, RunId: rid}}
+	if rows := summary.items(now); len(rows) > 0 && summary.rows[0].Kind == "Workflow" {
+		workflow := rows[0].(summaryRow)
+		node.row.Workflow = workflow.Name
+		node.row.Status = workflow.Status
+		node.row.Duration = workflow.Duration
+	}
+	if rid == "" && len(events) > 0 {
+		node.row.RunId = events[0].GetWorkflowExecutionStartedEventAttributes().GetOriginalExecutionRunId()
+	}
+	return node, children, next
+}
+
+// renderTrace flattens the tree into rows, drawing the branches in front of the workflow types
+func renderTrace(nodes []*traceNode) []interface{} {
+	var rows []interface{}
+	var walk func(nodes []*traceNode, indent string, root bool)
+	walk = func(nodes []*traceNode, indent string, root bool) {
+		for i, node := range nodes {
+			last := i == len(nodes)-1
+			row := node.row
+			branch, childIndent := "", indent
+			if !root {
+				branch, childIndent = "├─ ", indent+"│  "
+				if last {
+					branch, childIndent = "└─ ", indent+"   "
+				}
+			}
+			row.Workflow = indent + branch + row.Workflow
+			rows = append(rows, row)
+			walk(node.children, childIndent, false)
+		}
+	}
+	walk(nodes, "", true)
+	return rows
+}
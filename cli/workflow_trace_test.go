@@ -0,0 +1,80 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package cli
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	historypb "go.temporal.io/api/history/v1"
+)
+
+type workflowTraceSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestWorkflowTraceSuite(t *testing.T) {
+	suite.Run(t, new(workflowTraceSuite))
+}
+
+func (s *workflowTraceSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *workflowTraceSuite) TestNewTraceNode() {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	events := []*historypb.HistoryEvent{
+		{EventId: 1, EventTime: &start, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_STARTED, Attributes: &historypb.HistoryEvent_WorkflowExecutionStartedEventAttributes{
+			WorkflowExecutionStartedEventAttributes: &historypb.WorkflowExecutionStartedEventAttributes{WorkflowType: &commonpb.WorkflowType{Name: "Order"}}}},
+		{EventId: 5, EventTime: &start, EventType: enumspb.EVENT_TYPE_CHILD_WORKFLOW_EXECUTION_STARTED, Attributes: &historypb.HistoryEvent_ChildWorkflowExecutionStartedEventAttributes{
+			ChildWorkflowExecutionStartedEventAttributes: &historypb.ChildWorkflowExecutionStartedEventAttributes{WorkflowExecution: &commonpb.WorkflowExecution{WorkflowId: "child", RunId: "c1"}}}},
+		{EventId: 9, EventTime: &end, EventType: enumspb.EVENT_TYPE_WORKFLOW_EXECUTION_CONTINUED_AS_NEW, Attributes: &historypb.HistoryEvent_WorkflowExecutionContinuedAsNewEventAttributes{
+			WorkflowExecutionContinuedAsNewEventAttributes: &historypb.WorkflowExecutionContinuedAsNewEventAttributes{NewExecutionRunId: "r2"}}},
+	}
+
+	node, children, next := newTraceNode("wid", "r1", events, end)
+	s.Equal(traceRow{Workflow: "Order", WorkflowId: "wid", RunId: "r1", Status: "ContinuedAsNew", Duration: time.Minute}, node.row)
+	s.Equal([][2]string{{"child", "c1"}}, children)
+	s.Equal("r2", next)
+}
+
+func (s *workflowTraceSuite) TestRenderTrace() {
+	leaf := func(name string) *traceNode { return &traceNode{row: traceRow{Workflow: name}} }
+	root := leaf("Order")
+	payment := leaf("Payment")
+	payment.children = []*traceNode{leaf("Charge")}
+	root.children = []*traceNode{payment, leaf("Shipping")}
+
+	var names []string
+	for _, row := range renderTrace([]*traceNode{root, leaf("Order")}) {
+		names = append(names, row.(traceRow).Workflow)
+	}
+	s.Equal([]string{"Order", "├─ Payment", "│  └─ Charge", "└─ Shipping", "Order"}, names)
+}
@@ -25,8 +25,12 @@
 package color
 
 import (
+	"os"
+
 	"github.com/fatih/color"
 	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/pager"
 )
 
 const (
@@ -68,17 +72,24 @@ func Red(c *cli.Context, format string, a ...interface{}) string {
 }
 
 func checkColor(c *cli.Context) {
-	colorFlag := c.String(FlagColor)
+	color.NoColor = !Enabled(c)
+}
 
-	colorVal := ColorOption(colorFlag)
-	switch colorVal {
+// Enabled reports whether the output is colored. With --color=auto colors are used when printing to a terminal,
+// unless $NO_COLOR is set, the terminal is dumb or the pager doesn't pass the color codes through
+func Enabled(c *cli.Context) bool {
+	switch ColorOption(c.String(FlagColor)) {
 	case Always:
-		color.NoColor = false
+		return true
 	case Never:
-		color.NoColor = true
+		return false
+	}
 
-	default: // auto
-		// handled by color pkg
-		// tty - color. non-tty - false
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+	if os.Getenv("TERM") == "dumb" {
+		return false
 	}
+	return pager.IsTerminal(os.Stdout) && pager.SupportsColor(c)
 }
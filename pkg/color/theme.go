@@ -0,0 +1,123 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package color
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	FlagColorTheme = "color-theme"
+)
+
+// Role is the part of the output a theme colors
+type Role string
+
+const (
+	RoleHeader     Role = "header"
+	RoleKey        Role = "key" // JSON object keys
+	RoleRunning    Role = "running"
+	RoleCompleted  Role = "completed"
+	RoleFailed     Role = "failed"
+	RoleTerminated Role = "terminated"
+	RoleOther      Role = "other" // canceled, continued as new and other statuses
+)
+
+// Theme maps the roles to their color attributes
+type Theme map[Role][]color.Attribute
+
+const (
+	DefaultTheme = "default"
+)
+
+var Themes = map[string]Theme{
+	DefaultTheme: {
+		RoleHeader:     {color.FgHiMagenta},
+		RoleKey:        {color.FgBlue, color.Bold},
+		RoleRunning:    {color.FgGreen},
+		RoleCompleted:  {color.FgCyan},
+		RoleFailed:     {color.FgRed},
+		RoleTerminated: {color.FgYellow},
+		RoleOther:      {color.FgMagenta},
+	},
+	"light": {
+		RoleHeader:     {color.FgMagenta, color.Bold},
+		RoleKey:        {color.FgBlue},
+		RoleRunning:    {color.FgGreen, color.Bold},
+		RoleCompleted:  {color.FgBlue},
+		RoleFailed:     {color.FgRed, color.Bold},
+		RoleTerminated: {color.FgYellow, color.Bold},
+		RoleOther:      {color.FgMagenta},
+	},
+	"mono": {
+		RoleHeader:     {color.Bold},
+		RoleKey:        {color.Bold},
+		RoleRunning:    {color.Bold},
+		RoleCompleted:  {},
+		RoleFailed:     {color.Bold, color.Underline},
+		RoleTerminated: {color.Underline},
+		RoleOther:      {},
+	},
+}
+
+// ThemeNames lists the available themes for the usage of --color-theme
+func ThemeNames() string {
+	var names []string
+	for name := range Themes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// Attributes returns the color attributes of the role in the --color-theme theme
+func Attributes(c *cli.Context, role Role) []color.Attribute {
+	theme, ok := Themes[c.String(FlagColorTheme)]
+	if !ok {
+		theme = Themes[DefaultTheme]
+	}
+	return theme[role]
+}
+
+// New returns the color of the role, disabled when colors are off
+func New(c *cli.Context, role Role) *color.Color {
+	col := color.New(Attributes(c, role)...)
+	if !Enabled(c) {
+		col.DisableColor()
+	} else {
+		col.EnableColor()
+	}
+	return col
+}
+
+// Colorize formats the text with the color of the role
+func Colorize(c *cli.Context, role Role, format string, a ...interface{}) string {
+	return New(c, role).Sprint(fmt.Sprintf(format, a...))
+}
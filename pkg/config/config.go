@@ -27,6 +27,8 @@
 package config
 
 import (
+	"errors"
+
 	"gopkg.in/yaml.v3"
 )
 
@@ -65,6 +67,19 @@ func Set(key string, value string) error {
 	return config.SetScalarValue(key, value)
 }
 
+func Delete(key string) error {
+	config, err := readConfig()
+	if err != nil {
+		return err
+	}
+
+	if !isSequenceKey(key) {
+		return errors.New("only entries of sequence properties can be deleted, ex. alias.mycommand")
+	}
+
+	return config.DeleteSequenceValue(key)
+}
+
 func GetSequence(key string) (map[string]string, error) {
 	config, err := readConfig()
 	if err != nil {
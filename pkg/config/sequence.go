@@ -103,6 +103,34 @@ func (cfg *Config) SetSequenceValue(key string, value string) error {
 	return writeConfig(cfg)
 }
 
+// DeleteSequenceValue removes an entry of sequence property
+// key property must follow format <suquenceName>.<entryKeyValue>, ex "mySequence.myKey1"
+func (cfg *Config) DeleteSequenceValue(key string) error {
+	seqKey, entryKey, err := splitKey(key)
+	if err != nil {
+		return err
+	}
+
+	seqRoot, err := cfg.getScalarNode(seqKey)
+	if err != nil {
+		return err
+	}
+
+	entry, err := cfg.getSequenceEntryNode(seqKey, entryKey)
+	if err != nil {
+		return err
+	}
+
+	for i, e := range seqRoot.Content {
+		if e == entry {
+			seqRoot.Content = append(seqRoot.Content[:i], seqRoot.Content[i+1:]...)
+			break
+		}
+	}
+
+	return writeConfig(cfg)
+}
+
 func setSequenceRoot(cfg *Config, seqKey string) error {
 	_, err := cfg.getScalarNode(seqKey)
 	if err != nil {
@@ -34,6 +34,13 @@ import (
 	"github.com/temporalio/tctl/pkg/pager"
 )
 
+// FlagQuiet is accepted both globally and by the printing commands, ex. tctl workflow list -q
+var FlagQuiet = &cli.BoolFlag{
+	Name:    output.FlagQuiet,
+	Aliases: []string{"q"},
+	Usage:   "print only the primary id of the items, ex. the workflow id, one per line without header and pager. --" + output.FlagIDsOnly + " prints the run id as well",
+}
+
 var FlagsForPagination = []cli.Flag{
 	&cli.IntFlag{
 		Name:  output.FlagLimit,
@@ -41,14 +48,76 @@ var FlagsForPagination = []cli.Flag{
 	},
 	&cli.StringFlag{
 		Name:    pager.FlagPager,
-		Usage:   "pager to use: less, more, favoritePager..",
-		EnvVars: []string{"PAGER"},
+		Usage:   "pager command to use, ex. 'less -RS', bat, cat or builtin. Defaults to the pager config, then $PAGER",
+		EnvVars: []string{"TCTL_PAGER"},
 	},
 	&cli.BoolFlag{
 		Name:    pager.FlagNoPager,
 		Aliases: []string{"P"},
 		Usage:   "disable interactive pager",
 	},
+	&cli.StringFlag{
+		Name: output.FlagDedup,
+		Usage: "skip items with the same value of the given field as an earlier item, ex. WorkflowId. " +
+			"Keeps every distinct value in memory",
+	},
+	&cli.StringFlag{
+		Name: output.FlagSort,
+		Usage: "sort the items by comma separated fields, each optionally followed by :asc or :desc, ex. " +
+			output.FieldAge + ":desc. Reads all the items before printing",
+	},
+	&cli.StringFlag{
+		Name: output.FlagSortBy,
+		Usage: "sort the items of each printed page by comma separated fields, ex. StartTime. " +
+			"With --" + output.FlagAll + ", sorts all the items like --" + output.FlagSort,
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagDesc,
+		Usage: "reverse the order of --" + output.FlagSortBy,
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagAll,
+		Usage: "sort all the items by --" + output.FlagSortBy + " instead of each printed page. Reads all the items before printing",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagSpool,
+		Usage: "keep the items read by --" + output.FlagSort + " in a temp file instead of memory, for very large results",
+	},
+	&cli.DurationFlag{
+		Name: output.FlagCache,
+		Usage: "reuse the items fetched by the same command within the given time, ex. 30s. Cached per server and namespace. " +
+			"Not combinable with --" + output.FlagPrintCursor + " and --" + output.FlagCursorFile,
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagRefresh,
+		Usage: "fetch the items again and update the --" + output.FlagCache + " entry",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagNoCache,
+		Usage: "neither read nor write the --" + output.FlagCache + " entry",
+	},
+}
+
+// FlagWatch is offered only by the read-only commands, since the action is repeated on every tick
+var FlagWatch = &cli.DurationFlag{
+	Name:  output.FlagWatch,
+	Usage: "re-run the command every given interval and repaint the output, ex. 5s. Stop with Ctrl+C",
+}
+
+// FlagsForCursor are offered only by the commands printing through output.NewPagingIterator
+var FlagsForCursor = []cli.Flag{
+	&cli.BoolFlag{
+		Name:  output.FlagPrintCursor,
+		Usage: "print the cursor of the position after the last printed item to stderr",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagCursorFile,
+		Usage: "write the cursor of the position after the last printed item to a file",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagStartCursor,
+		Usage: "resume printing from a cursor returned by --print-cursor or --cursor-file",
+	},
 }
 
 var FlagsForRendering = []cli.Flag{
@@ -58,20 +127,156 @@ var FlagsForRendering = []cli.Flag{
 		Usage:   output.UsageText,
 		Value:   string(output.Table),
 	},
+	&cli.BoolFlag{
+		Name:  output.FlagNoHeader,
+		Usage: "do not print the header of table, csv, tsv and markdown output",
+	},
 	&cli.StringFlag{
-		Name:  format.FlagTimeFormat,
-		Usage: fmt.Sprintf("format time as: %v, %v, %v.", format.Relative, format.ISO, format.Raw),
+		Name: format.FlagTimeFormat,
+		Usage: fmt.Sprintf("format time in tables and cards as: %v (ex. 3m ago, in 2h), %v, %v, a Go layout (ex. '2006-01-02 15:04:05') "+
+			"or a strftime pattern (ex. '%%Y-%%m-%%d %%H:%%M:%%S'). Defaults to the time-format config. JSON keeps absolute times", format.Relative, format.ISO, format.Raw),
 		Value: string(format.Relative),
 	},
 	&cli.StringFlag{
-		Name:  output.FlagFields,
-		Usage: "customize fields to print. Set to 'long' to automatically print more of main fields",
+		Name:  format.FlagTimeZone,
+		Usage: "time zone to print times in: UTC, Local or an IANA zone, ex. America/New_York. Defaults to the time-zone config or the local zone",
+	},
+	&cli.StringFlag{
+		Name:  format.FlagNow,
+		Usage: "reference time of relative time formatting in RFC3339, ex. 2024-01-01T00:00:00Z. Defaults to the current time",
+	},
+	&cli.StringFlag{
+		Name: output.FlagFields,
+		Usage: "customize fields to print. Set to 'long' to automatically print more of main fields. " +
+			"Supports exclusion (-Field), addition (+Field), presets (@default, @long, @all), globs (Execution.*), " +
+			"labels (Field=Label), time directives (Field%iso) and the virtual " + output.FieldStatus + " field",
 	},
 	&cli.StringFlag{
 		Name:  color.FlagColor,
-		Usage: fmt.Sprintf("when to use color: %v, %v, %v.", color.Auto, color.Always, color.Never),
+		Usage: fmt.Sprintf("when to use color: %v, %v, %v. Auto colors the output of terminals unless NO_COLOR is set", color.Auto, color.Always, color.Never),
 		Value: string(color.Auto),
 	},
+	&cli.StringFlag{
+		Name:  color.FlagColorTheme,
+		Usage: "colors of the statuses, headers and JSON keys: " + color.ThemeNames(),
+		Value: color.DefaultTheme,
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagSSE,
+		Usage: "stream items as Server-Sent Events, one JSON data frame per item",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagCompactPolicies,
+		Usage: "print retry policies in a compact form instead of JSON",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagOutputFile,
+		Usage: fmt.Sprintf("file to write the output to instead of the pager, replaced once the output is complete. Required for %v output", output.Parquet),
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagTrailerChecksum,
+		Usage: "write the SHA-256 and the line count of --output-file to a .sha256 file next to it",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagAnnotate,
+		Usage: "wrap JSON and YAML output into an envelope recording the command, namespace and server address it came from",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagTrimTrailing,
+		Usage: "strip trailing padding from table rows and whitespace around csv cells. Enabled by default when output is not a terminal",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagExpand,
+		Usage: "comma separated array fields to render fully instead of as item counts",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagProfileFields,
+		Usage: "instead of the items, print how often each field is populated over a sample of the results",
+	},
+	&cli.StringSliceFlag{
+		Name:  output.FlagAlso,
+		Usage: fmt.Sprintf("also write the items to a file, as format:path. Supported formats: %v, %v", output.JSON, output.Parquet),
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagASCII,
+		Usage: "use ASCII symbols instead of unicode icons",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagHeadersOnly,
+		Usage: "print only the table header or an empty JSON record, without fetching the items",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagOutputExec,
+		Usage: "pipe the items, one JSON object per line, to the stdin of the given command and print its output",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagOmitEmpty,
+		Usage: "leave out nil and empty sub-objects from cards",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagMapSort,
+		Usage: "order the entries of map fields by key or value, optionally followed by :asc or :desc, ex. value:desc",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagIDsOnly,
+		Usage: "print only the ids of the items, one per line without header and pager, ex. for xargs",
+	},
+	FlagQuiet,
+	&cli.StringFlag{
+		Name:  output.FlagIDFields,
+		Usage: "comma separated fields to print with --" + output.FlagIDsOnly + ". Detected from the items by default, ex. WorkflowId,RunId",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagCodesAsInt,
+		Usage: "print gRPC status codes as numbers instead of names",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagSelect,
+		Usage: "print the values at a jq-like path of each item instead of the items, ex. '.PendingActivities[].LastHeartbeatDetails'",
+	},
+	&cli.IntFlag{
+		Name:    output.FlagMaxFieldLength,
+		Aliases: []string{"maxl"},
+		Usage:   "truncate the table values longer than the given length with an ellipsis",
+	},
+	&cli.StringSliceFlag{
+		Name:  output.FlagColumnWidth,
+		Usage: "truncate the values of a column to the given length, ex. Failure.Message=40. Can be repeated",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagNoTruncate,
+		Usage: "print the table values in full, ignoring the truncation set by the command or other flags",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagLegacyJSON,
+		Usage: "print json, ndjson and --output-exec items in the former shape, with enums as numbers and payloads base64 encoded",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagInteractive,
+		Usage: "browse the items full screen: filter with /, sort with s, open an item with Enter",
+	},
+	&cli.BoolFlag{
+		Name:  output.FlagRawValues,
+		Usage: "print durations as nanoseconds and sizes as bytes instead of ex. 2h13m and 1.4 MiB",
+	},
+	&cli.StringFlag{
+		Name:  output.FlagCardTemplate,
+		Usage: "print each card with the Go template in the given file, ex. a runbook layout. Without it, the fields of a card can be set per type in the config, ex. tctl config set card.WorkflowExecutionInfo 'Execution.WorkflowId=Workflow,Status'",
+	},
 }
 
-var FlagsForPaginationAndRendering = append(FlagsForPagination, FlagsForRendering...)
+var FlagsForPaginationAndRendering = concat(FlagsForPagination, FlagsForRendering)
+
+// FlagsForListing are the flags of the commands paging through the server results, resumable with a cursor
+var FlagsForListing = concat(FlagsForPagination, FlagsForCursor, FlagsForRendering, []cli.Flag{FlagWatch})
+
+// FlagsForViewing are the flags of the read-only commands printing without pagination
+var FlagsForViewing = concat(FlagsForRendering, []cli.Flag{FlagWatch})
+
+func concat(sets ...[]cli.Flag) []cli.Flag {
+	var all []cli.Flag
+	for _, set := range sets {
+		all = append(all, set...)
+	}
+	return all
+}
@@ -26,16 +26,21 @@ package format
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
-	"github.com/dustin/go-humanize"
 	"github.com/urfave/cli/v2"
 
 	"go.temporal.io/server/common/primitives/timestamp"
+
+	"github.com/temporalio/tctl/pkg/config"
+	"github.com/temporalio/tctl/pkg/process"
 )
 
 const (
 	FlagTimeFormat = "time-format"
+	FlagNow        = "now"
+	FlagTimeZone   = "time-zone"
 )
 
 type FormatTimeOption string
@@ -48,17 +53,163 @@ const (
 
 func FormatTime(c *cli.Context, val time.Time) string {
 	formatFlag := c.String(FlagTimeFormat)
+	if !c.IsSet(FlagTimeFormat) {
+		if val := configDefault(FlagTimeFormat); val != "" {
+			formatFlag = val
+		}
+	}
 
-	timeVal := timestamp.TimeValue(&val)
-	format := FormatTimeOption(formatFlag)
+	return FormatTimeAs(c, val, FormatTimeOption(formatFlag))
+}
+
+// FormatTimeAs formats time using the given format option regardless of the --time-format flag.
+// Besides the named options, the format is a Go reference layout, ex. 2006-01-02 15:04:05.000,
+// or a strftime pattern, ex. %Y-%m-%dT%H:%M:%S%z
+func FormatTimeAs(c *cli.Context, val time.Time, format FormatTimeOption) string {
+	timeVal := timestamp.TimeValue(&val).In(Location(c))
 	switch format {
+	case "", Relative:
+		return relativeTime(timeVal, Now(c))
 	case ISO:
 		return timeVal.Format(time.RFC3339)
 	case Raw:
 		return fmt.Sprintf("%v", timeVal)
-	case Relative:
-		return humanize.Time(timeVal)
 	default:
-		return humanize.Time(timeVal)
+		return timeVal.Format(layout(string(format)))
+	}
+}
+
+// strftimeLayouts maps the strftime directives to the Go reference layout
+var strftimeLayouts = map[byte]string{
+	'Y': "2006",
+	'y': "06",
+	'm': "01",
+	'b': "Jan",
+	'h': "Jan",
+	'B': "January",
+	'd': "02",
+	'e': "_2",
+	'j': "002",
+	'a': "Mon",
+	'A': "Monday",
+	'H': "15",
+	'I': "03",
+	'M': "04",
+	'S': "05",
+	'f': "000000",
+	'p': "PM",
+	'z': "-0700",
+	'Z': "MST",
+	'F': "2006-01-02",
+	'T': "15:04:05",
+	'D': "01/02/06",
+	'%': "%",
+}
+
+// layout converts a strftime pattern to a Go layout, Go layouts are returned as is
+func layout(format string) string {
+	if !strings.Contains(format, "%") {
+		return format
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(format); i++ {
+		if format[i] == '%' && i+1 < len(format) {
+			if l, ok := strftimeLayouts[format[i+1]]; ok {
+				b.WriteString(l)
+				i++
+				continue
+			}
+		}
+		b.WriteByte(format[i])
+	}
+	return b.String()
+}
+
+var (
+	// locations caches the loaded time zones by name
+	locations = map[string]*time.Location{}
+	// configDefaults caches the config defaults of the flags, read once
+	configDefaults = map[string]string{}
+)
+
+// configDefault returns the config value set for the flag, ex. tctl config set time-zone UTC
+func configDefault(flag string) string {
+	if val, ok := configDefaults[flag]; ok {
+		return val
+	}
+	val, _ := config.Get(flag)
+	configDefaults[flag] = val
+	return val
+}
+
+// Location returns the time zone to render times in: --time-zone, the time-zone config default or the local one.
+// Accepts UTC, Local or an IANA zone name, ex. America/New_York
+func Location(c *cli.Context) *time.Location {
+	name := c.String(FlagTimeZone)
+	if !c.IsSet(FlagTimeZone) {
+		name = configDefault(FlagTimeZone)
+	}
+	if name == "" {
+		return time.Local
+	}
+
+	if loc, ok := locations[name]; ok {
+		return loc
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		process.ErrorAndExit(fmt.Sprintf("invalid time zone %q", name), err)
+	}
+	locations[name] = loc
+	return loc
+}
+
+// relativeUnits are the units of relative time, from the largest
+var relativeUnits = []struct {
+	suffix string
+	size   time.Duration
+}{
+	{"y", 365 * 24 * time.Hour},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+// relativeTime formats the time compactly relative to now in its largest unit, ex. 3m ago, in 2h
+func relativeTime(t time.Time, now time.Time) string {
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+	if d < time.Second {
+		return "now"
+	}
+
+	var s string
+	for _, u := range relativeUnits {
+		if d >= u.size {
+			s = fmt.Sprintf("%d%s", d/u.size, u.suffix)
+			break
+		}
+	}
+	if future {
+		return "in " + s
+	}
+	return s + " ago"
+}
+
+// Now returns the reference time of relative formatting, set with --now for reproducible output
+func Now(c *cli.Context) time.Time {
+	if !c.IsSet(FlagNow) {
+		return time.Now()
+	}
+
+	now, err := time.Parse(time.RFC3339, c.String(FlagNow))
+	if err != nil {
+		process.ErrorAndExit(fmt.Sprintf("invalid --%s value", FlagNow), err)
 	}
+	return now
 }
@@ -0,0 +1,62 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package format
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type timeSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestTimeSuite(t *testing.T) {
+	suite.Run(t, new(timeSuite))
+}
+
+func (s *timeSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *timeSuite) TestRelativeTime() {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.Equal("now", relativeTime(now, now))
+	s.Equal("45s ago", relativeTime(now.Add(-45*time.Second), now))
+	s.Equal("3m ago", relativeTime(now.Add(-3*time.Minute-20*time.Second), now))
+	s.Equal("in 2h", relativeTime(now.Add(2*time.Hour+59*time.Minute), now))
+	s.Equal("5d ago", relativeTime(now.Add(-5*24*time.Hour), now))
+	s.Equal("in 1y", relativeTime(now.Add(400*24*time.Hour), now))
+}
+
+func (s *timeSuite) TestLayout() {
+	t := time.Date(2024, 3, 5, 14, 7, 9, 123456000, time.UTC)
+	s.Equal("2024-03-05T14:07:09+0000", t.Format(layout("%Y-%m-%dT%H:%M:%S%z")))
+	s.Equal("05/Mar/2024:14:07:09.123456 %", t.Format(layout("%d/%b/%Y:%T.%f %%")))
+	s.Equal("2024-03-05 14:07", t.Format(layout("2006-01-02 15:04")))
+}
@@ -0,0 +1,153 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/color"
+)
+
+// alsoSink is a secondary output written to a file next to the primary output, set with --also=format:path
+type alsoSink struct {
+	output OutputOption
+	path   string
+	file   *outputFile
+	opts   *PrintOptions
+	count  int
+	done   bool // set once the output is closed or failed
+}
+
+func parseAlsoSinks(c *cli.Context) ([]*alsoSink, error) {
+	var sinks []*alsoSink
+	for _, value := range c.StringSlice(FlagAlso) {
+		parts := strings.SplitN(value, ":", 2)
+		if len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("invalid --%s value %q, expected format:path", FlagAlso, value)
+		}
+
+		output := OutputOption(parts[0])
+		if output != JSON && output != Parquet {
+			return nil, fmt.Errorf("unsupported --%s format %q, expected %v or %v", FlagAlso, parts[0], JSON, Parquet)
+		}
+		sinks = append(sinks, &alsoSink{output: output, path: parts[1]})
+	}
+	return sinks, nil
+}
+
+// printAlso fans the items out to the secondary outputs. Failing outputs are reported and skipped from then on
+func (opts *PrintOptions) printAlso(c *cli.Context, items []interface{}) {
+	for _, sink := range opts.also {
+		if sink.done {
+			continue
+		}
+		if err := sink.print(c, items, opts); err != nil {
+			sink.warn(c, err)
+		}
+	}
+}
+
+func (s *alsoSink) print(c *cli.Context, items []interface{}, opts *PrintOptions) error {
+	if s.output == Parquet {
+		if s.opts == nil {
+			s.opts = &PrintOptions{columns: opts.columns}
+		}
+		return writeParquet(c, items, s.opts, s.path)
+	}
+
+	if s.file == nil {
+		file, err := createOutputFile(c, s.path)
+		if err != nil {
+			return err
+		}
+		s.file = file
+
+		prefix := "["
+		if c.Bool(FlagAnnotate) {
+			b, err := marshalItemJSON(c, newAnnotation(c), opts)
+			if err != nil {
+				return err
+			}
+			prefix = fmt.Sprintf(`{"annotation":%s,"data":[`, b)
+		}
+		if _, err := fmt.Fprintln(s.file, prefix); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range items {
+		b, err := marshalItemJSON(c, item, opts)
+		if err != nil {
+			return err
+		}
+		sep := ""
+		if s.count > 0 {
+			sep = ",\n"
+		}
+		if _, err := fmt.Fprintf(s.file, "%s%s", sep, b); err != nil {
+			return err
+		}
+		s.count++
+	}
+	return nil
+}
+
+func (s *alsoSink) close(c *cli.Context) {
+	if s.done {
+		return
+	}
+
+	var err error
+	if s.opts != nil {
+		err = s.opts.close(c)
+	} else if s.file != nil {
+		suffix := "\n]"
+		if c.Bool(FlagAnnotate) {
+			suffix = "\n]}"
+		}
+		if _, err = fmt.Fprintln(s.file, suffix); err == nil {
+			err = s.file.Close()
+		}
+	}
+	if err != nil {
+		s.warn(c, err)
+	}
+	s.done = true
+}
+
+func (s *alsoSink) warn(c *cli.Context, err error) {
+	s.done = true
+	if s.file != nil {
+		s.file.discard()
+	}
+	if s.opts != nil && s.opts.parquet != nil {
+		s.opts.parquet.abort()
+	}
+	fmt.Fprintf(os.Stderr, "%s: unable to write %v output to %s: %v\n", color.Magenta(c, "Warning"), s.output, s.path, err)
+}
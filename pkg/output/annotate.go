@@ -0,0 +1,95 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"os"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+const (
+	flagNamespace = "namespace"
+	flagAddress   = "address"
+	redacted      = "<redacted>"
+)
+
+// secretFlags are the flags whose values are left out of the annotation
+var secretFlags = []string{"password", "security-token", "st"}
+
+// Annotation records where the printed data came from
+type Annotation struct {
+	Command   string `json:"command"`
+	Namespace string `json:"namespace,omitempty"`
+	Address   string `json:"address,omitempty"`
+}
+
+type annotatedOutput struct {
+	Annotation Annotation  `json:"annotation"`
+	Data       interface{} `json:"data"`
+}
+
+// annotate wraps the data into an envelope with the command, namespace and server address when --annotate is set
+func annotate(c *cli.Context, o interface{}) interface{} {
+	if !c.Bool(FlagAnnotate) {
+		return o
+	}
+
+	return annotatedOutput{
+		Annotation: newAnnotation(c),
+		Data:       o,
+	}
+}
+
+func newAnnotation(c *cli.Context) Annotation {
+	return Annotation{
+		Command:   strings.Join(redactArgs(os.Args), " "),
+		Namespace: c.String(flagNamespace),
+		Address:   c.String(flagAddress),
+	}
+}
+
+func redactArgs(args []string) []string {
+	result := make([]string, len(args))
+	copy(result, args)
+
+	for i := 0; i < len(result); i++ {
+		name := strings.TrimLeft(result[i], "-")
+		if name == result[i] {
+			continue
+		}
+
+		if eq := strings.Index(name, "="); eq >= 0 {
+			if containsString(secretFlags, name[:eq]) {
+				result[i] = result[i][:len(result[i])-len(name)+eq+1] + redacted
+			}
+		} else if containsString(secretFlags, name) && i+1 < len(result) {
+			result[i+1] = redacted
+			i++
+		}
+	}
+	return result
+}
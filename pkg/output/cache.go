@@ -0,0 +1,177 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/server/common/collection"
+)
+
+// cacheItems serves the items from the cache of an earlier run of the same command when it is younger than --cache,
+// otherwise it records the items fetched by the iterator. The items are decoded as the type of ItemTemplate
+func cacheItems(c *cli.Context, iter collection.Iterator, opts *PrintOptions) (collection.Iterator, error) {
+	if c.Bool(FlagNoCache) || opts.ItemTemplate == nil {
+		return iter, nil
+	}
+
+	path, err := cachePath(c)
+	if err != nil {
+		return nil, err
+	}
+	typ := reflect.TypeOf(opts.ItemTemplate)
+
+	if !c.Bool(FlagRefresh) {
+		if stat, err := os.Stat(path); err == nil && time.Since(stat.ModTime()) < c.Duration(FlagCache) {
+			file, err := os.Open(path)
+			if err != nil {
+				return nil, err
+			}
+			return &cacheReader{file: file, reader: bufio.NewReader(file), typ: typ}, nil
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	file, err := ioutil.TempFile(filepath.Dir(path), "tmp-")
+	if err != nil {
+		return nil, err
+	}
+	return &cacheWriter{iter: iter, file: file, writer: bufio.NewWriter(file), typ: typ, path: path}, nil
+}
+
+// cachePath locates the cache of the command, under a directory of the server address and namespace
+func cachePath(c *cli.Context) (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	var args []string
+	for i := 1; i < len(os.Args); i++ {
+		name := strings.TrimLeft(os.Args[i], "-")
+		if name == os.Args[i] {
+			args = append(args, os.Args[i])
+			continue
+		}
+		switch strings.SplitN(name, "=", 2)[0] {
+		case FlagCache:
+			if !strings.Contains(name, "=") {
+				i++ // skip the value
+			}
+		case FlagRefresh, FlagNoCache:
+		default:
+			args = append(args, os.Args[i])
+		}
+	}
+
+	server := fmt.Sprintf("%x", sha256.Sum256([]byte(c.String(flagAddress)+"/"+c.String(flagNamespace))))
+	command := fmt.Sprintf("%x", sha256.Sum256([]byte(strings.Join(args, "\x00"))))
+	return filepath.Join(dir, "tctl", server[:16], command), nil
+}
+
+type cacheReader struct {
+	file   *os.File
+	reader *bufio.Reader
+	typ    reflect.Type
+	next   interface{}
+	err    error
+	done   bool
+}
+
+func (r *cacheReader) HasNext() bool {
+	if r.next == nil && r.err == nil && !r.done {
+		r.next, r.err = readItem(r.reader, r.typ)
+		if r.err == io.EOF {
+			r.err = nil
+			r.done = true
+		}
+	}
+	return r.next != nil || r.err != nil
+}
+
+func (r *cacheReader) Next() (interface{}, error) {
+	if !r.HasNext() {
+		return nil, fmt.Errorf("no more items")
+	}
+	item, err := r.next, r.err
+	r.next, r.err = nil, nil
+	return item, err
+}
+
+func (r *cacheReader) Close() error {
+	return r.file.Close()
+}
+
+// cacheWriter passes the items through while recording them. The cache is kept when all the items were recorded,
+// not when the printing stopped early, ex. with --limit or by quitting the pager
+type cacheWriter struct {
+	iter      collection.Iterator
+	file      *os.File
+	writer    *bufio.Writer
+	typ       reflect.Type
+	path      string
+	failed    bool
+	exhausted bool
+}
+
+func (w *cacheWriter) HasNext() bool {
+	hasNext := w.iter.HasNext()
+	w.exhausted = !hasNext
+	return hasNext
+}
+
+func (w *cacheWriter) Next() (interface{}, error) {
+	item, err := w.iter.Next()
+	if err != nil || reflect.TypeOf(item) != w.typ {
+		w.failed = true
+	}
+	if !w.failed {
+		if _, err := writeItem(w.writer, item); err != nil {
+			w.failed = true
+		}
+	}
+	return item, err
+}
+
+func (w *cacheWriter) Close() error {
+	err := w.writer.Flush()
+	w.file.Close()
+	if w.failed || !w.exhausted || err != nil {
+		return os.Remove(w.file.Name())
+	}
+	return os.Rename(w.file.Name(), w.path)
+}
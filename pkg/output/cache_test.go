@@ -0,0 +1,121 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package output
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/server/common/collection"
+)
+
+type cacheSuite struct {
+	*require.Assertions
+	suite.Suite
+	env map[string]string
+}
+
+func TestCacheSuite(t *testing.T) {
+	suite.Run(t, new(cacheSuite))
+}
+
+func (s *cacheSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	// the user cache dir is under one of them depending on the platform
+	s.env = make(map[string]string)
+	dir := s.T().TempDir()
+	for _, name := range []string{"XDG_CACHE_HOME", "HOME", "LocalAppData"} {
+		s.env[name] = os.Getenv(name)
+		s.NoError(os.Setenv(name, dir))
+	}
+}
+
+func (s *cacheSuite) TearDownTest() {
+	for name, value := range s.env {
+		_ = os.Setenv(name, value)
+	}
+}
+
+type cacheRow struct {
+	Name string
+}
+
+func (s *cacheSuite) context() *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Duration(FlagCache, 0, "")
+	set.Bool(FlagRefresh, false, "")
+	set.Bool(FlagNoCache, false, "")
+	s.NoError(set.Parse([]string{"--" + FlagCache, "1m"}))
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func (s *cacheSuite) rows(n int) collection.Iterator {
+	return collection.NewPagingIterator(func(token []byte) ([]interface{}, []byte, error) {
+		items := make([]interface{}, n)
+		for i := range items {
+			items[i] = cacheRow{Name: string(rune('a' + i))}
+		}
+		return items, nil, nil
+	})
+}
+
+// read takes up to limit items from the iterator and closes it
+func (s *cacheSuite) read(iter collection.Iterator, limit int) []interface{} {
+	var items []interface{}
+	for len(items) < limit && iter.HasNext() {
+		item, err := iter.Next()
+		s.NoError(err)
+		items = append(items, item)
+	}
+	if closer, ok := iter.(interface{ Close() error }); ok {
+		s.NoError(closer.Close())
+	}
+	return items
+}
+
+func (s *cacheSuite) TestCache_LimitedRunIsNotCached() {
+	opts := &PrintOptions{ItemTemplate: cacheRow{}}
+	c := s.context()
+
+	iter, err := cacheItems(c, s.rows(3), opts)
+	s.NoError(err)
+	s.Len(s.read(iter, 2), 2, "stopped early, ex. with --limit")
+
+	fresh := s.rows(3)
+	iter, err = cacheItems(c, fresh, opts)
+	s.NoError(err)
+	_, recording := iter.(*cacheWriter)
+	s.True(recording, "the truncated list is not served from the cache")
+	s.Len(s.read(iter, 10), 3)
+
+	iter, err = cacheItems(c, s.rows(0), opts)
+	s.NoError(err)
+	_, cached := iter.(*cacheReader)
+	s.True(cached)
+	s.Equal([]interface{}{cacheRow{Name: "a"}, cacheRow{Name: "b"}, cacheRow{Name: "c"}}, s.read(iter, 10))
+}
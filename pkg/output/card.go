@@ -25,39 +25,123 @@
 package output
 
 import (
+	"bytes"
 	"fmt"
+	"io/ioutil"
+	"reflect"
+	"text/template"
+	"time"
 
 	"github.com/urfave/cli/v2"
 
 	"github.com/temporalio/tctl/pkg/color"
+	"github.com/temporalio/tctl/pkg/config"
 	"github.com/temporalio/tctl/pkg/process"
 )
 
+const (
+	nullPlaceholder        = "null"
+	emptyStructPlaceholder = "{}"
+	cardSeparator          = "---------------------------------------------------\n"
+
+	// cardLayoutKey is the config sequence of card layouts, ex. card.WorkflowExecutionInfo: Execution.WorkflowId=Workflow,Status
+	cardLayoutKey = "card"
+)
+
 func PrintCards(c *cli.Context, items []interface{}, opts *PrintOptions) {
-	rows, err := extractFieldValues(items, opts.Fields)
+	if c.IsSet(FlagCardTemplate) {
+		printCardTemplate(c, items, opts)
+		return
+	}
+
+	columns := opts.getColumns(items)
+	rows, err := extractFieldValues(c, items, columnFields(columns))
 	if err != nil {
 		process.ErrorAndExit("unable to print card", err)
 	}
 
 	if opts.Pager == nil {
-		pager, close := newPagerWithDefault(c)
+		pager, close := newPagerWithOptions(c, opts)
 		opts.Pager = pager
 		defer close()
 	}
 
-	fieldNames := make([]string, len(opts.Fields))
-	for i, field := range opts.Fields {
-		nestedFields := splitFieldPath(field)
-		fieldNames[i] = nestedFields[len(nestedFields)-1]
-	}
-
 	w := opts.Pager
 	for _, row := range rows {
-		fmt.Fprintf(w, "---------------------------------------------------\n")
+		fmt.Fprint(w, cardSeparator)
 		for j, col := range row {
-			field := fieldNames[j]
-			val := formatField(c, col)
-			fmt.Fprintf(w, "%v \t\t%v\n", color.Magenta(c, field), val)
+			val, ok := formatCardField(c, columns[j], col)
+			if !ok {
+				continue
+			}
+			fmt.Fprintf(w, "%v \t\t%v\n", color.Magenta(c, columns[j].Header()), val)
+		}
+	}
+}
+
+// formatCardField formats a card field so that a missing sub-object is told apart from an empty one:
+// nil pointers are shown as null and empty structs as {}. With --omit-empty both are left out
+func formatCardField(c *cli.Context, col Column, i interface{}) (string, bool) {
+	val := reflect.ValueOf(i)
+	if !val.IsValid() || val.Kind() == reflect.Ptr && val.IsNil() {
+		return nullPlaceholder, !c.Bool(FlagOmitEmpty)
+	}
+
+	val = reflect.Indirect(val)
+	if val.Kind() == reflect.Struct && val.Type() != reflect.TypeOf(time.Time{}) && val.IsZero() {
+		return emptyStructPlaceholder, !c.Bool(FlagOmitEmpty)
+	}
+
+	return formatField(c, col, i), true
+}
+
+// printCardTemplate executes the --card-template file for each item, one card per item
+func printCardTemplate(c *cli.Context, items []interface{}, opts *PrintOptions) {
+	if opts.template == nil {
+		path := c.String(FlagCardTemplate)
+		text, err := ioutil.ReadFile(path)
+		if err != nil {
+			process.ErrorAndExit("unable to read card template", err)
+		}
+		tmpl, err := template.New(path).Funcs(templateFuncs(c)).Parse(string(text))
+		if err != nil {
+			process.ErrorAndExit("unable to parse card template", err)
+		}
+		opts.template = tmpl
+	}
+
+	var b bytes.Buffer
+	for _, item := range items {
+		b.Reset()
+		if err := opts.template.Execute(&b, item); err != nil {
+			process.ErrorAndExit("unable to execute card template", err)
 		}
+		if !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+			b.WriteByte('\n')
+		}
+		fmt.Fprint(opts.Pager, cardSeparator)
+		_, _ = opts.Pager.Write(b.Bytes())
+	}
+}
+
+// cardLayout returns the fields expression configured for the cards of the item type, if any
+func cardLayout(items []interface{}, opts *PrintOptions) string {
+	var t reflect.Type
+	if len(items) > 0 {
+		t = reflect.TypeOf(items[0])
+	} else if opts.ItemTemplate != nil {
+		t = reflect.TypeOf(opts.ItemTemplate)
+	}
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Name() == "" {
+		return ""
+	}
+
+	layouts, err := config.GetSequence(cardLayoutKey)
+	if err != nil {
+		return ""
 	}
+	return layouts[t.Name()]
 }
@@ -0,0 +1,110 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+)
+
+type cardSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestCardSuite(t *testing.T) {
+	suite.Run(t, new(cardSuite))
+}
+
+func (s *cardSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+type cardDetails struct {
+	Name string
+}
+
+type cardItem struct {
+	ID      string
+	Details *cardDetails
+}
+
+func (s *cardSuite) printCards(omitEmpty bool, items ...interface{}) string {
+	set := flag.NewFlagSet("test", 0)
+	set.Bool(FlagOmitEmpty, omitEmpty, "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+
+	var buf bytes.Buffer
+	PrintCards(c, items, &PrintOptions{Fields: []string{"ID", "Details"}, Pager: &buf})
+	return buf.String()
+}
+
+func (s *cardSuite) TestNilPointer() {
+	out := s.printCards(false, cardItem{ID: "a"})
+	s.Contains(out, "Details \t\tnull\n")
+}
+
+func (s *cardSuite) TestEmptyStruct() {
+	out := s.printCards(false, cardItem{ID: "a", Details: &cardDetails{}})
+	s.Contains(out, "Details \t\t{}\n")
+}
+
+func (s *cardSuite) TestPopulatedStruct() {
+	out := s.printCards(false, cardItem{ID: "a", Details: &cardDetails{Name: "b"}})
+	s.Contains(out, `"Name"`)
+	s.Contains(out, `"b"`)
+}
+
+func (s *cardSuite) TestOmitEmpty() {
+	out := s.printCards(true, cardItem{ID: "a"}, cardItem{ID: "b", Details: &cardDetails{}})
+	s.Contains(out, "ID \t\ta\n")
+	s.Contains(out, "ID \t\tb\n")
+	s.NotContains(out, "Details")
+}
+
+func (s *cardSuite) TestCardTemplate() {
+	dir, err := ioutil.TempDir("", "card")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "card.tmpl")
+	s.NoError(ioutil.WriteFile(path, []byte("Runbook for {{.ID}}"), 0644))
+
+	set := flag.NewFlagSet("test", 0)
+	set.String(FlagCardTemplate, "", "")
+	s.NoError(set.Set(FlagCardTemplate, path))
+	c := cli.NewContext(cli.NewApp(), set, nil)
+
+	var buf bytes.Buffer
+	PrintCards(c, []interface{}{cardItem{ID: "a"}, cardItem{ID: "b"}}, &PrintOptions{Pager: &buf})
+	s.Equal(cardSeparator+"Runbook for a\n"+cardSeparator+"Runbook for b\n", buf.String())
+}
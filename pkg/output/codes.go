@@ -0,0 +1,183 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/gogo/protobuf/proto"
+	"github.com/urfave/cli/v2"
+	"google.golang.org/grpc/codes"
+)
+
+var codeType = reflect.TypeOf(codes.Code(0))
+
+// hasCode reports whether values of the type can hold a gRPC status code, ex. in a struct field
+func hasCode(typ reflect.Type) bool {
+	return typ != nil && typeHasCode(typ, make(map[reflect.Type]bool))
+}
+
+func typeHasCode(typ reflect.Type, visited map[reflect.Type]bool) bool {
+	if typ == codeType {
+		return true
+	}
+	if visited[typ] {
+		return false
+	}
+	visited[typ] = true
+
+	switch typ.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array, reflect.Map:
+		return typeHasCode(typ.Elem(), visited)
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if f := typ.Field(i); (f.PkgPath == "" || f.Anonymous) && typeHasCode(f.Type, visited) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// marshalCodeNames encodes the value like json.Marshal, but with the gRPC status codes as their names,
+// ex. "NotFound". Values that cannot hold a code are encoded by marshalCompactJSON
+func marshalCodeNames(c *cli.Context, val reflect.Value) ([]byte, error) {
+	if !val.IsValid() {
+		return []byte("null"), nil
+	}
+	if val.Type() == codeType {
+		return json.Marshal(codes.Code(val.Uint()).String())
+	}
+	if _, ok := val.Interface().(proto.Message); ok || !hasCode(val.Type()) {
+		return marshalCompactJSON(c, val.Interface())
+	}
+
+	switch val.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if val.IsNil() {
+			return []byte("null"), nil
+		}
+		return marshalCodeNames(c, val.Elem())
+	case reflect.Slice, reflect.Array:
+		if val.Kind() == reflect.Slice && val.IsNil() {
+			return []byte("null"), nil
+		}
+		items := make([]json.RawMessage, val.Len())
+		for i := range items {
+			b, err := marshalCodeNames(c, val.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			items[i] = b
+		}
+		return json.Marshal(items)
+	case reflect.Map:
+		if val.IsNil() || val.Type().Key().Kind() != reflect.String {
+			return json.Marshal(val.Interface())
+		}
+		entries := make(map[string]json.RawMessage, val.Len())
+		for _, key := range val.MapKeys() {
+			b, err := marshalCodeNames(c, val.MapIndex(key))
+			if err != nil {
+				return nil, err
+			}
+			entries[key.String()] = b
+		}
+		return json.Marshal(entries)
+	case reflect.Struct:
+		var out bytes.Buffer
+		out.WriteByte('{')
+		if err := writeCodeNamesFields(c, &out, val); err != nil {
+			return nil, err
+		}
+		out.WriteByte('}')
+		return out.Bytes(), nil
+	}
+	return json.Marshal(val.Interface())
+}
+
+// writeCodeNamesFields writes the exported fields of the struct honoring the json tags, with the fields
+// of the embedded structs inlined
+func writeCodeNamesFields(c *cli.Context, out *bytes.Buffer, val reflect.Value) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		tag := f.Tag.Get("json")
+		name, opts := tag, ""
+		if idx := strings.Index(tag, ","); idx >= 0 {
+			name, opts = tag[:idx], tag[idx:]
+		}
+		fv := val.Field(i)
+		if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+			if err := writeCodeNamesFields(c, out, fv); err != nil {
+				return err
+			}
+			continue
+		}
+		if f.PkgPath != "" || tag == "-" {
+			continue
+		}
+		if strings.Contains(opts, ",omitempty") && isEmptyJSONValue(fv) {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		b, err := marshalCodeNames(c, fv)
+		if err != nil {
+			return err
+		}
+		k, err := json.Marshal(name)
+		if err != nil {
+			return err
+		}
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		out.Write(k)
+		out.WriteByte(':')
+		out.Write(b)
+	}
+	return nil
+}
+
+// isEmptyJSONValue mirrors the omitempty rule of encoding/json
+func isEmptyJSONValue(val reflect.Value) bool {
+	switch val.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return val.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return val.IsNil()
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		return val.IsZero()
+	}
+	return false
+}
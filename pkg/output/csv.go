@@ -0,0 +1,75 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"encoding/csv"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// PrintCSV prints the items as comma separated values, or tab separated values for TSV output
+func PrintCSV(c *cli.Context, items []interface{}, opts *PrintOptions, separator rune) {
+	columns := opts.getColumns(items)
+	w := csv.NewWriter(opts.Pager)
+	w.Comma = separator
+	trim := trimTrailing(c)
+
+	if !opts.NoHeader {
+		header := make([]string, len(columns))
+		for i, col := range columns {
+			header[i] = col.Header()
+		}
+		if err := w.Write(header); err != nil {
+			process.ErrorAndExit("unable to print csv", err)
+		}
+	}
+
+	rows, err := extractFieldValues(c, items, columnFields(columns))
+	if err != nil {
+		process.ErrorAndExit("unable to print csv", err)
+	}
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for j, value := range row {
+			cells[j] = formatField(c, columns[j], value)
+			if trim {
+				cells[j] = strings.TrimSpace(cells[j])
+			}
+		}
+		if err := w.Write(cells); err != nil {
+			process.ErrorAndExit("unable to print csv", err)
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		process.ErrorAndExit("unable to print csv", err)
+	}
+}
@@ -0,0 +1,76 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+)
+
+// deduplicator remembers the identity field values of the printed items.
+// Memory grows with the number of distinct values
+type deduplicator struct {
+	field    string
+	resolved string
+	values   map[string]struct{}
+}
+
+func newDeduplicator(field string) *deduplicator {
+	return &deduplicator{field: field, values: make(map[string]struct{})}
+}
+
+// seen reports whether an item with the same identity was already streamed, and remembers the item otherwise
+func (d *deduplicator) seen(c *cli.Context, item interface{}) (bool, error) {
+	if d.resolved == "" {
+		d.resolved = resolveFieldName(extractFieldNames(item, []string{}, "", fieldsDepth), d.field)
+	}
+
+	value, ok := ResolveField(c, item, d.resolved)
+	if !ok {
+		return false, fmt.Errorf("unknown field %v", d.field)
+	}
+
+	key := formatField(c, Column{Field: d.resolved}, value)
+	if _, ok := d.values[key]; ok {
+		return true, nil
+	}
+	d.values[key] = struct{}{}
+	return false, nil
+}
+
+// resolveFieldName finds the field by its path, or by its name when it is nested (ex. WorkflowId for Execution.WorkflowId)
+func resolveFieldName(known []string, field string) string {
+	if containsString(known, field) {
+		return field
+	}
+	for _, f := range known {
+		if strings.HasSuffix(f, "."+field) {
+			return f
+		}
+	}
+	return field
+}
@@ -0,0 +1,358 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/temporalio/tctl/pkg/format"
+)
+
+// Fields expression grammar, terms are separated by comma:
+//   Execution.RunId          include a field (dot-path)
+//   Memo.Fields.key          map key or slice index in the path, ex. PendingActivities.0.ActivityType
+//   -Execution.RunId         exclude a field
+//   +Execution.RunId         add a field to the default fields
+//   @long                    preset: @default, @long, @all
+//   Execution.*              glob, matched against the available fields
+//   Execution.RunId=Run      label to use in the header instead of the field name
+//   StartTime%iso            directive to apply when rendering the field
+// Commas, labels and directives inside brackets or quotes are part of the term, ex. Execution.[WR]*,Type.Name="Type, name".
+// Terms are resolved in the following order: base fields (included fields or, if none, the default ones),
+// additive fields, presets and globs expansion, exclusions, duplicates removal.
+
+type FieldOp int
+
+const (
+	FieldInclude FieldOp = iota
+	FieldExclude
+	FieldAdd
+)
+
+const (
+	presetPrefix    = "@"
+	labelPrefix     = "="
+	directivePrefix = "%"
+
+	PresetDefault = "default"
+	PresetLong    = "long"
+	PresetAll     = "all"
+)
+
+var (
+	knownDirectives = []string{string(format.Relative), string(format.ISO), string(format.Raw)}
+)
+
+// FieldSelector is a single term of the fields expression
+type FieldSelector struct {
+	Op         FieldOp
+	Pattern    string
+	Label      string
+	Directives []string
+}
+
+// FieldsSpec is a parsed fields expression
+type FieldsSpec struct {
+	Selectors []FieldSelector
+}
+
+// Column is a resolved field to print
+type Column struct {
+	Field      string
+	Label      string
+	Directives []string
+}
+
+// ParseFields parses the fields expression into a structured spec
+func ParseFields(expr string) (*FieldsSpec, error) {
+	terms, err := splitTerms(expr, ',')
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &FieldsSpec{}
+	for _, term := range terms {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		selector, err := parseFieldSelector(term)
+		if err != nil {
+			return nil, err
+		}
+		spec.Selectors = append(spec.Selectors, selector)
+	}
+
+	return spec, nil
+}
+
+func parseFieldSelector(term string) (FieldSelector, error) {
+	var selector FieldSelector
+
+	switch term[0] {
+	case '-':
+		selector.Op = FieldExclude
+		term = term[1:]
+	case '+':
+		selector.Op = FieldAdd
+		term = term[1:]
+	}
+
+	if i := indexOutside(term, labelPrefix[0]); i >= 0 {
+		selector.Label = unquote(strings.TrimSpace(term[i+1:]))
+		term = term[:i]
+		if selector.Label == "" {
+			return selector, fmt.Errorf("empty label in field %q", term)
+		}
+	}
+
+	parts, _ := splitTerms(term, directivePrefix[0])
+	selector.Pattern = strings.TrimSpace(parts[0])
+	for _, d := range parts[1:] {
+		d = strings.TrimSpace(d)
+		if !isKnownDirective(d) {
+			return selector, fmt.Errorf("unknown directive %q in field %q. Available directives: %v", d, term, strings.Join(knownDirectives, ", "))
+		}
+		selector.Directives = append(selector.Directives, d)
+	}
+
+	if selector.Pattern == "" || selector.Pattern == presetPrefix {
+		return selector, fmt.Errorf("missing field name in %q", term)
+	}
+	if selector.Op == FieldExclude && (selector.Label != "" || len(selector.Directives) > 0) {
+		return selector, fmt.Errorf("labels and directives are not allowed in excluded field %q", term)
+	}
+
+	// keep "long" keyword for backward compatibility
+	if selector.Op == FieldInclude && selector.Pattern == PresetLong {
+		selector.Op = FieldAdd
+		selector.Pattern = presetPrefix + PresetLong
+	}
+
+	return selector, nil
+}
+
+// Resolve produces the list of columns given the default fields of the command, the fields of the
+// long preset and the fields available in the printed items
+func (s *FieldsSpec) Resolve(defaults []string, long []string, known []string) ([]Column, error) {
+	presets := map[string][]string{
+		PresetDefault: defaults,
+		PresetLong:    long,
+		PresetAll:     known,
+	}
+
+	var base, added []FieldSelector
+	for _, sel := range s.Selectors {
+		switch sel.Op {
+		case FieldInclude:
+			base = append(base, sel)
+		case FieldAdd:
+			added = append(added, sel)
+		}
+	}
+	if len(base) == 0 {
+		for _, f := range defaults {
+			base = append(base, FieldSelector{Pattern: f})
+		}
+	}
+
+	var columns []Column
+	for _, sel := range append(base, added...) {
+		fields, err := expandSelector(sel, presets, known)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range fields {
+			col := Column{Field: f, Directives: sel.Directives}
+			if len(fields) == 1 {
+				col.Label = sel.Label
+			}
+			columns = append(columns, col)
+		}
+	}
+
+	for _, sel := range s.Selectors {
+		if sel.Op != FieldExclude {
+			continue
+		}
+		excluded, err := expandSelector(sel, presets, known)
+		if err != nil {
+			return nil, err
+		}
+		columns = removeColumns(columns, excluded)
+	}
+
+	return dedupColumns(columns), nil
+}
+
+func expandSelector(sel FieldSelector, presets map[string][]string, known []string) ([]string, error) {
+	if strings.HasPrefix(sel.Pattern, presetPrefix) {
+		preset := strings.TrimPrefix(sel.Pattern, presetPrefix)
+		fields, ok := presets[preset]
+		if !ok {
+			return nil, fmt.Errorf("unknown preset %v. Available presets: @%v, @%v, @%v", sel.Pattern, PresetDefault, PresetLong, PresetAll)
+		}
+		return fields, nil
+	}
+
+	if !isGlob(sel.Pattern) {
+		return []string{sel.Pattern}, nil
+	}
+
+	var fields []string
+	for _, f := range known {
+		if ok, err := path.Match(sel.Pattern, f); err != nil {
+			return nil, fmt.Errorf("invalid field pattern %q: %w", sel.Pattern, err)
+		} else if ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields, nil
+}
+
+func removeColumns(columns []Column, fields []string) []Column {
+	var result []Column
+	for _, col := range columns {
+		if !containsString(fields, col.Field) {
+			result = append(result, col)
+		}
+	}
+	return result
+}
+
+func dedupColumns(columns []Column) []Column {
+	seen := make(map[string]bool, len(columns))
+	var result []Column
+	for _, col := range columns {
+		if seen[col.Field] {
+			continue
+		}
+		seen[col.Field] = true
+		result = append(result, col)
+	}
+	return result
+}
+
+func columnsFromFields(fields []string) []Column {
+	columns := make([]Column, len(fields))
+	for i, f := range fields {
+		columns[i] = Column{Field: f}
+	}
+	return columns
+}
+
+func columnFields(columns []Column) []string {
+	fields := make([]string, len(columns))
+	for i, col := range columns {
+		fields[i] = col.Field
+	}
+	return fields
+}
+
+// Header returns the name to print for the column
+func (col Column) Header() string {
+	if col.Label != "" {
+		return col.Label
+	}
+	nestedFields := splitFieldPath(col.Field)
+	return nestedFields[len(nestedFields)-1]
+}
+
+// splitTerms splits the expression at the separators that are neither inside brackets nor quotes
+func splitTerms(expr string, sep byte) ([]string, error) {
+	if depth, quote := scanNesting(expr, len(expr)); depth != 0 || quote != 0 {
+		return nil, fmt.Errorf("unbalanced brackets or quotes in %q", expr)
+	}
+
+	var terms []string
+	for {
+		i := indexOutside(expr, sep)
+		if i < 0 {
+			break
+		}
+		terms = append(terms, expr[:i])
+		expr = expr[i+1:]
+	}
+	return append(terms, expr), nil
+}
+
+// indexOutside returns the index of the first separator that is neither inside brackets nor quotes, or -1
+func indexOutside(s string, sep byte) int {
+	for i := 0; i < len(s); i++ {
+		if s[i] != sep {
+			continue
+		}
+		if depth, quote := scanNesting(s, i); depth == 0 && quote == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// scanNesting returns the bracket depth and the open quote, if any, at the given position of s
+func scanNesting(s string, pos int) (depth int, quote byte) {
+	for i := 0; i < pos; i++ {
+		switch ch := s[i]; {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			}
+		case ch == '"' || ch == '\'':
+			quote = ch
+		case ch == '[':
+			depth++
+		case ch == ']' && depth > 0:
+			depth--
+		}
+	}
+	return depth, quote
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
+
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, "*?[")
+}
+
+func isKnownDirective(d string) bool {
+	return containsString(knownDirectives, d)
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,136 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type fieldsSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestFieldsSuite(t *testing.T) {
+	suite.Run(t, new(fieldsSuite))
+}
+
+func (s *fieldsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+var (
+	testDefaults = []string{"Execution.WorkflowId", "Execution.RunId", "StartTime"}
+	testLong     = []string{"Type.Name", "CloseTime"}
+	testKnown    = []string{"Execution", "Execution.WorkflowId", "Execution.RunId", "Type", "Type.Name", "StartTime", "CloseTime"}
+)
+
+func (s *fieldsSuite) resolve(expr string) []Column {
+	spec, err := ParseFields(expr)
+	s.NoError(err)
+	columns, err := spec.Resolve(testDefaults, testLong, testKnown)
+	s.NoError(err)
+	return columns
+}
+
+func (s *fieldsSuite) TestParseFields() {
+	spec, err := ParseFields("-Execution.RunId, +Type.Name%iso=Type, @long")
+	s.NoError(err)
+	s.Equal([]FieldSelector{
+		{Op: FieldExclude, Pattern: "Execution.RunId"},
+		{Op: FieldAdd, Pattern: "Type.Name", Label: "Type", Directives: []string{"iso"}},
+		{Op: FieldInclude, Pattern: "@long"},
+	}, spec.Selectors)
+}
+
+func (s *fieldsSuite) TestParseFields_Invalid() {
+	_, err := ParseFields("StartTime%unknown")
+	s.Error(err)
+	_, err = ParseFields("-StartTime=Start")
+	s.Error(err)
+	_, err = ParseFields("StartTime=")
+	s.Error(err)
+}
+
+func (s *fieldsSuite) TestParseFields_Brackets() {
+	spec, err := ParseFields("Execution.[RW]*,Type.Name='Type, name', Memo.[a,b]%iso")
+	s.NoError(err)
+	s.Equal([]FieldSelector{
+		{Op: FieldInclude, Pattern: "Execution.[RW]*"},
+		{Op: FieldInclude, Pattern: "Type.Name", Label: "Type, name"},
+		{Op: FieldInclude, Pattern: "Memo.[a,b]", Directives: []string{"iso"}},
+	}, spec.Selectors)
+
+	_, err = ParseFields("Execution.[RW*,StartTime")
+	s.Error(err)
+	_, err = ParseFields("StartTime=\"Start")
+	s.Error(err)
+}
+
+func (s *fieldsSuite) TestResolve_BracketGlob() {
+	columns := s.resolve("Execution.[RW]*")
+	s.Equal(columnsFromFields([]string{"Execution.WorkflowId", "Execution.RunId"}), columns)
+}
+
+func (s *fieldsSuite) TestResolve_Include() {
+	s.Equal([]string{"Type.Name", "StartTime"}, columnFields(s.resolve("Type.Name,StartTime")))
+}
+
+func (s *fieldsSuite) TestResolve_ExcludeFromDefaults() {
+	s.Equal([]string{"Execution.WorkflowId", "StartTime"}, columnFields(s.resolve("-Execution.RunId")))
+}
+
+func (s *fieldsSuite) TestResolve_AddToDefaults() {
+	s.Equal([]string{"Execution.WorkflowId", "Execution.RunId", "StartTime", "CloseTime"}, columnFields(s.resolve("+CloseTime")))
+}
+
+func (s *fieldsSuite) TestResolve_LongKeyword() {
+	s.Equal(append(append([]string{}, testDefaults...), testLong...), columnFields(s.resolve("long")))
+}
+
+func (s *fieldsSuite) TestResolve_GlobPresetAndExclude() {
+	columns := s.resolve("Execution.*,@long,-*Time")
+	s.Equal([]string{"Execution.WorkflowId", "Execution.RunId", "Type.Name"}, columnFields(columns))
+}
+
+func (s *fieldsSuite) TestResolve_LabelsAndDirectives() {
+	columns := s.resolve("Execution.WorkflowId=ID,StartTime%iso,Execution.WorkflowId")
+	s.Equal([]Column{
+		{Field: "Execution.WorkflowId", Label: "ID"},
+		{Field: "StartTime", Directives: []string{"iso"}},
+	}, columns)
+	s.Equal("ID", columns[0].Header())
+	s.Equal("StartTime", columns[1].Header())
+}
+
+func (s *fieldsSuite) TestResolve_UnknownPreset() {
+	spec, err := ParseFields("@unknown")
+	s.NoError(err)
+	_, err = spec.Resolve(testDefaults, testLong, testKnown)
+	s.Error(err)
+}
@@ -27,9 +27,52 @@ package output
 import "fmt"
 
 const (
-	FlagOutput = "output"
-	FlagFields = "fields"
-	FlagLimit  = "limit"
+	FlagOutput   = "output"
+	FlagNoHeader = "no-header"
+	FlagFields   = "fields"
+	FlagLimit    = "limit"
+
+	FlagPrintCursor = "print-cursor"
+	FlagCursorFile  = "cursor-file"
+	FlagStartCursor = "start-cursor"
+
+	FlagCache   = "cache"
+	FlagRefresh = "refresh"
+	FlagNoCache = "no-cache"
+
+	FlagSSE             = "sse"
+	FlagCompactPolicies = "compact-policies"
+	FlagOutputFile      = "output-file"
+	FlagAnnotate        = "annotate"
+	FlagTrimTrailing    = "trim-trailing"
+	FlagExpand          = "expand"
+	FlagProfileFields   = "profile-fields"
+	FlagAlso            = "also"
+	FlagASCII           = "ascii"
+	FlagDedup           = "dedup"
+	FlagTrailerChecksum = "trailer-checksum"
+	FlagHeadersOnly     = "headers-only"
+	FlagOutputExec      = "output-exec"
+	FlagOmitEmpty       = "omit-empty"
+	FlagSort            = "sort"
+	FlagSortBy          = "sort-by"
+	FlagDesc            = "desc"
+	FlagAll             = "all"
+	FlagSpool           = "spool"
+	FlagMapSort         = "map-sort"
+	FlagIDsOnly         = "ids-only"
+	FlagQuiet           = "quiet"
+	FlagIDFields        = "id-fields"
+	FlagSelect          = "select"
+	FlagCodesAsInt      = "codes-as-int"
+	FlagMaxFieldLength  = "max-field-length"
+	FlagColumnWidth     = "column-width"
+	FlagNoTruncate      = "no-truncate"
+	FlagLegacyJSON      = "legacy-json"
+	FlagWatch           = "watch"
+	FlagCardTemplate    = "card-template"
+	FlagRawValues       = "raw-values"
+	FlagInteractive     = "interactive"
 
 	FieldsLong = "long"
 )
@@ -37,11 +80,20 @@ const (
 type OutputOption string
 
 const (
-	Table OutputOption = "table"
-	JSON  OutputOption = "json"
-	Card  OutputOption = "card"
+	Table    OutputOption = "table"
+	Wide     OutputOption = "wide" // table with the FieldsLong columns
+	JSON     OutputOption = "json"
+	Card     OutputOption = "card"
+	Parquet  OutputOption = "parquet"
+	YAML     OutputOption = "yaml"
+	CSV      OutputOption = "csv"
+	TSV      OutputOption = "tsv"
+	NDJSON   OutputOption = "ndjson"
+	Markdown OutputOption = "markdown"
+
+	GoTemplate OutputOption = "go-template"
 )
 
 var (
-	UsageText = fmt.Sprintf("format output as: %v, %v, %v.", Table, JSON, Card)
+	UsageText = fmt.Sprintf("format output as: %v, %v, %v, %v, %v, %v, %v, %v, %v, %v, %v=TEMPLATE.", Table, Wide, JSON, NDJSON, YAML, Card, CSV, TSV, Markdown, Parquet, GoTemplate)
 )
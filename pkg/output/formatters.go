@@ -0,0 +1,205 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+	"go.temporal.io/server/common/payload"
+	"google.golang.org/grpc/codes"
+
+	"github.com/temporalio/tctl/pkg/color"
+)
+
+// Formatter renders a value of a registered type in a table or card cell. It returns false to fall back
+// to the default rendering
+type Formatter func(c *cli.Context, value interface{}) (string, bool)
+
+// formatters are consulted by the exact type of the value before the reflection based rendering
+var formatters = map[reflect.Type]Formatter{
+	reflect.TypeOf(codes.Code(0)):                      formatCode,
+	reflect.TypeOf(statusCell{}):                       formatStatusCell,
+	reflect.TypeOf(enumspb.WorkflowExecutionStatus(0)): formatWorkflowStatus,
+	reflect.TypeOf(ageCell{}):                          formatAgeCell,
+	reflect.TypeOf((*commonpb.Payload)(nil)):           formatPayload,
+	reflect.TypeOf((*commonpb.Payloads)(nil)):          formatPayloads,
+	reflect.TypeOf((*commonpb.RetryPolicy)(nil)):       formatCompactRetryPolicy,
+	reflect.TypeOf((*failurepb.Failure)(nil)):          formatFailure,
+	reflect.TypeOf(time.Duration(0)):                   formatDuration,
+	reflect.TypeOf((*time.Duration)(nil)):              formatDuration,
+}
+
+// sizeFieldSuffix marks the integer fields holding byte sizes, ex. HistorySizeBytes
+const sizeFieldSuffix = "Bytes"
+
+// RegisterFormatter sets the formatter of the values of the sample's type, ex. (*failurepb.Failure)(nil).
+// Formatters of pointer types are not called for nil values
+func RegisterFormatter(sample interface{}, formatter Formatter) {
+	formatters[reflect.TypeOf(sample)] = formatter
+}
+
+func formatCode(c *cli.Context, value interface{}) (string, bool) {
+	code := value.(codes.Code)
+	if c.Bool(FlagCodesAsInt) {
+		return strconv.FormatUint(uint64(code), 10), true
+	}
+	return code.String(), true
+}
+
+func formatStatusCell(c *cli.Context, value interface{}) (string, bool) {
+	return formatStatus(c, value.(statusCell)), true
+}
+
+func formatWorkflowStatus(c *cli.Context, value interface{}) (string, bool) {
+	status := value.(enumspb.WorkflowExecutionStatus)
+	return color.Colorize(c, statusRole(status), "%s", status), true
+}
+
+func formatAgeCell(c *cli.Context, value interface{}) (string, bool) {
+	return formatAge(c, value.(ageCell)), true
+}
+
+// formatPayload decodes search attributes, memo and other single values
+func formatPayload(c *cli.Context, value interface{}) (string, bool) {
+	return payload.ToString(value.(*commonpb.Payload)), true
+}
+
+// formatPayloads decodes inputs, results and heartbeat details
+func formatPayloads(c *cli.Context, value interface{}) (string, bool) {
+	var values []string
+	for _, p := range value.(*commonpb.Payloads).GetPayloads() {
+		values = append(values, payload.ToString(p))
+	}
+	return "[" + strings.Join(values, ", ") + "]", true
+}
+
+func formatCompactRetryPolicy(c *cli.Context, value interface{}) (string, bool) {
+	if !c.Bool(FlagCompactPolicies) {
+		return "", false
+	}
+	return formatRetryPolicy(value.(*commonpb.RetryPolicy)), true
+}
+
+// formatFailure prints the failure message and type followed by its causes, ex. "activity error; caused by: MyError: boom"
+func formatFailure(c *cli.Context, value interface{}) (string, bool) {
+	var parts []string
+	for f := value.(*failurepb.Failure); f != nil; f = f.GetCause() {
+		part := f.GetMessage()
+		if t := failureType(f); t != "" {
+			part = fmt.Sprintf("%s: %s", t, part)
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, "; caused by: "), true
+}
+
+func failureType(f *failurepb.Failure) string {
+	switch {
+	case f.GetApplicationFailureInfo() != nil:
+		return f.GetApplicationFailureInfo().GetType()
+	case f.GetTimeoutFailureInfo() != nil:
+		return fmt.Sprintf("%v timeout", f.GetTimeoutFailureInfo().GetTimeoutType())
+	case f.GetTerminatedFailureInfo() != nil:
+		return "Terminated"
+	case f.GetCanceledFailureInfo() != nil:
+		return "Canceled"
+	}
+	return ""
+}
+
+// formatDuration prints timeouts and execution times compactly, ex. 2h13m instead of 2h13m0s.
+// With --raw-values the nanosecond count is printed
+func formatDuration(c *cli.Context, value interface{}) (string, bool) {
+	var d time.Duration
+	switch v := value.(type) {
+	case time.Duration:
+		d = v
+	case *time.Duration:
+		d = *v
+	}
+	if c.Bool(FlagRawValues) {
+		return strconv.FormatInt(int64(d), 10), true
+	}
+	return compactDuration(d), true
+}
+
+func compactDuration(d time.Duration) string {
+	if d >= time.Minute || d <= -time.Minute {
+		d = d.Round(time.Second)
+	}
+	s := d.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// isSizeField tells whether the column holds a byte size to print with binary units
+func isSizeField(c *cli.Context, col Column, val reflect.Value) bool {
+	if c.Bool(FlagRawValues) || !strings.HasSuffix(col.Field, sizeFieldSuffix) {
+		return false
+	}
+	switch val.Kind() {
+	case reflect.Int, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+var sizeUnits = []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+
+// formatSize prints the byte size with binary units, ex. 1.4 MiB
+func formatSize(val reflect.Value) string {
+	var n float64
+	if val.Kind() >= reflect.Uint && val.Kind() <= reflect.Uint64 {
+		n = float64(val.Uint())
+	} else {
+		n = float64(val.Int())
+	}
+	if n < 1024 && n > -1024 {
+		return fmt.Sprintf("%v B", n)
+	}
+
+	unit := ""
+	for _, u := range sizeUnits {
+		n /= 1024
+		unit = u
+		if n < 1024 && n > -1024 {
+			break
+		}
+	}
+	return fmt.Sprintf("%.1f %s", n, unit)
+}
@@ -0,0 +1,136 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	failurepb "go.temporal.io/api/failure/v1"
+)
+
+type formattersSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestFormattersSuite(t *testing.T) {
+	suite.Run(t, new(formattersSuite))
+}
+
+func (s *formattersSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+type formattedValue struct {
+	Name string
+}
+
+func (s *formattersSuite) TestFormatField_Failure() {
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	failure := &failurepb.Failure{
+		Message: "activity error",
+		Cause: &failurepb.Failure{
+			Message:     "boom",
+			FailureInfo: &failurepb.Failure_ApplicationFailureInfo{ApplicationFailureInfo: &failurepb.ApplicationFailureInfo{Type: "MyError"}},
+		},
+	}
+
+	s.Equal("activity error; caused by: MyError: boom", formatField(c, Column{}, failure))
+	s.Equal("nil", formatField(c, Column{}, (*failurepb.Failure)(nil)))
+}
+
+func (s *formattersSuite) TestRegisterFormatter() {
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	RegisterFormatter(formattedValue{}, func(c *cli.Context, value interface{}) (string, bool) {
+		return "name=" + value.(formattedValue).Name, true
+	})
+	defer delete(formatters, reflect.TypeOf(formattedValue{}))
+
+	s.Equal("name=a", formatField(c, Column{}, formattedValue{Name: "a"}))
+}
+
+func (s *formattersSuite) TestFormatField_DurationAndSize() {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	raw := set.Bool(FlagRawValues, false, "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+	timeout := 2*time.Hour + 13*time.Minute
+	col := Column{Field: "HistorySizeBytes"}
+
+	s.Equal("2h13m", formatField(c, Column{}, &timeout))
+	s.Equal("1h", formatField(c, Column{}, time.Hour))
+	s.Equal("1m30s", formatField(c, Column{}, 90*time.Second))
+	s.Equal("1.4 MiB", formatField(c, col, int64(1468006)))
+	s.Equal("512 B", formatField(c, col, int64(512)))
+
+	*raw = true
+	s.Equal("7980000000000", formatField(c, Column{}, &timeout))
+	s.Equal("1468006", formatField(c, col, int64(1468006)))
+}
+
+func (s *formattersSuite) TestFormatField_NilTime() {
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	s.Equal("", formatField(c, Column{Field: "StartedTime"}, (*time.Time)(nil)))
+}
+
+func (s *formattersSuite) TestFormatField_RetryPolicy() {
+	initial, maximum := time.Second, 100*time.Second
+	tests := []struct {
+		name    string
+		compact bool
+		policy  *commonpb.RetryPolicy
+		want    string
+	}{
+		{
+			name:    "compact",
+			compact: true,
+			policy:  &commonpb.RetryPolicy{MaximumAttempts: 5, InitialInterval: &initial, BackoffCoefficient: 2, MaximumInterval: &maximum},
+			want:    "max=5 init=1s backoff=2.0 max-interval=1m40s",
+		},
+		{
+			name:    "non-retryable errors",
+			compact: true,
+			policy:  &commonpb.RetryPolicy{MaximumAttempts: 1, BackoffCoefficient: 1.5, NonRetryableErrorTypes: []string{"NotFound", "Invalid"}},
+			want:    "max=1 init=0s backoff=1.5 max-interval=0s non-retryable=NotFound,Invalid",
+		},
+		{
+			name:   "json without the flag",
+			policy: &commonpb.RetryPolicy{MaximumAttempts: 5, InitialInterval: &initial},
+			want:   `{"initialInterval":"1s","maximumAttempts":5}`,
+		},
+	}
+	for _, tt := range tests {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		set.Bool(FlagCompactPolicies, tt.compact, "")
+		c := cli.NewContext(cli.NewApp(), set, nil)
+		s.Equal(tt.want, formatField(c, Column{Field: "RetryPolicy"}, tt.policy), tt.name)
+	}
+}
@@ -0,0 +1,141 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// PrintHeaders prints the shape of the items without the items: the header row of the table,
+// an empty card or a JSON record with zero valued fields
+func PrintHeaders(c *cli.Context, sample interface{}, output OutputOption, opts *PrintOptions) {
+	if sample == nil {
+		process.ErrorAndExit("unable to print headers", fmt.Errorf("headers are not available for this command"))
+	}
+
+	switch output {
+	case JSON:
+		record, err := zeroRecord(reflect.TypeOf(sample), opts.getColumns(nil))
+		if err != nil {
+			process.ErrorAndExit("unable to print headers", err)
+		}
+		b, err := marshalJSON(c, record, true)
+		if err != nil {
+			process.ErrorAndExit("unable to print headers", err)
+		}
+		fmt.Fprintln(opts.Pager, string(b))
+	case Card:
+		for _, col := range opts.getColumns(nil) {
+			fmt.Fprintln(opts.Pager, col.Header())
+		}
+	case CSV:
+		PrintCSV(c, nil, opts, ',')
+	case TSV:
+		PrintCSV(c, nil, opts, '\t')
+	case Markdown:
+		PrintMarkdown(c, nil, opts)
+	default:
+		PrintTable(c, nil, opts)
+	}
+}
+
+// zeroRecord builds a nested record of the columns with the zero values of their types
+func zeroRecord(typ reflect.Type, columns []Column) (map[string]interface{}, error) {
+	record := make(map[string]interface{})
+	for _, col := range columns {
+		node := record
+		t := typ
+		path := splitFieldPath(col.Field)
+		for i, name := range path {
+			for t != nil && t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			if t == nil || t.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("unknown field %v", col.Field)
+			}
+			field, ok := t.FieldByName(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown field %v", col.Field)
+			}
+			t = field.Type
+
+			if i == len(path)-1 {
+				leaf := t
+				for leaf.Kind() == reflect.Ptr {
+					leaf = leaf.Elem()
+				}
+				node[name] = reflect.Zero(leaf).Interface()
+				break
+			}
+			child, ok := node[name].(map[string]interface{})
+			if !ok {
+				child = make(map[string]interface{})
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	return record, nil
+}
+
+// extractTypeFieldNames lists the fields of a type the way extractFieldNames does for values, including fields behind nil pointers
+func extractTypeFieldNames(typ reflect.Type, fieldNames []string, parentField string, depth int) []string {
+	if depth == 0 || typ == nil {
+		return fieldNames
+	}
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	if typ.Kind() != reflect.Struct {
+		return fieldNames
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !isFieldExported(field) {
+			continue
+		}
+
+		fieldName := field.Name
+		if parentField != "" {
+			fieldName = parentField + "." + fieldName
+		}
+		fieldNames = append(fieldNames, fieldName)
+
+		sub := field.Type
+		for sub.Kind() == reflect.Ptr {
+			sub = sub.Elem()
+		}
+		if sub.Kind() == reflect.Struct {
+			fieldNames = extractTypeFieldNames(sub, fieldNames, fieldName, depth-1)
+		}
+	}
+	return fieldNames
+}
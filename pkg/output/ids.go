@@ -0,0 +1,119 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// identityFields are looked up, in order of preference, to detect the identity of the items
+var identityFields = [][]string{
+	{"WorkflowId", "RunId"},
+	{"WorkflowId"},
+	{"Id"},
+	{"ID"},
+	{"Name"},
+	{"ScheduleId"},
+}
+
+// idsOnly tells whether only the ids are printed, with --ids-only or -q given to the command or globally
+func idsOnly(c *cli.Context) bool {
+	return c.Bool(FlagIDsOnly) || quiet(c)
+}
+
+func quiet(c *cli.Context) bool {
+	// the command's own -q shadows the global one in c.Bool
+	for _, ctx := range c.Lineage() {
+		if ctx.Bool(FlagQuiet) {
+			return true
+		}
+	}
+	return false
+}
+
+// PrintIDs prints the identity fields of the items, one item per line, quoted for xargs when needed.
+// With -q only the primary id is printed, ex. the WorkflowId of WorkflowId and RunId, so that each
+// line is a single argument
+func PrintIDs(c *cli.Context, items []interface{}, opts *PrintOptions) {
+	if len(items) == 0 {
+		return
+	}
+
+	known := extractFieldNames(items[0], []string{}, "", fieldsDepth)
+	var fields []string
+	if c.IsSet(FlagIDFields) {
+		for _, f := range strings.Split(c.String(FlagIDFields), ",") {
+			fields = append(fields, resolveFieldName(known, strings.TrimSpace(f)))
+		}
+	} else {
+		fields = detectIdentityFields(known)
+		if len(fields) > 1 && quiet(c) && !c.Bool(FlagIDsOnly) {
+			fields = fields[:1]
+		}
+	}
+	if len(fields) == 0 {
+		process.ErrorAndExit("unable to print ids", fmt.Errorf("unable to detect the id fields, set them with --%s", FlagIDFields))
+	}
+
+	rows, err := extractFieldValues(c, items, fields)
+	if err != nil {
+		process.ErrorAndExit("unable to print ids", err)
+	}
+	for _, row := range rows {
+		ids := make([]string, len(row))
+		for j, value := range row {
+			ids[j] = quoteID(formatField(c, Column{Field: fields[j]}, value))
+		}
+		fmt.Fprintln(opts.Pager, strings.Join(ids, " "))
+	}
+}
+
+func detectIdentityFields(known []string) []string {
+	for _, candidate := range identityFields {
+		var fields []string
+		for _, name := range candidate {
+			if f := resolveFieldName(known, name); containsString(known, f) {
+				fields = append(fields, f)
+			}
+		}
+		if len(fields) == len(candidate) {
+			return fields
+		}
+	}
+	return nil
+}
+
+// quoteID single-quotes ids with spaces or quotes the way xargs and shells parse them
+func quoteID(id string) string {
+	if id != "" && !strings.ContainsAny(id, " \t\n'\"\\") {
+		return id
+	}
+	return "'" + strings.ReplaceAll(id, "'", `'\''`) + "'"
+}
@@ -0,0 +1,86 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package output
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+)
+
+type idsSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestIDsSuite(t *testing.T) {
+	suite.Run(t, new(idsSuite))
+}
+
+func (s *idsSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+type idsExecution struct {
+	WorkflowId string
+	RunId      string
+	Type       string
+}
+
+func (s *idsSuite) printIDs(args ...string) []string {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Bool(FlagQuiet, false, "")
+	set.Bool(FlagIDsOnly, false, "")
+	set.String(FlagIDFields, "", "")
+	s.NoError(set.Parse(args))
+	c := cli.NewContext(cli.NewApp(), set, nil)
+
+	out := &bytes.Buffer{}
+	items := []interface{}{
+		idsExecution{WorkflowId: "order-1", RunId: "run-1", Type: "Order"},
+		idsExecution{WorkflowId: "order 2", RunId: "run-2", Type: "Order"},
+	}
+	PrintIDs(c, items, &PrintOptions{Pager: out})
+	return strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+}
+
+func (s *idsSuite) TestPrintIDs() {
+	tests := []struct {
+		name     string
+		args     []string
+		expected []string
+	}{
+		{"quiet prints one token per line", []string{"--" + FlagQuiet}, []string{"order-1", "'order 2'"}},
+		{"ids only prints the run ids too", []string{"--" + FlagIDsOnly}, []string{"order-1 run-1", "'order 2' run-2"}},
+		{"id fields", []string{"--" + FlagQuiet, "--" + FlagIDFields, "RunId,Type"}, []string{"run-1 Order", "run-2 Order"}},
+	}
+	for _, tt := range tests {
+		s.Equal(tt.expected, s.printIDs(tt.args...), tt.name)
+	}
+}
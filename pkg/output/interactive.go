@@ -0,0 +1,461 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/server/common/collection"
+
+	"github.com/temporalio/tctl/pkg/format"
+	"github.com/temporalio/tctl/pkg/pager"
+)
+
+const (
+	browserClear   = "\x1b[H\x1b[2J"
+	browserReverse = "\x1b[7m"
+	browserBold    = "\x1b[1m"
+	browserReset   = "\x1b[0m"
+)
+
+// browser is the full screen results browser of --interactive. Keys: up/down/j/k, PgUp/PgDn, g/G move the
+// selection, / filters the rows incrementally, left/right pick the column and s sorts by it (again to
+// reverse), Enter shows the selected item as a card, Esc clears the filter or closes the card, q quits
+type browser struct {
+	c     *cli.Context
+	iter  collection.Iterator
+	opts  *PrintOptions
+	keys  io.Reader
+	out   io.Writer
+	size  func() (int, int)
+	limit int
+
+	items   []interface{}
+	values  [][]interface{}
+	cells   [][]string
+	columns []Column
+	view    []int
+	loaded  bool
+
+	filter    string
+	filtering bool
+	column    int
+	sortBy    int
+	sortDesc  bool
+	cursor    int
+	top       int
+
+	card    []string
+	cardTop int
+}
+
+// interactive tells whether the items are browsed with --interactive, which needs a terminal
+func interactive(c *cli.Context) bool {
+	return c.Bool(FlagInteractive) && pager.IsTerminal(os.Stdout) && pager.IsTerminal(os.Stdin)
+}
+
+// Browse opens the results browser over the items of the iterator, fetching them as the selection moves
+func Browse(c *cli.Context, iter collection.Iterator, opts *PrintOptions) error {
+	restore, err := pager.MakeRaw(os.Stdin)
+	if err != nil {
+		return err
+	}
+	defer restore()
+
+	b := &browser{
+		c:      c,
+		iter:   iter,
+		opts:   opts,
+		keys:   os.Stdin,
+		out:    os.Stdout,
+		size:   func() (int, int) { return pager.TerminalSize(os.Stdout) },
+		limit:  c.Int(FlagLimit),
+		sortBy: -1,
+	}
+	return b.run()
+}
+
+func (b *browser) run() error {
+	if err := b.load(b.pageSize()); err != nil {
+		return err
+	}
+	if len(b.items) == 0 {
+		fmt.Fprintln(b.out, "no items")
+		return nil
+	}
+
+	buf := make([]byte, 16)
+	for {
+		b.draw()
+		n, err := b.keys.Read(buf)
+		if err != nil {
+			return nil
+		}
+		quit, err := b.handle(buf[:n])
+		if err != nil {
+			return err
+		}
+		if quit {
+			fmt.Fprint(b.out, browserClear)
+			return nil
+		}
+	}
+}
+
+// pageSize returns the number of rows in the list, the screen without the header and the status line
+func (b *browser) pageSize() int {
+	_, height := b.screen()
+	return height - 2
+}
+
+func (b *browser) screen() (int, int) {
+	width, height := b.size()
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 3 {
+		height = 25
+	}
+	return width, height
+}
+
+// load fetches the items until there are n of them, the iterator ends or --limit is reached. n < 0 loads all
+func (b *browser) load(n int) error {
+	var fetched []interface{}
+	for !b.loaded && (n < 0 || len(b.items)+len(fetched) < n) {
+		if !b.iter.HasNext() || b.limit > 0 && len(b.items)+len(fetched) >= b.limit {
+			b.loaded = true
+			break
+		}
+		item, err := b.iter.Next()
+		if err != nil {
+			return err
+		}
+		fetched = append(fetched, item)
+	}
+	if len(fetched) == 0 {
+		return nil
+	}
+
+	if b.columns == nil {
+		columns, err := resolveColumns(b.c, fetched, Table, b.opts)
+		if err != nil {
+			return err
+		}
+		b.columns = columns
+	}
+	values, err := extractFieldValues(b.c, fetched, columnFields(b.columns))
+	if err != nil {
+		return err
+	}
+	for i, row := range values {
+		cells := make([]string, len(row))
+		for j, value := range row {
+			cells[j] = pager.StripANSI(formatField(b.c, b.columns[j], value))
+		}
+		b.items = append(b.items, fetched[i])
+		b.values = append(b.values, row)
+		b.cells = append(b.cells, cells)
+	}
+	b.refresh()
+	return nil
+}
+
+// refresh recomputes the visible rows from the filter and the sort column
+func (b *browser) refresh() {
+	var selected = -1
+	if b.cursor < len(b.view) {
+		selected = b.view[b.cursor]
+	}
+
+	filter := strings.ToLower(b.filter)
+	b.view = b.view[:0]
+	for i, cells := range b.cells {
+		if filter == "" || strings.Contains(strings.ToLower(strings.Join(cells, " ")), filter) {
+			b.view = append(b.view, i)
+		}
+	}
+	if b.sortBy >= 0 {
+		now := format.Now(b.c)
+		sort.SliceStable(b.view, func(x, y int) bool {
+			cmp := compareValues(b.c, now, b.values[b.view[x]][b.sortBy], b.values[b.view[y]][b.sortBy])
+			if b.sortDesc {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+
+	b.cursor = 0
+	for i, idx := range b.view {
+		if idx == selected {
+			b.cursor = i
+		}
+	}
+	b.scroll(0)
+}
+
+// scroll moves the selection by delta rows, fetching the next items when it reaches the end
+func (b *browser) scroll(delta int) {
+	b.cursor += delta
+	if b.cursor >= len(b.view) && !b.loaded && b.filter == "" && b.sortBy < 0 {
+		_ = b.load(b.cursor + b.pageSize())
+	}
+	if b.cursor >= len(b.view) {
+		b.cursor = len(b.view) - 1
+	}
+	if b.cursor < 0 {
+		b.cursor = 0
+	}
+
+	page := b.pageSize()
+	if b.cursor < b.top {
+		b.top = b.cursor
+	}
+	if b.cursor >= b.top+page {
+		b.top = b.cursor - page + 1
+	}
+	if b.top > len(b.view)-page {
+		b.top = len(b.view) - page
+	}
+	if b.top < 0 {
+		b.top = 0
+	}
+}
+
+func (b *browser) handle(key []byte) (bool, error) {
+	if b.filtering {
+		return false, b.handleFilter(key)
+	}
+	if b.card != nil {
+		b.handleCard(key)
+		return false, nil
+	}
+
+	page := b.pageSize()
+	switch string(key) {
+	case "q", "Q", "\x03":
+		return true, nil
+	case "j", "\x1b[B":
+		b.scroll(1)
+	case "k", "\x1b[A":
+		b.scroll(-1)
+	case " ", "f", "\x1b[6~":
+		b.scroll(page)
+	case "b", "\x1b[5~":
+		b.scroll(-page)
+	case "g", "\x1b[H":
+		b.scroll(-b.cursor)
+	case "G", "\x1b[F":
+		if err := b.load(-1); err != nil {
+			return false, err
+		}
+		b.refresh()
+		b.scroll(len(b.view))
+	case "h", "\x1b[D":
+		if b.column > 0 {
+			b.column--
+		}
+	case "l", "\x1b[C":
+		if b.column < len(b.columns)-1 {
+			b.column++
+		}
+	case "s":
+		if err := b.load(-1); err != nil {
+			return false, err
+		}
+		if b.sortBy == b.column {
+			b.sortDesc = !b.sortDesc
+		} else {
+			b.sortBy, b.sortDesc = b.column, false
+		}
+		b.refresh()
+	case "/":
+		if err := b.load(-1); err != nil {
+			return false, err
+		}
+		b.filtering = true
+	case "\x1b":
+		b.filter = ""
+		b.refresh()
+	case "\r", "\n":
+		if len(b.view) > 0 {
+			b.card = b.cardLines(b.items[b.view[b.cursor]])
+			b.cardTop = 0
+		}
+	}
+	return false, nil
+}
+
+// handleFilter edits the filter, the rows are filtered as it is typed
+func (b *browser) handleFilter(key []byte) error {
+	switch string(key) {
+	case "\r", "\n":
+		b.filtering = false
+		return nil
+	case "\x1b", "\x03":
+		b.filtering = false
+		b.filter = ""
+	case "\x7f", "\x08":
+		if r := []rune(b.filter); len(r) > 0 {
+			b.filter = string(r[:len(r)-1])
+		}
+	default:
+		if key[0] >= ' ' {
+			b.filter += string(key)
+		}
+	}
+	b.refresh()
+	return nil
+}
+
+func (b *browser) handleCard(key []byte) {
+	_, height := b.screen()
+	switch string(key) {
+	case "q", "Q", "\x1b", "\r", "\n", "\x03":
+		b.card = nil
+	case "j", "\x1b[B":
+		b.cardTop++
+	case "k", "\x1b[A":
+		b.cardTop--
+	case " ", "f", "\x1b[6~":
+		b.cardTop += height - 1
+	case "b", "\x1b[5~":
+		b.cardTop -= height - 1
+	}
+	if b.cardTop > len(b.card)-(height-1) {
+		b.cardTop = len(b.card) - (height - 1)
+	}
+	if b.cardTop < 0 {
+		b.cardTop = 0
+	}
+}
+
+// cardLines renders all the fields of the item the way --output card does
+func (b *browser) cardLines(item interface{}) []string {
+	var buf bytes.Buffer
+	PrintCards(b.c, []interface{}{item}, &PrintOptions{Pager: &buf})
+	return strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+}
+
+func (b *browser) draw() {
+	width, height := b.screen()
+	var s strings.Builder
+	s.WriteString(browserClear)
+
+	if b.card != nil {
+		for i := b.cardTop; i < len(b.card) && i < b.cardTop+height-1; i++ {
+			s.WriteString(truncate(pager.StripANSI(b.card[i]), width) + "\n")
+		}
+		s.WriteString(browserReverse + truncate("Esc back  j/k scroll  q close", width) + browserReset)
+		fmt.Fprint(b.out, s.String())
+		return
+	}
+
+	widths := b.widths(width)
+	headers := make([]string, len(b.columns))
+	for j, col := range b.columns {
+		header := col.Header()
+		if j == b.sortBy {
+			header += map[bool]string{false: " ^", true: " v"}[b.sortDesc]
+		}
+		if j == b.column {
+			header = "[" + header + "]"
+		}
+		headers[j] = header
+	}
+	s.WriteString(browserBold + b.row(headers, widths, width) + browserReset + "\n")
+
+	page := b.pageSize()
+	for i := b.top; i < len(b.view) && i < b.top+page; i++ {
+		line := b.row(b.cells[b.view[i]], widths, width)
+		if i == b.cursor {
+			line = browserReverse + line + browserReset
+		}
+		s.WriteString(line + "\n")
+	}
+	for i := len(b.view) - b.top; i < page; i++ {
+		s.WriteString("~\n")
+	}
+
+	status := fmt.Sprintf("%d/%d", b.cursor+1, len(b.view))
+	if !b.loaded {
+		status += "+"
+	}
+	if b.filtering {
+		status = "/" + b.filter
+	} else if b.filter != "" {
+		status += "  filter: " + b.filter
+	}
+	if !b.filtering {
+		status += "  Enter details  / filter  ←/→ column  s sort  q quit"
+	}
+	s.WriteString(browserReverse + truncate(status, width) + browserReset)
+	fmt.Fprint(b.out, s.String())
+}
+
+// widths returns the widths of the columns, shrinking the widest ones to fit the screen
+func (b *browser) widths(width int) []int {
+	widths := make([]int, len(b.columns))
+	for j, col := range b.columns {
+		widths[j] = len([]rune(col.Header())) + 4
+	}
+	for _, cells := range b.cells {
+		for j, cell := range cells {
+			if w := len([]rune(cell)); w > widths[j] {
+				widths[j] = w
+			}
+		}
+	}
+
+	for {
+		total, widest := 0, 0
+		for j, w := range widths {
+			total += w + columnPadding
+			if w > widths[widest] {
+				widest = j
+			}
+		}
+		if total <= width || widths[widest] <= minColumnWidth {
+			return widths
+		}
+		widths[widest]--
+	}
+}
+
+func (b *browser) row(cells []string, widths []int, width int) string {
+	var s strings.Builder
+	for j, cell := range cells {
+		cell = strings.ReplaceAll(cell, "\n", " ")
+		s.WriteString(fmt.Sprintf("%-*s", widths[j], truncate(cell, widths[j])))
+		s.WriteString(strings.Repeat(" ", columnPadding))
+	}
+	return truncate(strings.TrimRight(s.String(), " "), width)
+}
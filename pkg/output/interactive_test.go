@@ -0,0 +1,103 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package output
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+)
+
+type interactiveSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestInteractiveSuite(t *testing.T) {
+	suite.Run(t, new(interactiveSuite))
+}
+
+func (s *interactiveSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+// scriptedKeys returns one key per read, then quits
+type scriptedKeys []string
+
+func (k *scriptedKeys) Read(b []byte) (int, error) {
+	if len(*k) == 0 {
+		return copy(b, "q"), nil
+	}
+	n := copy(b, (*k)[0])
+	*k = (*k)[1:]
+	return n, nil
+}
+
+type browsedItem struct {
+	ID    string
+	Count int
+}
+
+func (s *interactiveSuite) browse(keys ...string) *browser {
+	items := []interface{}{browsedItem{"a", 3}, browsedItem{"b", 1}, browsedItem{"ab", 2}}
+	script := scriptedKeys(keys)
+	b := &browser{
+		c:      cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", 0), nil),
+		iter:   &sliceIterator{items: items},
+		opts:   &PrintOptions{},
+		keys:   &script,
+		out:    &bytes.Buffer{},
+		size:   func() (int, int) { return 40, 10 },
+		sortBy: -1,
+	}
+	s.NoError(b.run())
+	return b
+}
+
+func (s *interactiveSuite) TestSort() {
+	b := s.browse("l", "s")
+	s.Equal([]int{1, 2, 0}, b.view)
+
+	b = s.browse("l", "s", "s")
+	s.Equal([]int{0, 2, 1}, b.view)
+}
+
+func (s *interactiveSuite) TestFilter() {
+	b := s.browse("/", "a", "\r")
+	s.Equal([]int{0, 2}, b.view)
+	s.Equal("a", b.filter)
+}
+
+func (s *interactiveSuite) TestCard() {
+	b := s.browse()
+	for _, key := range []string{"j", "\r"} {
+		_, err := b.handle([]byte(key))
+		s.NoError(err)
+	}
+	s.Contains(b.card, "ID \t\tb")
+}
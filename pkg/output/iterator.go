@@ -0,0 +1,165 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+)
+
+// PaginationFunc fetches a page of items for the given page token and returns the token of the next page
+type PaginationFunc func(pageToken []byte) ([]interface{}, []byte, error)
+
+// PagingIterator iterates over paged server results and keeps track of the position
+// after the last returned item, so that it can be resumed through a cursor
+type PagingIterator struct {
+	paginationFunc PaginationFunc
+	pageToken      []byte // token used to fetch the current page
+	nextPageToken  []byte
+	page           []interface{}
+	index          int // index of the next item in the current page
+	skip           int // items to skip in the first page when resuming from a cursor
+	loaded         bool
+	err            error
+}
+
+type cursor struct {
+	PageToken []byte `json:"pageToken,omitempty"`
+	Offset    int    `json:"offset,omitempty"`
+}
+
+// NewPagingIterator creates an iterator starting at the cursor passed in --start-cursor, if any
+func NewPagingIterator(c *cli.Context, paginationFunc PaginationFunc) *PagingIterator {
+	iter := &PagingIterator{paginationFunc: paginationFunc}
+
+	if c.IsSet(FlagStartCursor) {
+		cur, err := decodeCursor(c.String(FlagStartCursor))
+		if err != nil {
+			iter.err = fmt.Errorf("invalid cursor: %w", err)
+		}
+		iter.pageToken = cur.PageToken
+		iter.skip = cur.Offset
+	}
+
+	return iter
+}
+
+func (it *PagingIterator) HasNext() bool {
+	if it.err != nil {
+		return true
+	}
+	if !it.loaded {
+		it.fetch(it.pageToken)
+		return it.HasNext()
+	}
+	if it.index < len(it.page) {
+		return true
+	}
+	if len(it.nextPageToken) == 0 {
+		return false
+	}
+	it.fetch(it.nextPageToken)
+	return it.HasNext()
+}
+
+func (it *PagingIterator) Next() (interface{}, error) {
+	if !it.HasNext() {
+		return nil, fmt.Errorf("no more items")
+	}
+	if it.err != nil {
+		err := it.err
+		it.err = nil
+		it.page = nil
+		it.nextPageToken = nil
+		return nil, err
+	}
+
+	item := it.page[it.index]
+	it.index++
+	return item, nil
+}
+
+// Buffered returns the number of items left in the fetched page, that are returned without a server call
+func (it *PagingIterator) Buffered() int {
+	if !it.loaded || it.err != nil {
+		return 0
+	}
+	return len(it.page) - it.index
+}
+
+// Cursor returns an opaque cursor pointing to the position after the last returned item.
+// Empty cursor means that all items have been returned.
+func (it *PagingIterator) Cursor() string {
+	if !it.loaded {
+		return encodeCursor(cursor{PageToken: it.pageToken, Offset: it.skip})
+	}
+	if it.index < len(it.page) {
+		return encodeCursor(cursor{PageToken: it.pageToken, Offset: it.index})
+	}
+	if len(it.nextPageToken) == 0 {
+		return ""
+	}
+	return encodeCursor(cursor{PageToken: it.nextPageToken})
+}
+
+func (it *PagingIterator) fetch(pageToken []byte) {
+	page, nextPageToken, err := it.paginationFunc(pageToken)
+	it.loaded = true
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	it.pageToken = pageToken
+	it.nextPageToken = nextPageToken
+	it.page = page
+	it.index = 0
+	if it.skip > 0 {
+		// resuming from a cursor that points in the middle of the page
+		if it.skip > len(page) {
+			it.skip = len(page)
+		}
+		it.index = it.skip
+		it.skip = 0
+	}
+}
+
+func encodeCursor(cur cursor) string {
+	b, _ := json.Marshal(cur)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (cursor, error) {
+	var cur cursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return cur, err
+	}
+	err = json.Unmarshal(b, &cur)
+	return cur, err
+}
@@ -25,8 +25,10 @@
 package output
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/gogo/protobuf/proto"
 	"github.com/hokaccha/go-prettyjson"
@@ -37,7 +39,14 @@ import (
 )
 
 func PrintJSON(c *cli.Context, o interface{}, opts *PrintOptions) {
-	json, err := ParseToJSON(c, o, true)
+	o = annotate(c, o)
+	var json string
+	var err error
+	if opts.legacyJSON(c) {
+		json, err = legacyJSON(c, o)
+	} else {
+		json, err = ParseToJSON(c, o, true)
+	}
 
 	if err != nil {
 		fmt.Printf("Error when try to print pretty: %v\n", err)
@@ -48,39 +57,226 @@ func PrintJSON(c *cli.Context, o interface{}, opts *PrintOptions) {
 }
 
 func ParseToJSON(c *cli.Context, o interface{}, indent bool) (string, error) {
-	colorFlag := c.String(color.FlagColor)
-	enableColor := colorFlag == string(color.Auto) || colorFlag == string(color.Always)
-	var b []byte
-	var err error
+	b, err := marshalJSON(c, o, indent)
+	if err != nil {
+		return "", err
+	}
 
-	if enableColor {
-		encoder := prettyjson.NewFormatter()
+	if color.Enabled(c) {
+		formatter := prettyjson.NewFormatter()
+		formatter.KeyColor = color.New(c, color.RoleKey)
 		if !indent {
-			encoder.Indent = 0
-			encoder.Newline = ""
+			formatter.Indent = 0
+			formatter.Newline = ""
 		}
-		b, err = encoder.Marshal(o)
-	} else {
-		if pb, ok := o.(proto.Message); ok {
-			var encoder *codec.JSONPBEncoder
-			if indent {
-				encoder = codec.NewJSONPBIndentEncoder("  ")
-			} else {
-				encoder = codec.NewJSONPBEncoder()
+		if b, err = formatter.Format(b); err != nil {
+			return "", err
+		}
+	}
+
+	return string(b), nil
+}
+
+// marshalJSON encodes the object without colors. Proto messages, including the ones in lists and in the
+// --annotate envelope, are encoded with jsonpb so enums are names and timestamps RFC3339. JSON payloads,
+// such as the memo and search attribute values, are replaced by their decoded value. gRPC status codes
+// are encoded by name unless --codes-as-int is set
+func marshalJSON(c *cli.Context, o interface{}, indent bool) ([]byte, error) {
+	b, err := marshalCompactJSON(c, o)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	if err := decodePayloads(&out, b); err != nil {
+		return nil, err
+	}
+	if !indent {
+		return out.Bytes(), nil
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, out.Bytes(), "", "  "); err != nil {
+		return nil, err
+	}
+	return indented.Bytes(), nil
+}
+
+func marshalCompactJSON(c *cli.Context, o interface{}) ([]byte, error) {
+	if pb, ok := o.(proto.Message); ok {
+		if val := reflect.ValueOf(pb); val.Kind() == reflect.Ptr && val.IsNil() {
+			return []byte("null"), nil
+		}
+		return codec.NewJSONPBEncoder().Encode(pb)
+	}
+
+	if a, ok := o.(annotatedOutput); ok {
+		data, err := marshalCompactJSON(c, a.Data)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(struct {
+			Annotation Annotation      `json:"annotation"`
+			Data       json.RawMessage `json:"data"`
+		}{a.Annotation, data})
+	}
+
+	if val := reflect.ValueOf(o); val.Kind() == reflect.Slice && !val.IsNil() && val.Type().Elem().Kind() != reflect.Uint8 {
+		items := make([]json.RawMessage, val.Len())
+		for i := range items {
+			b, err := marshalCompactJSON(c, val.Index(i).Interface())
+			if err != nil {
+				return nil, err
 			}
-			b, err = encoder.Encode(pb)
-		} else {
-			if indent {
-				b, err = json.MarshalIndent(o, "", "  ")
-			} else {
-				b, err = json.Marshal(o)
+			items[i] = b
+		}
+		return json.Marshal(items)
+	}
+
+	if !c.Bool(FlagCodesAsInt) && hasCode(reflect.TypeOf(o)) {
+		return marshalCodeNames(c, reflect.ValueOf(o))
+	}
+	return json.Marshal(o)
+}
+
+// decodePayloads copies the JSON value, keeping the order of the keys, with the payloads encoded as
+// json/plain replaced by their data and the binary/null ones by null
+func decodePayloads(out *bytes.Buffer, raw json.RawMessage) error {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return nil
+	}
+
+	switch raw[0] {
+	case '{':
+		keys, values, err := decodeObject(raw)
+		if err != nil {
+			return err
+		}
+		if data, ok := payloadData(keys, values); ok {
+			out.Write(data)
+			return nil
+		}
+		out.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			k, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			out.Write(k)
+			out.WriteByte(':')
+			if err := decodePayloads(out, values[i]); err != nil {
+				return err
+			}
+		}
+		out.WriteByte('}')
+	case '[':
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			return err
+		}
+		out.WriteByte('[')
+		for i, item := range items {
+			if i > 0 {
+				out.WriteByte(',')
+			}
+			if err := decodePayloads(out, item); err != nil {
+				return err
 			}
 		}
+		out.WriteByte(']')
+	default:
+		out.Write(raw)
+	}
+	return nil
+}
+
+func decodeObject(raw json.RawMessage) ([]string, []json.RawMessage, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+
+	var keys []string
+	var values []json.RawMessage
+	for dec.More() {
+		t, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		keys = append(keys, t.(string))
+		values = append(values, value)
+	}
+	return keys, values, nil
+}
+
+// payloadData returns the decoded data of an object shaped as a jsonpb encoded payload
+func payloadData(keys []string, values []json.RawMessage) ([]byte, bool) {
+	var metadata map[string][]byte
+	var data []byte
+	for i, key := range keys {
+		var err error
+		switch key {
+		case "metadata":
+			err = json.Unmarshal(values[i], &metadata)
+		case "data":
+			err = json.Unmarshal(values[i], &data)
+		default:
+			return nil, false
+		}
+		if err != nil {
+			return nil, false
+		}
+	}
+
+	switch string(metadata["encoding"]) {
+	case "json/plain", "json/protobuf":
+		if json.Valid(data) {
+			return data, true
+		}
+	case "binary/null":
+		return []byte("null"), true
+	}
+	return nil, false
+}
+
+// marshalItemJSON encodes an item of the ndjson, sse and --output-exec outputs and of the --also json
+// sink on a single line, in the legacy shape with --legacy-json
+func marshalItemJSON(c *cli.Context, o interface{}, opts *PrintOptions) ([]byte, error) {
+	if !opts.legacyJSON(c) {
+		return marshalJSON(c, o, false)
 	}
+	if pb, ok := o.(proto.Message); ok {
+		return codec.NewJSONPBEncoder().Encode(pb)
+	}
+	return json.Marshal(o)
+}
 
+func (opts *PrintOptions) legacyJSON(c *cli.Context) bool {
+	return opts.LegacyJSON || !opts.IgnoreFlags && c.Bool(FlagLegacyJSON)
+}
+
+// legacyJSON encodes the object the way it was before jsonpb was used for lists: enums and gRPC status
+// codes as numbers and payloads base64 encoded
+func legacyJSON(c *cli.Context, o interface{}) (string, error) {
+	var b []byte
+	var err error
+	if color.Enabled(c) {
+		b, err = prettyjson.NewFormatter().Marshal(o)
+	} else if pb, ok := o.(proto.Message); ok {
+		b, err = codec.NewJSONPBIndentEncoder("  ").Encode(pb)
+	} else {
+		b, err = json.MarshalIndent(o, "", "  ")
+	}
 	if err != nil {
 		return "", err
 	}
-
 	return string(b), nil
 }
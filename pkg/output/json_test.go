@@ -0,0 +1,135 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+	commonpb "go.temporal.io/api/common/v1"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+	"go.temporal.io/server/common/payload"
+	"google.golang.org/grpc/codes"
+)
+
+type jsonSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestJSONSuite(t *testing.T) {
+	suite.Run(t, new(jsonSuite))
+}
+
+func (s *jsonSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *jsonSuite) TestMarshalJSON_ProtoList() {
+	items := []interface{}{
+		&workflowpb.WorkflowExecutionInfo{
+			Execution: &commonpb.WorkflowExecution{WorkflowId: "wid"},
+			Status:    enumspb.WORKFLOW_EXECUTION_STATUS_FAILED,
+			Memo:      &commonpb.Memo{Fields: map[string]*commonpb.Payload{"reason": payload.EncodeString("test")}},
+		},
+		(*workflowpb.WorkflowExecutionInfo)(nil),
+	}
+
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	b, err := marshalJSON(c, items, false)
+	s.NoError(err)
+	s.Equal(`[{"execution":{"workflowId":"wid"},"status":"Failed","memo":{"fields":{"reason":"test"}}},null]`, string(b))
+}
+
+func (s *jsonSuite) TestMarshalJSON_BinaryPayload() {
+	p := payload.EncodeBytes([]byte{1, 2})
+	c := cli.NewContext(cli.NewApp(), flag.NewFlagSet("test", flag.ContinueOnError), nil)
+	b, err := marshalJSON(c, p, false)
+	s.NoError(err)
+	s.Equal(`{"metadata":{"encoding":"YmluYXJ5L3BsYWlu"},"data":"AQI="}`, string(b))
+}
+
+func (s *jsonSuite) TestPrintNDJSON_LegacyJSON() {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	legacy := set.Bool(FlagLegacyJSON, false, "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+	items := []interface{}{&workflowpb.WorkflowExecutionInfo{
+		Execution: &commonpb.WorkflowExecution{WorkflowId: "wid"},
+		Memo:      &commonpb.Memo{Fields: map[string]*commonpb.Payload{"reason": payload.EncodeString("test")}},
+	}}
+
+	var out bytes.Buffer
+	PrintNDJSON(c, items, &PrintOptions{Pager: &out})
+	s.Equal(`{"execution":{"workflowId":"wid"},"memo":{"fields":{"reason":"test"}}}`+"\n", out.String())
+
+	*legacy = true
+	out.Reset()
+	PrintNDJSON(c, items, &PrintOptions{Pager: &out})
+	s.Equal(`{"execution":{"workflowId":"wid"},"memo":{"fields":{"reason":{"metadata":{"encoding":"anNvbi9wbGFpbg=="},"data":"InRlc3Qi"}}}}`+"\n", out.String())
+}
+
+type codeDetails struct {
+	Retried []codes.Code
+}
+
+type codeError struct {
+	Message string
+	Code    codes.Code `json:"code"`
+	Cause   *codeError `json:"cause,omitempty"`
+	codeDetails
+}
+
+func (s *jsonSuite) TestMarshalJSON_Codes() {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	asInt := set.Bool(FlagCodesAsInt, false, "")
+	c := cli.NewContext(cli.NewApp(), set, nil)
+	item := []interface{}{&codeError{
+		Message:     "not found",
+		Code:        codes.NotFound,
+		codeDetails: codeDetails{Retried: []codes.Code{codes.Unavailable}},
+	}}
+
+	b, err := marshalJSON(c, item, false)
+	s.NoError(err)
+	s.Equal(`[{"Message":"not found","code":"NotFound","Retried":["Unavailable"]}]`, string(b))
+
+	node, err := toYAMLNode(c, item[0])
+	s.NoError(err)
+	s.Equal("NotFound", node.Content[3].Value)
+
+	legacy, err := legacyJSON(c, item[0])
+	s.NoError(err)
+	s.JSONEq(`{"Message":"not found","code":5,"Retried":[14]}`, legacy)
+
+	*asInt = true
+	b, err = marshalJSON(c, item, false)
+	s.NoError(err)
+	s.Equal(`[{"Message":"not found","code":5,"Retried":[14]}]`, string(b))
+}
@@ -0,0 +1,98 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/format"
+)
+
+const (
+	mapSortKey   = "key"
+	mapSortValue = "value"
+)
+
+// formatMap renders a map as a JSON object with the entries ordered by --map-sort, ex. value:desc
+func formatMap(c *cli.Context, val reflect.Value) (string, error) {
+	by, desc, err := parseMapSort(c.String(FlagMapSort))
+	if err != nil {
+		return "", err
+	}
+
+	keys := val.MapKeys()
+	sort.SliceStable(keys, func(a, b int) bool {
+		var cmp int
+		if by == mapSortValue {
+			cmp = compareValues(c, format.Now(c), val.MapIndex(keys[a]).Interface(), val.MapIndex(keys[b]).Interface())
+		}
+		if cmp == 0 {
+			cmp = strings.Compare(fmt.Sprint(keys[a].Interface()), fmt.Sprint(keys[b].Interface()))
+		}
+		if desc {
+			return cmp > 0
+		}
+		return cmp < 0
+	})
+
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		k, err := json.Marshal(fmt.Sprint(key.Interface()))
+		if err != nil {
+			return "", err
+		}
+		v, err := marshalJSON(c, val.MapIndex(key).Interface(), false)
+		if err != nil {
+			return "", err
+		}
+		entries[i] = fmt.Sprintf("%s:%s", k, v)
+	}
+	return "{" + strings.Join(entries, ",") + "}", nil
+}
+
+func parseMapSort(expr string) (string, bool, error) {
+	by := expr
+	desc := false
+	if i := strings.Index(expr, ":"); i >= 0 {
+		by = expr[:i]
+		switch order := expr[i+1:]; order {
+		case sortAsc:
+		case sortDesc:
+			desc = true
+		default:
+			return "", false, fmt.Errorf("invalid map sort order %q, expected %v or %v", order, sortAsc, sortDesc)
+		}
+	}
+	if by != mapSortKey && by != mapSortValue {
+		return "", false, fmt.Errorf("invalid map sort %q, expected %v or %v", by, mapSortKey, mapSortValue)
+	}
+	return by, desc, nil
+}
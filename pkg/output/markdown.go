@@ -0,0 +1,74 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+// PrintMarkdown prints the items as a GitHub flavored Markdown table
+func PrintMarkdown(c *cli.Context, items []interface{}, opts *PrintOptions) {
+	columns := opts.getColumns(items)
+
+	if !opts.NoHeader {
+		headers := make([]string, len(columns))
+		separators := make([]string, len(columns))
+		for i, col := range columns {
+			headers[i] = markdownEscaper.Replace(col.Header())
+			separators[i] = "---"
+		}
+		printMarkdownRow(opts, headers)
+		printMarkdownRow(opts, separators)
+	}
+
+	widths, err := columnWidths(c, columns, opts)
+	if err != nil {
+		process.ErrorAndExit("unable to print markdown", err)
+	}
+
+	rows, err := extractFieldValues(c, items, columnFields(columns))
+	if err != nil {
+		process.ErrorAndExit("unable to print markdown", err)
+	}
+
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for j, value := range row {
+			cells[j] = markdownEscaper.Replace(truncateCell(strings.TrimSpace(formatField(c, columns[j], value)), widths[j]))
+		}
+		printMarkdownRow(opts, cells)
+	}
+}
+
+func printMarkdownRow(opts *PrintOptions, cells []string) {
+	fmt.Fprintf(opts.Pager, "| %s |\n", strings.Join(cells, " | "))
+}
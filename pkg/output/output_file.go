@@ -0,0 +1,148 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// outputFile is a file the output is written to. The output goes to a temp file next to it, renamed
+// over the file on close so readers never see a partial output. With --trailer-checksum it also computes
+// the SHA-256 and the line count of the written data and stores them next to the file on close
+type outputFile struct {
+	file   *os.File
+	path   string
+	hash   hash.Hash
+	lines  int
+	failed bool
+}
+
+// commandOutputFile is the --output-file of the command, shared by all the lists it prints
+var commandOutputFile *outputFile
+
+// invocationOutputFile opens the --output-file on the first list that the command prints. The following
+// lists are appended to it, and it is moved into place once when the command exits
+func invocationOutputFile(c *cli.Context) *outputFile {
+	if commandOutputFile != nil {
+		return commandOutputFile
+	}
+	file, err := createOutputFile(c, c.String(FlagOutputFile))
+	if err != nil {
+		process.ErrorAndExit("unable to create output file", err)
+	}
+	commandOutputFile = file
+	process.OnExit(func(failed bool) error {
+		commandOutputFile = nil
+		if failed {
+			return nil
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("unable to write output file: %w", err)
+		}
+		return nil
+	})
+	return file
+}
+
+func createOutputFile(c *cli.Context, path string) (*outputFile, error) {
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, err
+	}
+	if err := file.Chmod(mode); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return nil, err
+	}
+
+	f := &outputFile{file: file, path: path}
+	if c.Bool(FlagTrailerChecksum) {
+		f.hash = sha256.New()
+	}
+	// os.Exit skips the deferred closes of a command exiting with an error
+	process.OnExit(func(failed bool) error {
+		if failed {
+			f.discard()
+		}
+		return nil
+	})
+	return f, nil
+}
+
+func (f *outputFile) Write(p []byte) (int, error) {
+	n, err := f.file.Write(p)
+	if err != nil {
+		f.failed = true
+	}
+	if f.hash != nil {
+		f.hash.Write(p[:n])
+		f.lines += bytes.Count(p[:n], []byte("\n"))
+	}
+	return n, err
+}
+
+// discard removes the written output, leaving the file unchanged
+func (f *outputFile) discard() {
+	f.file.Close()
+	os.Remove(f.file.Name())
+}
+
+// Close moves the written output to the file and writes the <path>.sha256 sidecar, verifiable with `sha256sum -c`.
+// The output is discarded when a write failed
+func (f *outputFile) Close() error {
+	err := f.file.Close()
+	if f.failed || err != nil {
+		os.Remove(f.file.Name())
+		if err == nil {
+			err = fmt.Errorf("incomplete output discarded, %s is unchanged", f.path)
+		}
+		return err
+	}
+	if err := os.Rename(f.file.Name(), f.path); err != nil {
+		os.Remove(f.file.Name())
+		return err
+	}
+	if f.hash == nil {
+		return nil
+	}
+
+	trailer := fmt.Sprintf("%x  %s\n# lines: %d\n", f.hash.Sum(nil), filepath.Base(f.path), f.lines)
+	return ioutil.WriteFile(f.path+".sha256", []byte(trailer), 0644)
+}
@@ -0,0 +1,94 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package output
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+type outputFileSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestOutputFileSuite(t *testing.T) {
+	suite.Run(t, new(outputFileSuite))
+}
+
+func (s *outputFileSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+type outputFileRow struct {
+	Name string
+}
+
+func (s *outputFileSuite) context(args ...string) *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(FlagOutput, string(Table), "")
+	set.String(FlagOutputFile, "", "")
+	set.Bool(FlagTrailerChecksum, false, "")
+	s.NoError(set.Parse(args))
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func (s *outputFileSuite) TestOutputFile_AllLists() {
+	path := filepath.Join(s.T().TempDir(), "out.txt")
+	c := s.context("--"+FlagOutputFile, path)
+
+	PrintItems(c, []interface{}{outputFileRow{Name: "first"}}, &PrintOptions{Fields: []string{"Name"}})
+	PrintItems(c, []interface{}{outputFileRow{Name: "second"}}, &PrintOptions{Fields: []string{"Name"}})
+	s.NoFileExists(path, "moved into place when the command exits")
+
+	s.NoError(process.RunExitHooks(false))
+	data, err := ioutil.ReadFile(path)
+	s.NoError(err)
+	s.Contains(string(data), "first")
+	s.Contains(string(data), "second")
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), ".out.txt.*.tmp"))
+	s.NoError(err)
+	s.Empty(matches)
+}
+
+func (s *outputFileSuite) TestOutputFile_ErrorExit() {
+	path := filepath.Join(s.T().TempDir(), "out.csv")
+	c := s.context("--"+FlagOutput, string(CSV), "--"+FlagOutputFile, path, "--"+FlagTrailerChecksum)
+
+	PrintItems(c, []interface{}{outputFileRow{Name: "partial"}}, &PrintOptions{Fields: []string{"Name"}})
+	s.NoError(process.RunExitHooks(true), "the hooks run by a failed command")
+
+	matches, err := filepath.Glob(filepath.Join(filepath.Dir(path), "*"))
+	s.NoError(err)
+	s.Empty(matches, "the temp file is removed and no output or sidecar are written")
+}
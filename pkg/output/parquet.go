@@ -0,0 +1,153 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/parquet"
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// parquetSink writes items to a parquet file. The columns of each batch are added to the schema
+type parquetSink struct {
+	file   *outputFile
+	writer *parquet.Writer
+}
+
+// PrintParquet appends items as rows to the parquet file set by --output-file
+func PrintParquet(c *cli.Context, items []interface{}, opts *PrintOptions) {
+	if err := writeParquet(c, items, opts, c.String(FlagOutputFile)); err != nil {
+		process.ErrorAndExit("unable to print parquet", err)
+	}
+}
+
+func writeParquet(c *cli.Context, items []interface{}, opts *PrintOptions, path string) error {
+	columns := opts.getColumns(items)
+	rows, err := extractFieldValues(c, items, columnFields(columns))
+	if err != nil {
+		return err
+	}
+
+	if opts.parquet == nil {
+		sink, err := newParquetSink(c, path)
+		if err != nil {
+			return err
+		}
+		opts.parquet = sink
+	}
+
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = parquetColumnName(col)
+	}
+	// the columns are in the file even if no row is written, ex. for an empty result
+	opts.parquet.writer.AddColumns(names)
+	for _, row := range rows {
+		values := make([]interface{}, len(row))
+		for i, value := range row {
+			values[i] = toParquetValue(c, columns[i], value)
+		}
+		if err := opts.parquet.writer.Write(names, values); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func newParquetSink(c *cli.Context, path string) (*parquetSink, error) {
+	if path == "" {
+		return nil, fmt.Errorf("--%s is required for %v output", FlagOutputFile, Parquet)
+	}
+
+	file, err := createOutputFile(c, path)
+	if err != nil {
+		return nil, err
+	}
+	w, err := parquet.NewWriter(file)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &parquetSink{file: file, writer: w}, nil
+}
+
+// close writes the rows and the footer and closes the file
+func (s *parquetSink) close() error {
+	if err := s.writer.Close(); err != nil {
+		s.file.Close()
+		return err
+	}
+	return s.file.Close()
+}
+
+// abort drops the rows written so far
+func (s *parquetSink) abort() {
+	s.writer.Abort()
+	s.file.discard()
+}
+
+func parquetColumnName(col Column) string {
+	if col.Label != "" {
+		return col.Label
+	}
+	return strings.ReplaceAll(col.Field, ".", "_")
+}
+
+// toParquetValue converts the field value to one of the parquet writer value types
+func toParquetValue(c *cli.Context, col Column, value interface{}) interface{} {
+	val := reflect.Indirect(reflect.ValueOf(value))
+	if !val.IsValid() {
+		return nil
+	}
+	if t, ok := val.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return nil
+		}
+		return t
+	}
+	if _, ok := value.(fmt.Stringer); ok {
+		// enums and durations read better by name
+		return formatField(c, col, value)
+	}
+
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return int64(val.Uint())
+	case reflect.Float32, reflect.Float64:
+		return val.Float()
+	case reflect.Bool:
+		return val.Bool()
+	}
+	return formatField(c, col, value)
+}
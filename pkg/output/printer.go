@@ -27,12 +27,17 @@ package output
 import (
 	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
 	"reflect"
 	"strings"
+	"text/template"
 	"time"
 
+	"github.com/temporalio/tctl/pkg/color"
 	"github.com/temporalio/tctl/pkg/format"
 	"github.com/temporalio/tctl/pkg/pager"
+	"github.com/temporalio/tctl/pkg/process"
 	"github.com/urfave/cli/v2"
 	"go.temporal.io/server/common/collection"
 )
@@ -45,51 +50,138 @@ type PrintOptions struct {
 	Fields      []string
 	FieldsLong  []string
 	IgnoreFlags bool
-	Output      OutputOption
-	Pager       io.Writer
-	NoPager     bool
-	NoHeader    bool
-	Separator   string
+	// ItemTemplate is a zero value of the printed items, lets --headers-only print their shape without fetching them
+	ItemTemplate interface{}
+	Output       OutputOption
+	Pager        io.Writer
+	NoPager      bool
+	NoHeader     bool
+	Separator    string
+	SortBy       []string // fields to sort each printed batch, or all the items with --all, by. Overridden by --sort-by
+	SortDesc     bool
+	// MaxFieldLength truncates the table cells, ColumnWidths the cells of given fields. Overridden by
+	// --max-field-length and --column-width, lifted by --no-truncate
+	MaxFieldLength int
+	ColumnWidths   map[string]int
+	// ColumnPriority ranks the columns to shrink or drop first when the table is wider than the terminal,
+	// lowest first. FieldsLong columns default to -1, the others to 0
+	ColumnPriority map[string]int
+	// LegacyJSON prints json output with enums as numbers and raw payloads, as set by --legacy-json
+	LegacyJSON bool
+
+	columns   []Column           // resolved from Fields and --fields flag
+	parquet   *parquetSink       // opened on the first batch of parquet output
+	template  *template.Template // parsed from --output go-template=... on the first batch
+	layout    []int              // table columns fitted to the terminal on the first batch
+	also      []*alsoSink        // secondary outputs set with --also
+	streaming bool               // set by Pager, which closes the file outputs after the last batch
+	sortedAll bool               // set by Pager once all the items are sorted, so that the batches are not sorted again
 }
 
 func PrintItems(c *cli.Context, items []interface{}, opts *PrintOptions) {
 	outputFlag := c.String(FlagOutput)
-	fields := c.String(FlagFields)
 
 	if opts.Pager == nil {
-		pager, close := newPagerWithDefault(c)
+		pager, close := newPagerWithOptions(c, opts)
 		opts.Pager = pager
 		defer close()
 	}
 
-	if !opts.IgnoreFlags && c.IsSet(FlagFields) {
-		if fields == FieldsLong {
-			opts.Fields = append(opts.Fields, opts.FieldsLong...)
-			opts.FieldsLong = []string{}
-		} else {
-			f := strings.Split(fields, ",")
-			for i := range f {
-				f[i] = strings.TrimSpace(f[i])
-			}
-			opts.Fields = f
-			opts.FieldsLong = []string{}
-		}
-	}
-
 	output := Table
 	if !opts.IgnoreFlags && c.IsSet(FlagOutput) {
-		output = OutputOption(outputFlag)
+		output, _ = parseOutputOption(outputFlag)
 	} else if opts.Output != "" {
 		output = opts.Output
 	}
 
+	if opts.columns == nil {
+		columns, err := resolveColumns(c, items, output, opts)
+		if err != nil {
+			process.ErrorAndExit("unable to print items", err)
+		}
+		opts.columns = columns
+	}
+
+	if !opts.IgnoreFlags && c.Bool(FlagNoHeader) {
+		opts.NoHeader = true
+	}
+
+	if err := sortBatch(c, items, opts); err != nil {
+		process.ErrorAndExit("unable to sort items", err)
+	}
+
+	if !opts.IgnoreFlags && c.Bool(FlagHeadersOnly) {
+		sample := opts.ItemTemplate
+		if len(items) > 0 {
+			sample = items[0]
+		}
+		PrintHeaders(c, sample, output, opts)
+		return
+	}
+
+	if !opts.IgnoreFlags && c.Bool(FlagProfileFields) {
+		PrintFieldsProfile(c, items, opts)
+		return
+	}
+
+	if !opts.IgnoreFlags && c.Bool(FlagSSE) {
+		PrintSSE(c, items, opts)
+		return
+	}
+
+	if !opts.IgnoreFlags && idsOnly(c) {
+		PrintIDs(c, items, opts)
+		return
+	}
+
+	if !opts.IgnoreFlags && c.IsSet(FlagSelect) {
+		PrintSelected(c, items, opts)
+		return
+	}
+
+	if !opts.IgnoreFlags && c.IsSet(FlagOutputExec) {
+		PrintExec(c, items, opts)
+		return
+	}
+
+	if !opts.streaming {
+		defer func() {
+			if err := opts.close(c); err != nil {
+				process.ErrorAndExit("unable to print items", err)
+			}
+		}()
+	}
+
+	if !opts.IgnoreFlags && opts.also == nil && c.IsSet(FlagAlso) {
+		sinks, err := parseAlsoSinks(c)
+		if err != nil {
+			process.ErrorAndExit("unable to print items", err)
+		}
+		opts.also = sinks
+	}
+	opts.printAlso(c, items)
+
 	switch output {
-	case Table:
+	case Table, Wide:
 		PrintTable(c, items, opts)
 	case JSON:
 		PrintJSON(c, items, opts)
 	case Card:
 		PrintCards(c, items, opts)
+	case YAML:
+		PrintYAML(c, items, opts)
+	case CSV:
+		PrintCSV(c, items, opts, ',')
+	case TSV:
+		PrintCSV(c, items, opts, '\t')
+	case NDJSON:
+		PrintNDJSON(c, items, opts)
+	case Markdown:
+		PrintMarkdown(c, items, opts)
+	case GoTemplate:
+		PrintTemplate(c, items, opts)
+	case Parquet:
+		PrintParquet(c, items, opts)
 	default:
 	}
 }
@@ -98,48 +190,305 @@ func PrintItems(c *cli.Context, items []interface{}, opts *PrintOptions) {
 func Pager(c *cli.Context, iter collection.Iterator, opts *PrintOptions) error {
 	limit := c.Int(FlagLimit)
 
-	pager, close := newPagerWithDefault(c)
-	defer close()
-
 	if opts == nil {
 		opts = &PrintOptions{}
 	}
+	if !opts.IgnoreFlags && interactive(c) {
+		return Browse(c, iter, opts)
+	}
+
+	pager, close := newPagerWithOptions(c, opts)
+	defer close()
+
 	opts.Pager = pager
+	opts.streaming = true
+
+	if c.Bool(FlagProfileFields) {
+		return profileSample(c, iter, opts)
+	}
+
+	if c.Bool(FlagHeadersOnly) {
+		PrintItems(c, nil, opts)
+		return nil
+	}
+
+	// the iterators wrapping the former ones, closed once printed
+	var wrappers []io.Closer
+	defer func() {
+		for _, wrapper := range wrappers {
+			wrapper.Close()
+		}
+	}()
+
+	if c.IsSet(FlagCache) && !c.Bool(FlagNoCache) {
+		if c.Bool(FlagPrintCursor) || c.IsSet(FlagCursorFile) {
+			// the cached items are not read at a cursor of the server
+			return fmt.Errorf("--%s can't be combined with --%s or --%s", FlagCache, FlagPrintCursor, FlagCursorFile)
+		}
+		cached, err := cacheItems(c, iter, opts)
+		if err != nil {
+			return err
+		}
+		if closer, ok := cached.(io.Closer); ok && cached != iter {
+			wrappers = append(wrappers, closer)
+		}
+		iter = cached
+	}
+
+	keys, err := globalSortKeys(c, opts)
+	if err != nil {
+		return err
+	}
+	if len(keys) > 0 {
+		sorted, err := sortItems(c, iter, keys)
+		if err != nil {
+			return err
+		}
+		if closer, ok := sorted.(io.Closer); ok {
+			wrappers = append(wrappers, closer)
+		}
+		iter = sorted
+		opts.sortedAll = true
+	}
+
+	var dedup *deduplicator
+	if c.IsSet(FlagDedup) {
+		dedup = newDeduplicator(c.String(FlagDedup))
+	}
+
+	batchSize := BatchPrintSize
+	if isStreamed(c) {
+		// lines don't need consistent formatting, print each item as soon as it is fetched
+		batchSize = 1
+	}
 
 	itemsPrinted := 0
 	var batch []interface{}
 	for iter.HasNext() {
+		if c.IsSet(FlagLimit) && itemsPrinted >= limit {
+			break
+		}
+
 		item, err := iter.Next()
 		if err != nil {
 			return err
 		}
 
-		if c.IsSet(FlagLimit) && itemsPrinted >= limit {
-			break
+		if dedup != nil {
+			if dup, err := dedup.seen(c, item); err != nil {
+				return err
+			} else if dup {
+				continue
+			}
 		}
 
 		batch = append(batch, item)
 		itemsPrinted++
 
-		isLastBatch := limit-itemsPrinted < BatchPrintSize
-		isBatchFilled := (len(batch) == BatchPrintSize) || (isLastBatch && len(batch) == limit%BatchPrintSize)
+		isLastBatch := limit-itemsPrinted < batchSize
+		isBatchFilled := (len(batch) == batchSize) || (isLastBatch && len(batch) == limit%batchSize)
 
-		if isBatchFilled || !iter.HasNext() {
+		// the fetched items are printed before waiting for the next page, so the first page shows up immediately
+		if isBatchFilled || !buffered(iter) || !iter.HasNext() {
 			PrintItems(c, batch, opts)
 			batch = batch[:0]
 			opts.NoHeader = true
 		}
 	}
+	if len(batch) > 0 {
+		// the remaining items were followed by skipped duplicates
+		PrintItems(c, batch, opts)
+	}
+
+	if err := opts.close(c); err != nil {
+		return err
+	}
+	return printCursor(c, iter)
+}
+
+// buffered tells whether the iterator returns its next item, if any, without a server call
+func buffered(iter collection.Iterator) bool {
+	if b, ok := iter.(interface{ Buffered() int }); ok {
+		return b.Buffered() > 0
+	}
+	return true
+}
+
+// isStreamed tells whether the items are printed one by one rather than in batches: always for ndjson,
+// and for the other line based outputs when stdout is piped or redirected
+func isStreamed(c *cli.Context) bool {
+	switch output, _ := parseOutputOption(c.String(FlagOutput)); output {
+	case NDJSON:
+		return true
+	case CSV, TSV, GoTemplate:
+		return !pager.IsTerminal(os.Stdout)
+	}
+	return false
+}
+
+// profileSample profiles the fields over the first items of the result set
+func profileSample(c *cli.Context, iter collection.Iterator, opts *PrintOptions) error {
+	size := profileSampleSize
+	if c.IsSet(FlagLimit) {
+		size = c.Int(FlagLimit)
+	}
+
+	var sample []interface{}
+	for len(sample) < size && iter.HasNext() {
+		item, err := iter.Next()
+		if err != nil {
+			return err
+		}
+		sample = append(sample, item)
+	}
 
+	PrintFieldsProfile(c, sample, opts)
 	return nil
 }
 
+// close flushes and closes the file outputs opened while printing
+func (opts *PrintOptions) close(c *cli.Context) error {
+	for _, sink := range opts.also {
+		sink.close(c)
+	}
+
+	if opts.parquet == nil {
+		return nil
+	}
+	err := opts.parquet.close()
+	opts.parquet = nil
+	return err
+}
+
+type cursorIterator interface {
+	Cursor() string
+}
+
+// printCursor outputs the position after the last printed item so that printing can be resumed with --start-cursor
+func printCursor(c *cli.Context, iter collection.Iterator) error {
+	if !c.Bool(FlagPrintCursor) && !c.IsSet(FlagCursorFile) {
+		return nil
+	}
+
+	cIter, ok := iter.(cursorIterator)
+	if !ok {
+		return fmt.Errorf("cursor is not supported by this command")
+	}
+	cursor := cIter.Cursor()
+
+	if c.IsSet(FlagCursorFile) {
+		return ioutil.WriteFile(c.String(FlagCursorFile), []byte(cursor), 0644)
+	}
+	if cursor != "" {
+		fmt.Fprintln(os.Stderr, cursor)
+	}
+	return nil
+}
+
+func resolveColumns(c *cli.Context, items []interface{}, output OutputOption, opts *PrintOptions) ([]Column, error) {
+	var known []string
+	if len(items) > 0 {
+		known = extractFieldNames(items[0], []string{}, "", fieldsDepth)
+	} else if opts.ItemTemplate != nil {
+		known = extractTypeFieldNames(reflect.TypeOf(opts.ItemTemplate), []string{}, "", fieldsDepth)
+	}
+
+	defaults := opts.Fields
+	if len(defaults) == 0 {
+		defaults = known
+	}
+
+	var expr string
+	if !opts.IgnoreFlags && c.IsSet(FlagFields) {
+		expr = c.String(FlagFields)
+	}
+	if output == Card && expr == "" && !opts.IgnoreFlags {
+		expr = cardLayout(items, opts)
+	}
+	if output == Wide {
+		// same as adding --fields @long
+		expr += ",+" + presetPrefix + PresetLong
+	}
+	if expr == "" {
+		return columnsFromFields(defaults), nil
+	}
+
+	spec, err := ParseFields(expr)
+	if err != nil {
+		return nil, err
+	}
+	return spec.Resolve(defaults, opts.FieldsLong, known)
+}
+
+// getColumns returns the columns to print, falling back to Fields when PrintItems was not used
+func (opts *PrintOptions) getColumns(items []interface{}) []Column {
+	if opts.columns != nil {
+		return opts.columns
+	}
+	if len(opts.Fields) == 0 && len(items) > 0 {
+		return columnsFromFields(extractFieldNames(items[0], []string{}, "", fieldsDepth))
+	}
+	return columnsFromFields(opts.Fields)
+}
+
 func newPagerWithDefault(c *cli.Context) (io.Writer, func()) {
-	outputFlag := c.String(FlagOutput)
-	output := OutputOption(outputFlag)
+	return newWriter(c, true)
+}
+
+// newPagerWithOptions skips the interactive pager when the command sets NoPager, the other outputs still apply
+func newPagerWithOptions(c *cli.Context, opts *PrintOptions) (io.Writer, func()) {
+	return newWriter(c, !opts.NoPager)
+}
+
+// newWriter returns the writer of the items: a sink set by the flags, the pager when paged, or stdout
+func newWriter(c *cli.Context, paged bool) (io.Writer, func()) {
+	if c.Bool(FlagSSE) {
+		// events are streamed as they come, regardless of the terminal
+		return os.Stdout, func() {}
+	}
+
+	if c.IsSet(FlagOutputExec) {
+		return newExecWriter(c.String(FlagOutputExec))
+	}
+
+	if c.IsSet(FlagWatch) {
+		// repainted on every run, a pager would wait for the user
+		return os.Stdout, func() {}
+	}
+
+	if idsOnly(c) || c.IsSet(FlagSelect) {
+		// ids and selected values are meant to be piped
+		return os.Stdout, func() {}
+	}
+
+	output, _ := parseOutputOption(c.String(FlagOutput))
+
+	if output == Parquet {
+		// parquet is written to --output-file, nothing to page
+		return os.Stdout, func() {}
+	}
+
+	if c.Bool(FlagTrailerChecksum) && !c.IsSet(FlagOutputFile) {
+		process.ErrorAndExit("unable to print items", fmt.Errorf("--%s requires --%s", FlagTrailerChecksum, FlagOutputFile))
+	}
+
+	if output == CSV || output == TSV || output == Markdown {
+		// separated values and markdown are meant for other tools
+		disableAutoColor(c)
+	}
+
+	if c.IsSet(FlagOutputFile) {
+		// files are not colored unless asked for explicitly
+		disableAutoColor(c)
+		return invocationOutputFile(c), func() {}
+	}
+
+	if !paged {
+		return os.Stdout, func() {}
+	}
 
 	var defaultPager string
-	if output == Table {
+	if output == Table || output == Wide {
 		defaultPager = string(pager.Less)
 	} else {
 		defaultPager = string(pager.More)
@@ -147,7 +496,14 @@ func newPagerWithDefault(c *cli.Context) (io.Writer, func()) {
 	return pager.NewPager(c, defaultPager)
 }
 
-func formatField(c *cli.Context, i interface{}) string {
+// disableAutoColor turns colors off unless they were asked for with --color
+func disableAutoColor(c *cli.Context) {
+	if !c.IsSet(color.FlagColor) {
+		_ = c.Set(color.FlagColor, string(color.Never))
+	}
+}
+
+func formatField(c *cli.Context, col Column, i interface{}) string {
 	val := reflect.ValueOf(i)
 	val = reflect.Indirect(val)
 
@@ -157,9 +513,39 @@ func formatField(c *cli.Context, i interface{}) string {
 	}
 	kin := val.Kind()
 
+	if formatter, ok := formatters[reflect.TypeOf(i)]; ok && val.IsValid() {
+		if str, ok := formatter(c, i); ok {
+			return str
+		}
+	}
+
+	if !val.IsValid() && reflect.TypeOf(i) == reflect.TypeOf((*time.Time)(nil)) {
+		// a time that is not set yet, ex. the start time of a scheduled task
+		return ""
+	}
+
+	if isSizeField(c, col, val) {
+		return formatSize(val)
+	}
+
+	if kin == reflect.Map && c.IsSet(FlagMapSort) {
+		str, err := formatMap(c, val)
+		if err != nil {
+			process.ErrorAndExit("unable to format map", err)
+		}
+		return str
+	}
+
+	if kin == reflect.Slice && val.Type().Elem().Kind() != reflect.Uint8 && !isExpanded(c, col) {
+		return formatCount(val.Len())
+	}
+
 	if typ == reflect.TypeOf(time.Time{}) {
+		if d := timeDirective(col); d != "" {
+			return format.FormatTimeAs(c, val.Interface().(time.Time), d)
+		}
 		return format.FormatTime(c, val.Interface().(time.Time))
-	} else if kin == reflect.Struct && val.CanInterface() {
+	} else if (kin == reflect.Struct || kin == reflect.Slice && hasStructElems(val)) && val.CanInterface() {
 		str, _ := ParseToJSON(c, i, false)
 
 		return str
@@ -167,3 +553,42 @@ func formatField(c *cli.Context, i interface{}) string {
 		return fmt.Sprintf("%v", i)
 	}
 }
+
+// isExpanded reports whether the column is listed in --expand and should be rendered fully
+func isExpanded(c *cli.Context, col Column) bool {
+	if !c.IsSet(FlagExpand) {
+		return false
+	}
+	for _, field := range strings.Split(c.String(FlagExpand), ",") {
+		field = strings.TrimSpace(field)
+		if field == col.Field || field == col.Header() {
+			return true
+		}
+	}
+	return false
+}
+
+func hasStructElems(val reflect.Value) bool {
+	elem := val.Type().Elem()
+	if elem.Kind() == reflect.Ptr {
+		elem = elem.Elem()
+	}
+	return elem.Kind() == reflect.Struct
+}
+
+func formatCount(n int) string {
+	if n == 1 {
+		return "1 item"
+	}
+	return fmt.Sprintf("%d items", n)
+}
+
+func timeDirective(col Column) format.FormatTimeOption {
+	for _, d := range col.Directives {
+		switch opt := format.FormatTimeOption(d); opt {
+		case format.Relative, format.ISO, format.Raw:
+			return opt
+		}
+	}
+	return ""
+}
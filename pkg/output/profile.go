@@ -0,0 +1,108 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+const (
+	profileSampleSize = 100
+	profileSampleLen  = 40
+)
+
+type fieldProfile struct {
+	Field     string
+	Populated string
+	Sample    string
+}
+
+// PrintFieldsProfile prints, per field, the share of items with a non-empty value and a sample value
+func PrintFieldsProfile(c *cli.Context, items []interface{}, opts *PrintOptions) {
+	if len(items) == 0 {
+		return
+	}
+
+	fields := extractFieldNames(items[0], []string{}, "", fieldsDepth)
+	rows, err := extractFieldValues(c, items, fields)
+	if err != nil {
+		process.ErrorAndExit("unable to profile fields", err)
+	}
+
+	var profiles []interface{}
+	for j, field := range fields {
+		populated := 0
+		sample := ""
+		for _, row := range rows {
+			if isEmptyValue(row[j]) {
+				continue
+			}
+			if populated == 0 {
+				sample = truncate(formatField(c, Column{Field: field}, row[j]), profileSampleLen)
+			}
+			populated++
+		}
+
+		profiles = append(profiles, fieldProfile{
+			Field:     field,
+			Populated: fmt.Sprintf("%d%%", populated*100/len(rows)),
+			Sample:    sample,
+		})
+	}
+
+	PrintItems(c, profiles, &PrintOptions{
+		Fields:      []string{"Field", "Populated", "Sample"},
+		IgnoreFlags: true,
+		Pager:       opts.Pager,
+	})
+}
+
+func isEmptyValue(i interface{}) bool {
+	val := reflect.Indirect(reflect.ValueOf(i))
+	if !val.IsValid() {
+		return true
+	}
+	switch val.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return val.Len() == 0
+	}
+	return val.IsZero()
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	if n <= 3 {
+		return string(runes[:n])
+	}
+	return string(runes[:n-3]) + "..."
+}
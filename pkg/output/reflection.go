@@ -27,14 +27,17 @@ package output
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
+
+	"github.com/urfave/cli/v2"
 )
 
 const (
 	fieldsDepth = 2 // depth of the nested fields to examine
 )
 
-func extractFieldValues(objs []interface{}, fields []string) ([][]interface{}, error) {
+func extractFieldValues(c *cli.Context, objs []interface{}, fields []string) ([][]interface{}, error) {
 	if len(objs) == 0 {
 		return [][]interface{}{}, nil
 	}
@@ -45,30 +48,117 @@ func extractFieldValues(objs []interface{}, fields []string) ([][]interface{}, e
 		fields = knownFields
 	}
 
-	if err := validateFields(knownFields, fields); err != nil {
+	var unresolved []string
+	for _, field := range fields {
+		if _, ok := ResolveField(c, objs[0], field); !ok {
+			unresolved = append(unresolved, field)
+		}
+	}
+	if err := validateFields(knownFields, unresolved); err != nil {
 		return nil, err
 	}
 
 	var result = make([][]interface{}, len(objs))
 	for i, item := range objs {
 		result[i] = make([]interface{}, len(fields))
-		val := reflect.ValueOf(item)
 		for j, field := range fields {
-			nestedFields := splitFieldPath(field)
-			var col interface{}
-			for _, nField := range nestedFields {
-				val = reflect.Indirect(val)
-				val = val.FieldByName(nField)
-				col = val.Interface()
-				val = reflect.ValueOf(col)
+			result[i][j], _ = ResolveField(c, item, field)
+		}
+	}
+
+	return result, nil
+}
+
+// ResolveField returns the value of a field of the item. The field is either a virtual field such as __status
+// or a dot separated path of struct fields, getter methods, map keys and slice indexes, ex. Execution.WorkflowId
+// or PendingActivities.0.ActivityType. Keys of structs wrapping a single map, such as SearchAttributes and Memo,
+// are looked up directly, ex. SearchAttributes.CustomKeywordField.
+// A path going through a nil pointer, a missing map key or an index out of range resolves to nil
+func ResolveField(c *cli.Context, item interface{}, field string) (interface{}, bool) {
+	if virtual, ok := virtualFields[field]; ok {
+		return virtual(c, item), true
+	}
+
+	val := reflect.ValueOf(item)
+	for _, name := range splitFieldPath(field) {
+		for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+			if val.IsNil() {
+				return nil, true
 			}
-			result[i][j] = col
+			val = val.Elem()
+		}
 
-			val = reflect.ValueOf(item)
+		switch val.Kind() {
+		case reflect.Struct:
+			if f := val.FieldByName(name); f.IsValid() && f.CanInterface() {
+				val = f
+			} else if m := getterByName(val, name); m.IsValid() {
+				val = m.Call(nil)[0]
+			} else if m := wrappedMap(val); m.IsValid() {
+				val = m.MapIndex(reflect.ValueOf(name).Convert(m.Type().Key()))
+				if !val.IsValid() {
+					return nil, true
+				}
+			} else {
+				return nil, false
+			}
+		case reflect.Map:
+			if val.Type().Key().Kind() != reflect.String {
+				return nil, false
+			}
+			val = val.MapIndex(reflect.ValueOf(name).Convert(val.Type().Key()))
+			if !val.IsValid() {
+				return nil, true
+			}
+		case reflect.Slice, reflect.Array:
+			index, err := strconv.Atoi(name)
+			if err != nil {
+				return nil, false
+			}
+			if index < 0 || index >= val.Len() {
+				return nil, true
+			}
+			val = val.Index(index)
+		default:
+			return nil, false
 		}
 	}
 
-	return result, nil
+	if !val.IsValid() || !val.CanInterface() {
+		return nil, false
+	}
+	return val.Interface(), true
+}
+
+// wrappedMap returns the map of a struct whose only exported field is a map with string keys,
+// ex. the IndexedFields of SearchAttributes
+func wrappedMap(val reflect.Value) reflect.Value {
+	var m reflect.Value
+	for i := 0; i < val.NumField(); i++ {
+		if !isFieldExported(val.Type().Field(i)) {
+			continue
+		}
+		if m.IsValid() {
+			return reflect.Value{}
+		}
+		m = val.Field(i)
+	}
+	if !m.IsValid() || m.Kind() != reflect.Map || m.Type().Key().Kind() != reflect.String {
+		return reflect.Value{}
+	}
+	return m
+}
+
+// getterByName finds an exported method that takes no arguments and returns one value
+func getterByName(val reflect.Value, name string) reflect.Value {
+	m := val.MethodByName(name)
+	if !m.IsValid() && val.CanAddr() {
+		m = val.Addr().MethodByName(name)
+	}
+	if !m.IsValid() || m.Type().NumIn() != 0 || m.Type().NumOut() != 1 {
+		return reflect.Value{}
+	}
+	return m
 }
 
 func extractFieldNames(obj interface{}, fieldNames []string, parentField string, depth int) []string {
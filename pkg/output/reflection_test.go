@@ -0,0 +1,115 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	enumspb "go.temporal.io/api/enums/v1"
+	workflowpb "go.temporal.io/api/workflow/v1"
+)
+
+type reflectionSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestReflectionSuite(t *testing.T) {
+	suite.Run(t, new(reflectionSuite))
+}
+
+func (s *reflectionSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+type resolveParent struct {
+	Name string
+}
+
+type resolveItem struct {
+	Parent  *resolveParent
+	Counts  map[string]int
+	Parents []*resolveParent
+	Wrapped *resolveWrapper
+}
+
+type resolveWrapper struct {
+	Values map[string]string
+}
+
+func (i *resolveItem) GetTotal() int {
+	total := 0
+	for _, v := range i.Counts {
+		total += v
+	}
+	return total
+}
+
+func (s *reflectionSuite) TestResolveField() {
+	item := &resolveItem{
+		Parent:  &resolveParent{Name: "p"},
+		Counts:  map[string]int{"a": 1, "b": 2},
+		Parents: []*resolveParent{{Name: "p0"}, {Name: "p1"}},
+		Wrapped: &resolveWrapper{Values: map[string]string{"k": "v"}},
+	}
+
+	tests := []struct {
+		field string
+		value interface{}
+		ok    bool
+	}{
+		{"Parent.Name", "p", true},
+		{"Counts.b", 2, true},
+		{"Counts.c", nil, true},
+		{"GetTotal", 3, true},
+		{"Parent.Unknown", nil, false},
+		{"Parents.1.Name", "p1", true},
+		{"Parents.2.Name", nil, true},
+		{"Parents.x", nil, false},
+		{"Wrapped.k", "v", true},
+		{"Wrapped.Values.k", "v", true},
+		{"Wrapped.missing", nil, true},
+	}
+	for _, tt := range tests {
+		value, ok := ResolveField(nil, item, tt.field)
+		s.Equal(tt.ok, ok, tt.field)
+		s.Equal(tt.value, value, tt.field)
+	}
+}
+
+func (s *reflectionSuite) TestResolveField_NilParent() {
+	value, ok := ResolveField(nil, resolveItem{}, "Parent.Name")
+	s.True(ok)
+	s.Nil(value)
+}
+
+func (s *reflectionSuite) TestResolveField_Virtual() {
+	item := &workflowpb.WorkflowExecutionInfo{Status: enumspb.WORKFLOW_EXECUTION_STATUS_FAILED}
+	value, ok := ResolveField(nil, item, FieldStatus)
+	s.True(ok)
+	s.Equal(statusCell{status: enumspb.WORKFLOW_EXECUTION_STATUS_FAILED}, value)
+}
@@ -0,0 +1,48 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"strings"
+
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/server/common/primitives/timestamp"
+)
+
+// formatRetryPolicy renders retry policy as ex. "max=5 init=1s backoff=2.0 max-interval=1m40s"
+func formatRetryPolicy(rp *commonpb.RetryPolicy) string {
+	parts := []string{
+		fmt.Sprintf("max=%d", rp.GetMaximumAttempts()),
+		fmt.Sprintf("init=%v", timestamp.DurationValue(rp.GetInitialInterval())),
+		fmt.Sprintf("backoff=%.1f", rp.GetBackoffCoefficient()),
+		fmt.Sprintf("max-interval=%v", timestamp.DurationValue(rp.GetMaximumInterval())),
+	}
+	if len(rp.GetNonRetryableErrorTypes()) > 0 {
+		parts = append(parts, fmt.Sprintf("non-retryable=%v", strings.Join(rp.GetNonRetryableErrorTypes(), ",")))
+	}
+
+	return strings.Join(parts, " ")
+}
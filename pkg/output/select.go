@@ -0,0 +1,244 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// Select expression grammar, a jq-like path applied to each item:
+//   .                        the item itself
+//   .Execution.WorkflowId    struct field, getter method or map key
+//   .Memo.Fields["a.b"]      map key containing dots
+//   .PendingActivities[]     every element of a list, or every value of a map
+//   .PendingActivities[0]    element of a list by index, negative indexes count from the end
+// Each selected value is printed on its own line, strings as is and nested values as JSON.
+
+type selectStep struct {
+	name    string // field or map key, empty for index and iteration steps
+	key     bool   // name is a bracketed map key, used as is
+	index   int
+	indexed bool
+	iterate bool
+}
+
+// parseSelect parses the --select expression into the steps of the path
+func parseSelect(expr string) ([]selectStep, error) {
+	expr = strings.TrimSpace(expr)
+	if !strings.HasPrefix(expr, ".") {
+		return nil, fmt.Errorf("select expression %q must start with '.'", expr)
+	}
+
+	var steps []selectStep
+	rest := expr
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			rest = rest[1:]
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if end > 0 {
+				steps = append(steps, selectStep{name: rest[:end]})
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("missing ']' in select expression %q", expr)
+			}
+			inner := strings.TrimSpace(rest[1:end])
+			rest = rest[end+1:]
+			switch {
+			case inner == "":
+				steps = append(steps, selectStep{iterate: true})
+			case strings.HasPrefix(inner, `"`):
+				key, err := strconv.Unquote(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid key %s in select expression %q", inner, expr)
+				}
+				steps = append(steps, selectStep{name: key, key: true})
+			default:
+				index, err := strconv.Atoi(inner)
+				if err != nil {
+					return nil, fmt.Errorf("invalid index %s in select expression %q", inner, expr)
+				}
+				steps = append(steps, selectStep{index: index, indexed: true})
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q in select expression %q", rest[0], expr)
+		}
+	}
+	return steps, nil
+}
+
+// selectValues applies the steps to the item and returns the selected values. Iteration steps
+// produce a value per element, a nil value along the path selects null
+func selectValues(c *cli.Context, item interface{}, steps []selectStep) ([]interface{}, error) {
+	values := []interface{}{item}
+	for _, step := range steps {
+		var next []interface{}
+		for _, v := range values {
+			selected, err := selectStepValues(c, v, step)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, selected...)
+		}
+		values = next
+	}
+	return values, nil
+}
+
+func selectStepValues(c *cli.Context, v interface{}, step selectStep) ([]interface{}, error) {
+	if v == nil {
+		return []interface{}{nil}, nil
+	}
+	if step.key {
+		return selectKey(v, step.name)
+	}
+	if step.name != "" {
+		value, ok := ResolveField(c, v, step.name)
+		if !ok {
+			return nil, fmt.Errorf("unable to select %s of %T", step.name, v)
+		}
+		return []interface{}{value}, nil
+	}
+
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return []interface{}{nil}, nil
+		}
+		val = val.Elem()
+	}
+
+	switch {
+	case step.iterate && (val.Kind() == reflect.Slice || val.Kind() == reflect.Array):
+		values := make([]interface{}, val.Len())
+		for i := range values {
+			values[i] = val.Index(i).Interface()
+		}
+		return values, nil
+	case step.iterate && val.Kind() == reflect.Map:
+		keys := val.MapKeys()
+		sort.Slice(keys, func(a, b int) bool {
+			return fmt.Sprint(keys[a].Interface()) < fmt.Sprint(keys[b].Interface())
+		})
+		values := make([]interface{}, len(keys))
+		for i, key := range keys {
+			values[i] = val.MapIndex(key).Interface()
+		}
+		return values, nil
+	case step.indexed && (val.Kind() == reflect.Slice || val.Kind() == reflect.Array):
+		index := step.index
+		if index < 0 {
+			index += val.Len()
+		}
+		if index < 0 || index >= val.Len() {
+			return []interface{}{nil}, nil
+		}
+		return []interface{}{val.Index(index).Interface()}, nil
+	}
+	return nil, fmt.Errorf("unable to index %T", v)
+}
+
+// selectKey looks the key up in a map, or in the map wrapped by a struct such as Memo, without splitting it on dots
+func selectKey(v interface{}, key string) ([]interface{}, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return []interface{}{nil}, nil
+		}
+		val = val.Elem()
+	}
+	if val.Kind() == reflect.Struct {
+		val = wrappedMap(val)
+	}
+	if val.Kind() != reflect.Map || val.Type().Key().Kind() != reflect.String {
+		return nil, fmt.Errorf("unable to select key %q of %T", key, v)
+	}
+
+	value := val.MapIndex(reflect.ValueOf(key).Convert(val.Type().Key()))
+	if !value.IsValid() {
+		return []interface{}{nil}, nil
+	}
+	return []interface{}{value.Interface()}, nil
+}
+
+// PrintSelected prints the values selected with --select from each item, one value per line
+func PrintSelected(c *cli.Context, items []interface{}, opts *PrintOptions) {
+	expr := c.String(FlagSelect)
+	steps, err := parseSelect(expr)
+	if err != nil {
+		process.ErrorAndExit("unable to print items", err)
+	}
+
+	for _, item := range items {
+		values, err := selectValues(c, item, steps)
+		if err != nil {
+			process.ErrorAndExit("unable to print items", err)
+		}
+		for _, v := range values {
+			str, err := formatSelected(c, expr, v)
+			if err != nil {
+				process.ErrorAndExit("unable to print items", err)
+			}
+			fmt.Fprintln(opts.Pager, str)
+		}
+	}
+}
+
+func formatSelected(c *cli.Context, expr string, v interface{}) (string, error) {
+	val := reflect.ValueOf(v)
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nullPlaceholder, nil
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return nullPlaceholder, nil
+	}
+
+	switch {
+	case val.Type() == reflect.TypeOf(time.Time{}):
+	case val.Kind() == reflect.Struct || val.Kind() == reflect.Map ||
+		val.Kind() == reflect.Slice && val.Type().Elem().Kind() != reflect.Uint8:
+		b, err := marshalJSON(c, v, false)
+		return string(b), err
+	}
+	return formatField(c, Column{Field: expr}, v), nil
+}
@@ -0,0 +1,124 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type selectSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestSelectSuite(t *testing.T) {
+	suite.Run(t, new(selectSuite))
+}
+
+func (s *selectSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+type selectActivity struct {
+	ActivityId string
+	Details    []string
+}
+
+type selectItem struct {
+	Activities []*selectActivity
+	Fields     map[string]int
+	Memo       *selectMemo
+}
+
+type selectMemo struct {
+	Fields map[string]string
+}
+
+func (s *selectSuite) TestParseSelect() {
+	steps, err := parseSelect(`.Activities[].Details[-1].Fields["a.b"]`)
+	s.NoError(err)
+	s.Equal([]selectStep{
+		{name: "Activities"},
+		{iterate: true},
+		{name: "Details"},
+		{index: -1, indexed: true},
+		{name: "Fields"},
+		{name: "a.b", key: true},
+	}, steps)
+
+	steps, err = parseSelect(".")
+	s.NoError(err)
+	s.Empty(steps)
+
+	for _, expr := range []string{"Activities", ".Activities[", ".Activities[x]", `.Fields["a]`} {
+		_, err := parseSelect(expr)
+		s.Error(err, expr)
+	}
+}
+
+func (s *selectSuite) TestSelectValues() {
+	item := &selectItem{
+		Activities: []*selectActivity{
+			{ActivityId: "1", Details: []string{"a", "b"}},
+			{ActivityId: "2"},
+		},
+		Fields: map[string]int{"b": 2, "a": 1, "a.b": 3},
+		Memo:   &selectMemo{Fields: map[string]string{"team.owner": "payments"}},
+	}
+
+	tests := []struct {
+		expr   string
+		values []interface{}
+	}{
+		{".Activities[].ActivityId", []interface{}{"1", "2"}},
+		{".Activities[0].Details[]", []interface{}{"a", "b"}},
+		{".Activities[].Details[-1]", []interface{}{"b", nil}},
+		{".Activities[5].ActivityId", []interface{}{nil}},
+		{".Fields[]", []interface{}{1, 3, 2}},
+		{".Fields.b", []interface{}{2}},
+		{`.Fields["a.b"]`, []interface{}{3}},
+		{`.Fields["b"]`, []interface{}{2}},
+		{`.Fields["a.c"]`, []interface{}{nil}},
+		{`.Memo["team.owner"]`, []interface{}{"payments"}},
+		{`.Memo.Fields["team.owner"]`, []interface{}{"payments"}},
+	}
+	for _, tt := range tests {
+		steps, err := parseSelect(tt.expr)
+		s.NoError(err, tt.expr)
+		values, err := selectValues(nil, item, steps)
+		s.NoError(err, tt.expr)
+		s.Equal(tt.values, values, tt.expr)
+	}
+
+	steps, _ := parseSelect(".Activities.ActivityId")
+	_, err := selectValues(nil, item, steps)
+	s.Error(err)
+	steps, _ = parseSelect(`.Activities["a.b"]`)
+	_, err = selectValues(nil, item, steps)
+	s.Error(err)
+}
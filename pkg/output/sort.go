@@ -0,0 +1,341 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	"go.temporal.io/server/common/collection"
+
+	"github.com/temporalio/tctl/pkg/format"
+)
+
+const (
+	sortAsc  = "asc"
+	sortDesc = "desc"
+)
+
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+// parseSort parses --sort values such as "StartTime:desc,WorkflowId"
+func parseSort(expr string) ([]sortKey, error) {
+	var keys []sortKey
+	for _, term := range strings.Split(expr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		key := sortKey{field: term}
+		if i := strings.LastIndex(term, ":"); i >= 0 {
+			key.field = term[:i]
+			switch order := term[i+1:]; order {
+			case sortAsc:
+			case sortDesc:
+				key.desc = true
+			default:
+				return nil, fmt.Errorf("invalid sort order %q of field %v, expected %v or %v", order, key.field, sortAsc, sortDesc)
+			}
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// sortItems reads all the items and sorts them by the --sort keys. Keys may be virtual fields,
+// values are resolved the same way as for printing. With --spool the items are kept in a temp file
+// and only the sort keys stay in memory
+func sortItems(c *cli.Context, iter collection.Iterator, keys []sortKey) (collection.Iterator, error) {
+	var err error
+	var store itemStore = &memoryStore{}
+	if c.Bool(FlagSpool) {
+		if store, err = newSpoolStore(); err != nil {
+			return nil, err
+		}
+	}
+
+	var fields []string
+	var values [][]interface{}
+	for iter.HasNext() {
+		item, err := iter.Next()
+		if err != nil {
+			store.Close()
+			return nil, err
+		}
+
+		if fields == nil {
+			if fields, err = resolveSortFields(c, item, keys); err != nil {
+				store.Close()
+				return nil, err
+			}
+		}
+		row := make([]interface{}, len(fields))
+		for k, field := range fields {
+			row[k], _ = ResolveField(c, item, field)
+		}
+		values = append(values, row)
+
+		if err := store.add(item); err != nil {
+			store.Close()
+			return nil, err
+		}
+	}
+
+	return &sortedIterator{store: store, order: sortOrder(c, values, keys)}, nil
+}
+
+// globalSortKeys returns the keys to sort the whole result set by before printing: the --sort keys or, with --all,
+// the keys of each printed batch
+func globalSortKeys(c *cli.Context, opts *PrintOptions) ([]sortKey, error) {
+	if c.IsSet(FlagSort) {
+		return parseSort(c.String(FlagSort))
+	}
+	if !opts.IgnoreFlags && c.Bool(FlagAll) {
+		return batchSortKeys(c, opts)
+	}
+	return nil, nil
+}
+
+// batchSortKeys returns the keys of PrintOptions.SortBy and SortDesc, overridden by --sort-by and --desc
+func batchSortKeys(c *cli.Context, opts *PrintOptions) ([]sortKey, error) {
+	sortBy := opts.SortBy
+	desc := opts.SortDesc
+	if !opts.IgnoreFlags && c.IsSet(FlagSortBy) {
+		sortBy = strings.Split(c.String(FlagSortBy), ",")
+	}
+	if !opts.IgnoreFlags && c.IsSet(FlagDesc) {
+		desc = c.Bool(FlagDesc)
+	}
+
+	keys, err := parseSort(strings.Join(sortBy, ","))
+	if err != nil {
+		return nil, err
+	}
+	if desc {
+		for i := range keys {
+			keys[i].desc = !keys[i].desc
+		}
+	}
+	return keys, nil
+}
+
+// sortOrder returns the indexes of the rows of sort key values in the sorted order
+func sortOrder(c *cli.Context, rows [][]interface{}, keys []sortKey) []int {
+	order := make([]int, len(rows))
+	for i := range order {
+		order[i] = i
+	}
+	less := rowsLess(c, keys)
+	sort.SliceStable(order, func(a, b int) bool {
+		return less(rows[order[a]], rows[order[b]])
+	})
+	return order
+}
+
+// sortBatch sorts the printed items in place by PrintOptions.SortBy, or by --sort-by and --desc. Only the items
+// of the current batch are sorted, unless the whole result set was already sorted by Pager with --sort or --all
+func sortBatch(c *cli.Context, items []interface{}, opts *PrintOptions) error {
+	if opts.sortedAll || len(items) == 0 {
+		return nil
+	}
+	keys, err := batchSortKeys(c, opts)
+	if err != nil || len(keys) == 0 {
+		return err
+	}
+	fields, err := resolveSortFields(c, items[0], keys)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]interface{}, len(items))
+	for i, item := range items {
+		rows[i] = make([]interface{}, len(fields))
+		for k, field := range fields {
+			rows[i][k], _ = ResolveField(c, item, field)
+		}
+	}
+
+	sorted := make([]interface{}, len(items))
+	for i, j := range sortOrder(c, rows, keys) {
+		sorted[i] = items[j]
+	}
+	copy(items, sorted)
+	return nil
+}
+
+// rowsLess compares the sort key values of two items
+func rowsLess(c *cli.Context, keys []sortKey) func(a, b []interface{}) bool {
+	now := format.Now(c)
+	return func(a, b []interface{}) bool {
+		for k, key := range keys {
+			cmp := compareValues(c, now, a[k], b[k])
+			if cmp == 0 {
+				continue
+			}
+			if key.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	}
+}
+
+func resolveSortFields(c *cli.Context, item interface{}, keys []sortKey) ([]string, error) {
+	known := extractFieldNames(item, []string{}, "", fieldsDepth)
+	fields := make([]string, len(keys))
+	var unresolved []string
+	for i, key := range keys {
+		fields[i] = resolveFieldName(known, key.field)
+		if _, ok := ResolveField(c, item, fields[i]); !ok {
+			unresolved = append(unresolved, key.field)
+		}
+	}
+	if err := validateFields(known, unresolved); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// compareValues orders values of the same field. Empty values go first
+func compareValues(c *cli.Context, now time.Time, a, b interface{}) int {
+	if cell, ok := a.(ageCell); ok {
+		a = cell.duration(now)
+	}
+	if cell, ok := b.(ageCell); ok {
+		b = cell.duration(now)
+	}
+	if cell, ok := a.(statusCell); ok {
+		a = cell.status.String()
+	}
+	if cell, ok := b.(statusCell); ok {
+		b = cell.status.String()
+	}
+
+	va := reflect.Indirect(reflect.ValueOf(a))
+	vb := reflect.Indirect(reflect.ValueOf(b))
+	switch {
+	case !va.IsValid() && !vb.IsValid():
+		return 0
+	case !va.IsValid():
+		return -1
+	case !vb.IsValid():
+		return 1
+	}
+
+	if ta, ok := va.Interface().(time.Time); ok {
+		if tb, ok := vb.Interface().(time.Time); ok {
+			switch {
+			case ta.Before(tb):
+				return -1
+			case ta.After(tb):
+				return 1
+			}
+			return 0
+		}
+	}
+
+	if va.Kind() == vb.Kind() {
+		switch va.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return compareFloats(float64(va.Int()), float64(vb.Int()))
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return compareFloats(float64(va.Uint()), float64(vb.Uint()))
+		case reflect.Float32, reflect.Float64:
+			return compareFloats(va.Float(), vb.Float())
+		case reflect.Bool:
+			return compareFloats(boolToFloat(va.Bool()), boolToFloat(vb.Bool()))
+		}
+	}
+
+	return strings.Compare(formatField(c, Column{}, a), formatField(c, Column{}, b))
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+type sliceIterator struct {
+	items []interface{}
+	index int
+}
+
+func (it *sliceIterator) HasNext() bool {
+	return it.index < len(it.items)
+}
+
+func (it *sliceIterator) Next() (interface{}, error) {
+	if !it.HasNext() {
+		return nil, fmt.Errorf("no more items")
+	}
+	item := it.items[it.index]
+	it.index++
+	return item, nil
+}
+
+// sortedIterator returns the stored items in the sorted order
+type sortedIterator struct {
+	store itemStore
+	order []int
+	index int
+}
+
+func (it *sortedIterator) HasNext() bool {
+	return it.index < len(it.order)
+}
+
+func (it *sortedIterator) Next() (interface{}, error) {
+	if !it.HasNext() {
+		return nil, fmt.Errorf("no more items")
+	}
+	item, err := it.store.get(it.order[it.index])
+	it.index++
+	return item, err
+}
+
+func (it *sortedIterator) Close() error {
+	return it.store.Close()
+}
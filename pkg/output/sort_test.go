@@ -0,0 +1,138 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+)
+
+type sortSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestSortSuite(t *testing.T) {
+	suite.Run(t, new(sortSuite))
+}
+
+func (s *sortSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+type sortItem struct {
+	ID    string
+	Count int
+}
+
+func (s *sortSuite) context(args ...string) *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.String(FlagSort, "", "")
+	set.String(FlagSortBy, "", "")
+	set.Bool(FlagDesc, false, "")
+	set.Bool(FlagAll, false, "")
+	s.NoError(set.Parse(args))
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func sortedIDs(items []interface{}) []string {
+	var result []string
+	for _, item := range items {
+		result = append(result, item.(sortItem).ID)
+	}
+	return result
+}
+
+func (s *sortSuite) TestSortBatch() {
+	items := []interface{}{sortItem{"a", 2}, sortItem{"b", 3}, sortItem{"c", 1}, sortItem{"d", 2}}
+
+	s.NoError(sortBatch(s.context("--sort-by", "Count"), items, &PrintOptions{}))
+	s.Equal([]string{"c", "a", "d", "b"}, sortedIDs(items))
+
+	s.NoError(sortBatch(s.context("--sort-by", "Count", "--desc"), items, &PrintOptions{}))
+	s.Equal([]string{"b", "a", "d", "c"}, sortedIDs(items))
+
+	s.NoError(sortBatch(s.context("--sort-by", "Count:desc,ID:desc"), items, &PrintOptions{}))
+	s.Equal([]string{"b", "d", "a", "c"}, sortedIDs(items))
+}
+
+func (s *sortSuite) TestSortBatch_Options() {
+	items := []interface{}{sortItem{"b", 1}, sortItem{"a", 2}}
+
+	s.NoError(sortBatch(s.context(), items, &PrintOptions{SortBy: []string{"ID"}}))
+	s.Equal([]string{"a", "b"}, sortedIDs(items))
+
+	// the flags override the options of the command
+	s.NoError(sortBatch(s.context("--sort-by", "Count"), items, &PrintOptions{SortBy: []string{"ID"}}))
+	s.Equal([]string{"b", "a"}, sortedIDs(items))
+
+	s.NoError(sortBatch(s.context("--sort-by", "Count"), items, &PrintOptions{SortBy: []string{"ID"}, IgnoreFlags: true}))
+	s.Equal([]string{"a", "b"}, sortedIDs(items))
+
+	s.NoError(sortBatch(s.context("--sort-by", "Count"), items, &PrintOptions{sortedAll: true}))
+	s.Equal([]string{"a", "b"}, sortedIDs(items))
+}
+
+func (s *sortSuite) TestSortBatch_Invalid() {
+	items := []interface{}{sortItem{"a", 1}}
+	s.Error(sortBatch(s.context("--sort-by", "Count:up"), items, &PrintOptions{}))
+	s.Error(sortBatch(s.context("--sort-by", "Unknown"), items, &PrintOptions{}))
+}
+
+func (s *sortSuite) TestGlobalSortKeys() {
+	keys, err := globalSortKeys(s.context("--sort-by", "Count"), &PrintOptions{})
+	s.NoError(err)
+	s.Empty(keys)
+
+	keys, err = globalSortKeys(s.context("--sort-by", "Count", "--desc", "--all"), &PrintOptions{})
+	s.NoError(err)
+	s.Equal([]sortKey{{field: "Count", desc: true}}, keys)
+
+	keys, err = globalSortKeys(s.context("--sort", "ID:desc", "--sort-by", "Count"), &PrintOptions{})
+	s.NoError(err)
+	s.Equal([]sortKey{{field: "ID", desc: true}}, keys)
+}
+
+func (s *sortSuite) TestSortItems_All() {
+	c := s.context("--sort-by", "Count", "--all")
+	keys, err := globalSortKeys(c, &PrintOptions{})
+	s.NoError(err)
+
+	iter := &sliceIterator{items: []interface{}{sortItem{"a", 3}, sortItem{"b", 1}, sortItem{"c", 2}, sortItem{"d", 0}}}
+	sorted, err := sortItems(c, iter, keys)
+	s.NoError(err)
+
+	var items []interface{}
+	for sorted.HasNext() {
+		item, err := sorted.Next()
+		s.NoError(err)
+		items = append(items, item)
+	}
+	s.Equal([]string{"d", "b", "c", "a"}, sortedIDs(items))
+}
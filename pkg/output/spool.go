@@ -0,0 +1,173 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+
+	"github.com/gogo/protobuf/proto"
+)
+
+// itemStore keeps the items that have to be read in full before printing, ex. for sorting
+type itemStore interface {
+	add(item interface{}) error
+	get(i int) (interface{}, error)
+	Close() error
+}
+
+type memoryStore struct {
+	items []interface{}
+}
+
+func (s *memoryStore) add(item interface{}) error {
+	s.items = append(s.items, item)
+	return nil
+}
+
+func (s *memoryStore) get(i int) (interface{}, error) {
+	return s.items[i], nil
+}
+
+func (s *memoryStore) Close() error {
+	return nil
+}
+
+// spoolStore serializes the items to a temp file, so that memory does not grow with the number of items
+type spoolStore struct {
+	file    *os.File
+	offsets []int64
+	size    int64
+	typ     reflect.Type
+}
+
+func newSpoolStore() (*spoolStore, error) {
+	file, err := ioutil.TempFile("", "tctl-spool-")
+	if err != nil {
+		return nil, err
+	}
+	return &spoolStore{file: file}, nil
+}
+
+func (s *spoolStore) add(item interface{}) error {
+	typ := reflect.TypeOf(item)
+	if s.typ == nil {
+		s.typ = typ
+	} else if typ != s.typ {
+		return fmt.Errorf("unable to spool items of different types %v and %v", s.typ, typ)
+	}
+
+	n, err := writeItem(s.file, item)
+	if err != nil {
+		return err
+	}
+	s.offsets = append(s.offsets, s.size)
+	s.size += int64(n)
+	return nil
+}
+
+func (s *spoolStore) get(i int) (interface{}, error) {
+	var length [4]byte
+	if _, err := s.file.ReadAt(length[:], s.offsets[i]); err != nil {
+		return nil, err
+	}
+	data := make([]byte, binary.LittleEndian.Uint32(length[:]))
+	if _, err := s.file.ReadAt(data, s.offsets[i]+int64(len(length))); err != nil && err != io.EOF {
+		return nil, err
+	}
+	return decodeItem(s.typ, data)
+}
+
+// writeItem writes a length prefixed record of the item. Proto messages are stored in their binary encoding, other items with gob
+func writeItem(w io.Writer, item interface{}) (int, error) {
+	var data []byte
+	if pb, ok := item.(proto.Message); ok {
+		b, err := proto.Marshal(pb)
+		if err != nil {
+			return 0, err
+		}
+		data = b
+	} else {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(item); err != nil {
+			return 0, err
+		}
+		data = buf.Bytes()
+	}
+
+	var length [4]byte
+	binary.LittleEndian.PutUint32(length[:], uint32(len(data)))
+	if _, err := w.Write(length[:]); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return 0, err
+	}
+	return len(length) + len(data), nil
+}
+
+// readItem reads a record written by writeItem
+func readItem(r io.Reader, typ reflect.Type) (interface{}, error) {
+	var length [4]byte
+	if _, err := io.ReadFull(r, length[:]); err != nil {
+		return nil, err
+	}
+	data := make([]byte, binary.LittleEndian.Uint32(length[:]))
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return decodeItem(typ, data)
+}
+
+func decodeItem(typ reflect.Type, data []byte) (interface{}, error) {
+	if typ.Kind() == reflect.Ptr {
+		item := reflect.New(typ.Elem())
+		if pb, ok := item.Interface().(proto.Message); ok {
+			return pb, proto.Unmarshal(data, pb)
+		}
+		if err := gob.NewDecoder(bytes.NewReader(data)).Decode(item.Interface()); err != nil {
+			return nil, err
+		}
+		return item.Interface(), nil
+	}
+
+	item := reflect.New(typ)
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(item.Interface()); err != nil {
+		return nil, err
+	}
+	return item.Elem().Interface(), nil
+}
+
+// Close removes the temp file
+func (s *spoolStore) Close() error {
+	s.file.Close()
+	return os.Remove(s.file.Name())
+}
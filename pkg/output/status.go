@@ -0,0 +1,169 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/urfave/cli/v2"
+	enumspb "go.temporal.io/api/enums/v1"
+
+	"github.com/temporalio/tctl/pkg/color"
+	"github.com/temporalio/tctl/pkg/format"
+)
+
+const (
+	// FieldStatus is a virtual field combining the workflow status with the time spent in it
+	FieldStatus = "__status"
+	// FieldAge is a virtual field with the time a workflow has been running, or ran for when closed
+	FieldAge = "__age"
+)
+
+// virtualFields are computed from the whole item rather than read from one of its fields
+var virtualFields = map[string]func(c *cli.Context, item interface{}) interface{}{
+	FieldStatus: statusOf,
+	FieldAge:    ageOf,
+}
+
+type statusIcon struct {
+	unicode string
+	ascii   string
+}
+
+var statusIcons = map[enumspb.WorkflowExecutionStatus]statusIcon{
+	enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:          {"▶", ">"},
+	enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED:        {"✔", "+"},
+	enumspb.WORKFLOW_EXECUTION_STATUS_FAILED:           {"✖", "x"},
+	enumspb.WORKFLOW_EXECUTION_STATUS_CANCELED:         {"⊘", "-"},
+	enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED:       {"■", "!"},
+	enumspb.WORKFLOW_EXECUTION_STATUS_CONTINUED_AS_NEW: {"↻", "~"},
+	enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT:        {"⧗", "t"},
+}
+
+type statusCell struct {
+	status enumspb.WorkflowExecutionStatus
+	since  *time.Time
+}
+
+// statusOf reads the Status, StartTime and CloseTime fields of a workflow execution
+func statusOf(c *cli.Context, item interface{}) interface{} {
+	val := reflect.Indirect(reflect.ValueOf(item))
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	status, ok := fieldInterface(val, "Status").(enumspb.WorkflowExecutionStatus)
+	if !ok {
+		return nil
+	}
+
+	cell := statusCell{status: status}
+	timeField := "CloseTime"
+	if status == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		timeField = "StartTime"
+	}
+	if t, ok := fieldInterface(val, timeField).(*time.Time); ok && t != nil && !t.IsZero() {
+		cell.since = t
+	}
+	return cell
+}
+
+// ageCell keeps the execution times, the age itself depends on the reference time of the output
+type ageCell struct {
+	start *time.Time
+	close *time.Time
+}
+
+func ageOf(c *cli.Context, item interface{}) interface{} {
+	val := reflect.Indirect(reflect.ValueOf(item))
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	start, ok := fieldInterface(val, "StartTime").(*time.Time)
+	if !ok || start == nil || start.IsZero() {
+		return nil
+	}
+	cell := ageCell{start: start}
+	if closeTime, ok := fieldInterface(val, "CloseTime").(*time.Time); ok && closeTime != nil && !closeTime.IsZero() {
+		cell.close = closeTime
+	}
+	return cell
+}
+
+func (a ageCell) duration(now time.Time) time.Duration {
+	if a.close != nil {
+		return a.close.Sub(*a.start)
+	}
+	return now.Sub(*a.start)
+}
+
+func fieldInterface(val reflect.Value, name string) interface{} {
+	field := val.FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return nil
+	}
+	return field.Interface()
+}
+
+func formatStatus(c *cli.Context, cell statusCell) string {
+	icon := statusIcons[cell.status].unicode
+	if c.Bool(FlagASCII) {
+		icon = statusIcons[cell.status].ascii
+	}
+
+	text := color.Colorize(c, statusRole(cell.status), "%s %s", icon, cell.status)
+
+	if cell.since == nil {
+		return text
+	}
+	verb := "closed"
+	if cell.status == enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING {
+		verb = "started"
+	}
+	return fmt.Sprintf("%s (%s %s)", text, verb, format.FormatTimeAs(c, *cell.since, format.Relative))
+}
+
+// statusRole is the theme color of the workflow status
+func statusRole(status enumspb.WorkflowExecutionStatus) color.Role {
+	switch status {
+	case enumspb.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		return color.RoleRunning
+	case enumspb.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		return color.RoleCompleted
+	case enumspb.WORKFLOW_EXECUTION_STATUS_FAILED, enumspb.WORKFLOW_EXECUTION_STATUS_TIMED_OUT:
+		return color.RoleFailed
+	case enumspb.WORKFLOW_EXECUTION_STATUS_TERMINATED:
+		return color.RoleTerminated
+	default:
+		return color.RoleOther
+	}
+}
+
+func formatAge(c *cli.Context, cell ageCell) string {
+	return cell.duration(format.Now(c)).Round(time.Second).String()
+}
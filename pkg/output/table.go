@@ -25,36 +25,83 @@
 package output
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/olekukonko/tablewriter"
 	"github.com/urfave/cli/v2"
 
 	"github.com/temporalio/tctl/pkg/color"
+	"github.com/temporalio/tctl/pkg/pager"
 	"github.com/temporalio/tctl/pkg/process"
 )
 
-var (
-	headerColor = tablewriter.Colors{tablewriter.FgHiMagentaColor}
-)
-
 func PrintTable(c *cli.Context, items []interface{}, opts *PrintOptions) {
-	colorFlag := c.String(color.FlagColor)
-	enableColor := colorFlag == string(color.Auto) || colorFlag == string(color.Always)
-	fields := opts.Fields
-	table := tablewriter.NewWriter(opts.Pager)
+	enableColor := color.Enabled(c)
+	columns := opts.getColumns(items)
+	var buf bytes.Buffer
+	var w io.Writer = opts.Pager
+	trim := trimTrailing(c)
+	if trim {
+		w = &buf
+	}
+	table := tablewriter.NewWriter(w)
 	table.SetBorder(false)
 	table.SetColumnSeparator(opts.Separator)
 
+	widths, err := columnWidths(c, columns, opts)
+	if err != nil {
+		process.ErrorAndExit("unable to print table", err)
+	}
+
+	rows, err := extractFieldValues(c, items, columnFields(columns))
+	if err != nil {
+		process.ErrorAndExit("unable to print table", err)
+	}
+	cells := make([][]string, len(rows))
+	for i, row := range rows {
+		cells[i] = make([]string, len(row))
+		for j, value := range row {
+			cells[i][j] = truncateCell(formatField(c, columns[j], value), widths[j])
+		}
+	}
+
+	termWidth := tableTerminalWidth(c, opts)
+	if opts.layout == nil {
+		// decided on the first batch, so the batches printed after the header stay aligned
+		opts.layout = fitColumns(columns, cells, termWidth, opts)
+	}
+	if termWidth > 0 {
+		// values are truncated to fit instead of being wrapped
+		table.SetAutoWrapText(false)
+	}
+	for _, width := range widths {
+		if width > 0 {
+			// keep the truncated values on a single line
+			table.SetAutoWrapText(false)
+		}
+	}
+
 	if !opts.NoHeader {
-		headerNames := make([]string, len(fields))
-		for i, f := range fields {
-			nestedFields := splitFieldPath(f)
-			headerNames[i] = nestedFields[len(nestedFields)-1]
+		var headerNames []string
+		for i, col := range columns {
+			if opts.layout[i] >= 0 {
+				headerNames = append(headerNames, truncateCell(col.Header(), opts.layout[i]))
+			}
 		}
 		table.SetHeader(headerNames)
 		table.SetAutoFormatHeaders(false)
 
 		if enableColor {
-			headerColors := make([]tablewriter.Colors, len(fields))
+			var headerColor tablewriter.Colors
+			for _, attr := range color.Attributes(c, color.RoleHeader) {
+				headerColor = append(headerColor, int(attr))
+			}
+			headerColors := make([]tablewriter.Colors, len(headerNames))
 			for i := range headerColors {
 				headerColors[i] = headerColor
 			}
@@ -63,18 +110,170 @@ func PrintTable(c *cli.Context, items []interface{}, opts *PrintOptions) {
 		table.SetHeaderLine(false)
 	}
 
-	rows, err := extractFieldValues(items, fields)
-	if err != nil {
-		process.ErrorAndExit("unable to print table", err)
-	}
-
-	for _, row := range rows {
-		columns := make([]string, len(row))
-		for j, column := range row {
-			columns[j] = formatField(c, column)
+	for _, row := range cells {
+		var visible []string
+		for j, cell := range row {
+			if opts.layout[j] >= 0 {
+				visible = append(visible, truncateCell(cell, opts.layout[j]))
+			}
 		}
-		table.Append(columns)
+		table.Append(visible)
 	}
 	table.Render()
 	table.ClearRows()
+
+	if trim {
+		writeTrimmed(opts.Pager, &buf)
+	}
+}
+
+const (
+	dropColumn     = -1 // layout of a column that doesn't fit in the terminal
+	minColumnWidth = 16 // columns are shrunk down to this width before being dropped
+	columnPadding  = 3  // space taken by the separator and the padding of each column
+)
+
+// fitColumns lays the columns out to fit in the terminal width, if any. Columns of the lowest priority, the FieldsLong
+// ones by default, are shrunk first then dropped, starting from the right. Returns the width to truncate each
+// column to, 0 to keep it as is or dropColumn
+func fitColumns(columns []Column, cells [][]string, termWidth int, opts *PrintOptions) []int {
+	layout := make([]int, len(columns))
+	if termWidth <= 0 || len(columns) == 0 {
+		return layout
+	}
+
+	natural := make([]int, len(columns))
+	total := 0
+	for j, col := range columns {
+		natural[j] = tablewriter.DisplayWidth(col.Header())
+		for _, row := range cells {
+			if w := tablewriter.DisplayWidth(row[j]); w > natural[j] {
+				natural[j] = w
+			}
+		}
+		total += natural[j] + columnPadding
+	}
+
+	visible := len(columns)
+	for total > termWidth && visible > 1 {
+		j := lowestPriorityColumn(columns, layout, opts)
+		if excess := total - termWidth; natural[j] > minColumnWidth {
+			shrunk := natural[j] - excess
+			if shrunk < minColumnWidth {
+				shrunk = minColumnWidth
+			}
+			total -= natural[j] - shrunk
+			natural[j] = shrunk
+			layout[j] = shrunk
+			continue
+		}
+		total -= natural[j] + columnPadding
+		layout[j] = dropColumn
+		visible--
+	}
+	return layout
+}
+
+// lowestPriorityColumn returns the rightmost visible column of the lowest priority
+func lowestPriorityColumn(columns []Column, layout []int, opts *PrintOptions) int {
+	lowest := -1
+	for j, col := range columns {
+		if layout[j] == dropColumn {
+			continue
+		}
+		if lowest < 0 || columnPriority(col, opts) <= columnPriority(columns[lowest], opts) {
+			lowest = j
+		}
+	}
+	return lowest
+}
+
+// columnPriority is set with PrintOptions.ColumnPriority, it defaults to -1 for the FieldsLong columns and 0 otherwise
+func columnPriority(col Column, opts *PrintOptions) int {
+	if p, ok := opts.ColumnPriority[col.Field]; ok {
+		return p
+	}
+	if containsString(opts.FieldsLong, col.Field) {
+		return -1
+	}
+	return 0
+}
+
+// tableTerminalWidth returns the width to fit the table in, 0 when the table is not printed to a terminal
+// or --no-truncate is set
+func tableTerminalWidth(c *cli.Context, opts *PrintOptions) int {
+	if !opts.IgnoreFlags && c.Bool(FlagNoTruncate) {
+		return 0
+	}
+	if c.IsSet(FlagOutputFile) || c.IsSet(FlagOutputExec) {
+		return 0
+	}
+	return pager.TerminalWidth(os.Stdout)
+}
+
+// columnWidths returns the maximum length of the values of each column, 0 for no limit. The limits come from
+// --max-field-length and --column-width, or PrintOptions when not set, and are lifted by --no-truncate
+func columnWidths(c *cli.Context, columns []Column, opts *PrintOptions) ([]int, error) {
+	widths := make([]int, len(columns))
+	if !opts.IgnoreFlags && c.Bool(FlagNoTruncate) {
+		return widths, nil
+	}
+
+	maxLength := opts.MaxFieldLength
+	if !opts.IgnoreFlags && c.IsSet(FlagMaxFieldLength) {
+		maxLength = c.Int(FlagMaxFieldLength)
+	}
+	perColumn := make(map[string]int, len(opts.ColumnWidths))
+	for field, width := range opts.ColumnWidths {
+		perColumn[field] = width
+	}
+	if !opts.IgnoreFlags {
+		for _, term := range c.StringSlice(FlagColumnWidth) {
+			parts := strings.SplitN(term, "=", 2)
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("invalid column width %q, expected field=width", term)
+			}
+			width, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+			if err != nil || width < 0 {
+				return nil, fmt.Errorf("invalid width of column %v: %q", parts[0], parts[1])
+			}
+			perColumn[strings.TrimSpace(parts[0])] = width
+		}
+	}
+
+	for i, col := range columns {
+		widths[i] = maxLength
+		if width, ok := perColumn[col.Field]; ok {
+			widths[i] = width
+		} else if width, ok := perColumn[col.Header()]; ok {
+			widths[i] = width
+		}
+	}
+	return widths, nil
+}
+
+// truncateCell shortens the value to the width with an ellipsis, a width of 0 keeps the value
+func truncateCell(s string, width int) string {
+	if width <= 0 {
+		return s
+	}
+	return truncate(s, width)
+}
+
+// trimTrailing reports whether the padding after the last column should be stripped, by default when not printing to a terminal
+func trimTrailing(c *cli.Context) bool {
+	if c.IsSet(FlagTrimTrailing) {
+		return c.Bool(FlagTrimTrailing)
+	}
+	return !pager.IsTerminal(os.Stdout)
+}
+
+func writeTrimmed(w io.Writer, buf *bytes.Buffer) {
+	lines := strings.SplitAfter(buf.String(), "\n")
+	for _, line := range lines {
+		if strings.HasSuffix(line, "\n") {
+			line = strings.TrimRight(line[:len(line)-1], " \t") + "\n"
+		}
+		io.WriteString(w, line)
+	}
 }
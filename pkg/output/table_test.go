@@ -0,0 +1,66 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type tableSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestTableSuite(t *testing.T) {
+	suite.Run(t, new(tableSuite))
+}
+
+func (s *tableSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *tableSuite) TestFitColumns() {
+	columns := []Column{{Field: "Name"}, {Field: "Message"}, {Field: "Type"}}
+	cells := [][]string{{"abc", strings.Repeat("x", 80), strings.Repeat("y", 30)}}
+	opts := &PrintOptions{FieldsLong: []string{"Type"}}
+
+	s.Equal([]int{0, 0, 0}, fitColumns(columns, cells, 0, opts))
+	s.Equal([]int{0, 0, 0}, fitColumns(columns, cells, 200, opts))
+	s.Equal([]int{0, 0, dropColumn}, fitColumns(columns, cells, 100, opts))
+	s.Equal([]int{0, 50, dropColumn}, fitColumns(columns, cells, 60, opts))
+	s.Equal([]int{0, dropColumn, dropColumn}, fitColumns(columns, cells, 10, opts))
+}
+
+func (s *tableSuite) TestFitColumns_Priority() {
+	columns := []Column{{Field: "Name"}, {Field: "Message"}, {Field: "Type"}}
+	cells := [][]string{{"abc", strings.Repeat("x", 80), strings.Repeat("y", 10)}}
+	opts := &PrintOptions{ColumnPriority: map[string]int{"Message": -2}}
+
+	s.Equal([]int{0, 39, 0}, fitColumns(columns, cells, 62, opts))
+}
@@ -0,0 +1,81 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// templateFuncs are available in --output go-template=...
+func templateFuncs(c *cli.Context) template.FuncMap {
+	return template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			b, err := marshalJSON(c, v, false)
+			return string(b), err
+		},
+	}
+}
+
+// parseOutputOption splits the template off the go-template=... output option
+func parseOutputOption(value string) (OutputOption, string) {
+	if strings.HasPrefix(value, string(GoTemplate)+"=") {
+		return GoTemplate, strings.TrimPrefix(value, string(GoTemplate)+"=")
+	}
+	return OutputOption(value), ""
+}
+
+// PrintTemplate executes the --output go-template=... template for each item
+func PrintTemplate(c *cli.Context, items []interface{}, opts *PrintOptions) {
+	if opts.template == nil {
+		_, text := parseOutputOption(c.String(FlagOutput))
+		if text == "" {
+			process.ErrorAndExit("unable to print items", fmt.Errorf("%s output requires a template, e.g. %s='{{.Execution.WorkflowId}}'", GoTemplate, GoTemplate))
+		}
+		tmpl, err := template.New(string(GoTemplate)).Funcs(templateFuncs(c)).Parse(text)
+		if err != nil {
+			process.ErrorAndExit("unable to parse template", err)
+		}
+		opts.template = tmpl
+	}
+
+	var b bytes.Buffer
+	for _, item := range items {
+		b.Reset()
+		if err := opts.template.Execute(&b, item); err != nil {
+			process.ErrorAndExit("unable to execute template", err)
+		}
+		if !bytes.HasSuffix(b.Bytes(), []byte("\n")) {
+			b.WriteByte('\n')
+		}
+		_, _ = opts.Pager.Write(b.Bytes())
+	}
+}
@@ -0,0 +1,121 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/pager"
+)
+
+const (
+	clearScreen = "\033[H\033[2J"
+)
+
+// watching is set while a watched action runs, so a command wrapped twice still runs its action once per tick
+var watching bool
+
+// Watch runs the action once or, with --watch, every interval until interrupted. A terminal showing a table
+// or cards is cleared before each run so the output is repainted in place, like `watch -n`. For the other
+// outputs the "Every ..." header goes to stderr, so that stdout stays parseable. Watching stops at the first error
+func Watch(c *cli.Context, action cli.ActionFunc) error {
+	if watching || !c.IsSet(FlagWatch) {
+		return action(c)
+	}
+	interval := c.Duration(FlagWatch)
+	if interval <= 0 {
+		return fmt.Errorf("--%s interval must be positive, ex. 5s", FlagWatch)
+	}
+
+	watching = true
+	defer func() { watching = false }()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	w := newWatcher(c, pager.IsTerminal(os.Stdout))
+	w.interval = interval
+	return w.run(c, action, ticker.C, interrupt)
+}
+
+type watcher struct {
+	header   io.Writer
+	repaint  bool
+	interval time.Duration
+	command  string
+}
+
+func newWatcher(c *cli.Context, terminal bool) *watcher {
+	w := &watcher{header: os.Stderr, command: strings.Join(redactArgs(os.Args), " ")}
+	if terminal && isScreenOutput(c) {
+		w.header = os.Stdout
+		w.repaint = true
+	}
+	return w
+}
+
+// isScreenOutput tells whether the output is meant to be read on the screen rather than parsed
+func isScreenOutput(c *cli.Context) bool {
+	if idsOnly(c) || c.IsSet(FlagSelect) {
+		return false
+	}
+	switch output, _ := parseOutputOption(c.String(FlagOutput)); output {
+	case "", Table, Wide, Card:
+		return true
+	}
+	return false
+}
+
+// run runs the action on start and on every tick until stopped. The actions of most commands exit on errors,
+// the others stop watching by returning the error
+func (w *watcher) run(c *cli.Context, action cli.ActionFunc, tick <-chan time.Time, stop <-chan os.Signal) error {
+	for {
+		if w.repaint {
+			fmt.Fprint(w.header, clearScreen)
+		}
+		fmt.Fprintf(w.header, "Every %v: %s\t%s\n\n", w.interval, w.command, time.Now().Format(time.RFC1123))
+
+		if err := action(c); err != nil {
+			return err
+		}
+
+		select {
+		case <-stop:
+			return nil
+		case <-tick:
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/urfave/cli/v2"
+)
+
+type watchSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestWatchSuite(t *testing.T) {
+	suite.Run(t, new(watchSuite))
+}
+
+func (s *watchSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *watchSuite) context(args ...string) *cli.Context {
+	set := flag.NewFlagSet("test", flag.ContinueOnError)
+	set.Duration(FlagWatch, 0, "")
+	set.String(FlagOutput, "", "")
+	set.Bool(FlagIDsOnly, false, "")
+	s.NoError(set.Parse(args))
+	return cli.NewContext(cli.NewApp(), set, nil)
+}
+
+func (s *watchSuite) TestWatch_Unset() {
+	runs := 0
+	err := Watch(s.context(), func(c *cli.Context) error {
+		runs++
+		return nil
+	})
+	s.NoError(err)
+	s.Equal(1, runs)
+}
+
+func (s *watchSuite) TestWatch_InvalidInterval() {
+	runs := 0
+	err := Watch(s.context("--watch", "0s"), func(c *cli.Context) error {
+		runs++
+		return nil
+	})
+	s.Error(err)
+	s.Equal(0, runs)
+}
+
+func (s *watchSuite) TestWatch_Nested() {
+	// an action wrapped twice runs once per tick
+	c := s.context("--watch", "1h")
+	watching = true
+	defer func() { watching = false }()
+
+	runs := 0
+	s.NoError(Watch(c, func(c *cli.Context) error {
+		runs++
+		return nil
+	}))
+	s.Equal(1, runs)
+}
+
+func (s *watchSuite) TestNewWatcher() {
+	tests := []struct {
+		args     []string
+		terminal bool
+		repaint  bool
+	}{
+		{args: nil, terminal: true, repaint: true},
+		{args: []string{"--output", "card"}, terminal: true, repaint: true},
+		{args: nil, terminal: false, repaint: false},
+		{args: []string{"--output", "json"}, terminal: true, repaint: false},
+		{args: []string{"--ids-only"}, terminal: true, repaint: false},
+	}
+	for _, tt := range tests {
+		w := newWatcher(s.context(tt.args...), tt.terminal)
+		s.Equal(tt.repaint, w.repaint, "%v", tt.args)
+		if tt.repaint {
+			s.Equal(os.Stdout, w.header, "%v", tt.args)
+		} else {
+			s.Equal(os.Stderr, w.header, "%v", tt.args)
+		}
+	}
+}
+
+func (s *watchSuite) TestRun_RepeatsUntilStopped() {
+	var out bytes.Buffer
+	w := &watcher{header: &out, repaint: true, interval: 5 * time.Second, command: "tctl workflow list"}
+	tick := make(chan time.Time)
+	stop := make(chan os.Signal, 1)
+
+	runs := 0
+	action := func(c *cli.Context) error {
+		runs++
+		if runs == 3 {
+			stop <- os.Interrupt
+		} else {
+			go func() { tick <- time.Now() }()
+		}
+		return nil
+	}
+	s.NoError(w.run(s.context(), action, tick, stop))
+	s.Equal(3, runs)
+	s.Equal(3, strings.Count(out.String(), clearScreen))
+	s.Equal(3, strings.Count(out.String(), "Every 5s: tctl workflow list\t"))
+}
+
+func (s *watchSuite) TestRun_StopsOnError() {
+	var out bytes.Buffer
+	w := &watcher{header: &out, interval: time.Second}
+	tick := make(chan time.Time, 1)
+	stop := make(chan os.Signal)
+
+	runs := 0
+	failure := errors.New("unavailable")
+	action := func(c *cli.Context) error {
+		runs++
+		if runs == 2 {
+			return failure
+		}
+		tick <- time.Now()
+		return nil
+	}
+	s.Equal(failure, w.run(s.context(), action, tick, stop))
+	s.Equal(2, runs)
+	s.NotContains(out.String(), clearScreen)
+}
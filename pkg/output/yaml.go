@@ -0,0 +1,101 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package output
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+	"gopkg.in/yaml.v3"
+
+	"github.com/temporalio/tctl/pkg/process"
+)
+
+// PrintYAML prints the items as YAML documents. Items are converted through JSON, so that proto messages
+// are rendered with their JSON names and enums and timestamps are readable
+func PrintYAML(c *cli.Context, items []interface{}, opts *PrintOptions) {
+	var docs []*yaml.Node
+	for _, item := range items {
+		node, err := toYAMLNode(c, item)
+		if err != nil {
+			process.ErrorAndExit("unable to print yaml", err)
+		}
+		docs = append(docs, node)
+	}
+
+	if c.Bool(FlagAnnotate) {
+		annotation, err := toYAMLNode(c, newAnnotation(c))
+		if err != nil {
+			process.ErrorAndExit("unable to print yaml", err)
+		}
+		docs = []*yaml.Node{{
+			Kind: yaml.MappingNode,
+			Content: []*yaml.Node{
+				{Kind: yaml.ScalarNode, Value: "annotation"}, annotation,
+				{Kind: yaml.ScalarNode, Value: "data"}, {Kind: yaml.SequenceNode, Content: docs},
+			},
+		}}
+	}
+
+	for _, doc := range docs {
+		// every document starts with a separator, so that the batches of Pager form a valid stream
+		fmt.Fprintln(opts.Pager, "---")
+		encoder := yaml.NewEncoder(opts.Pager)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			process.ErrorAndExit("unable to print yaml", err)
+		}
+		encoder.Close()
+	}
+}
+
+func toYAMLNode(c *cli.Context, o interface{}) (*yaml.Node, error) {
+	b, err := marshalJSON(c, o, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// JSON is valid YAML, decoding it into a node keeps the order of the fields
+	var doc yaml.Node
+	if err := yaml.Unmarshal(b, &doc); err != nil {
+		return nil, err
+	}
+	node := doc.Content[0]
+	resetYAMLStyle(node)
+	return node, nil
+}
+
+// resetYAMLStyle switches the nodes decoded from JSON from the flow to the block style
+func resetYAMLStyle(node *yaml.Node) {
+	if node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode {
+		node.Style = 0
+	}
+	if node.Kind == yaml.ScalarNode && node.Style == yaml.DoubleQuotedStyle {
+		node.Style = 0
+	}
+	for _, child := range node.Content {
+		resetYAMLStyle(child)
+	}
+}
@@ -0,0 +1,384 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package pager
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	clearScreen = "\x1b[H\x1b[2J"
+	clearLine   = "\r\x1b[K"
+)
+
+var (
+	errPagerQuit = errors.New("pager quit")
+	ansiPattern  = regexp.MustCompile("\x1b\\[[0-9;]*[a-zA-Z]")
+)
+
+type pagerKey int
+
+const (
+	keyNone pagerKey = iota
+	keyQuit
+	keyLineDown
+	keyLineUp
+	keyPageDown
+	keyPageUp
+	keyTop
+	keyBottom
+	keySearch
+	keyNextMatch
+	keyPrevMatch
+)
+
+// builtinPager is the pager used when less and more are not installed, ex. in minimal containers.
+// Keys: space/f/PgDn and b/PgUp scroll by page, j/Enter/Down and k/Up by line, g/G go to the top/bottom,
+// /text searches forward, n/N repeat the search forward/backward and q quits
+type builtinPager struct {
+	keys   io.Reader
+	out    io.Writer
+	src    *bufio.Reader
+	size   func() (int, int)
+	lines  []string
+	eof    bool
+	top    int
+	search string
+	status string
+}
+
+// newBuiltinPager starts the built-in pager reading the keys from stdin, returns stdout when it is not interactive
+func newBuiltinPager() (io.Writer, func()) {
+	if !IsTerminal(os.Stdout) || !IsTerminal(os.Stdin) {
+		return os.Stdout, func() {}
+	}
+
+	reader, writer := io.Pipe()
+	p := &builtinPager{
+		keys: os.Stdin,
+		out:  os.Stdout,
+		src:  bufio.NewReader(reader),
+		size: func() (int, int) { return terminalSize(os.Stdout) },
+	}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		restore, err := makeRaw(os.Stdin)
+		if err != nil {
+			_, _ = io.Copy(os.Stdout, reader)
+			return
+		}
+		defer restore()
+
+		p.run()
+		reader.CloseWithError(errPagerQuit)
+	}()
+
+	return writer, func() {
+		writer.Close()
+		<-done
+	}
+}
+
+// StripANSI removes the color and cursor escape sequences from the text
+func StripANSI(s string) string {
+	return ansiPattern.ReplaceAllString(s, "")
+}
+
+// MakeRaw puts the terminal in raw mode for reading single keys, the returned func restores it
+func MakeRaw(f *os.File) (func(), error) {
+	return makeRaw(f)
+}
+
+// TerminalSize returns the columns and rows of the terminal, zeros when unknown
+func TerminalSize(f *os.File) (int, int) {
+	return terminalSize(f)
+}
+
+func (p *builtinPager) run() {
+	width, height := p.screen()
+	p.fill(height)
+	if p.eof && p.rows(p.lines, width) <= height {
+		// fits in one screen, printed as is like less -F
+		for _, line := range p.lines {
+			fmt.Fprintln(p.out, line)
+		}
+		return
+	}
+
+	for {
+		p.draw()
+		key := p.readKey()
+		width, height = p.screen()
+		switch key {
+		case keyQuit:
+			fmt.Fprint(p.out, clearLine)
+			return
+		case keyLineDown:
+			p.scroll(1)
+		case keyLineUp:
+			p.scroll(-1)
+		case keyPageDown:
+			p.scroll(p.pageLines(width, height))
+		case keyPageUp:
+			p.scroll(-height)
+		case keyTop:
+			p.top = 0
+		case keyBottom:
+			p.fillAll()
+			p.top = len(p.lines)
+			p.scroll(0)
+		case keySearch:
+			if pattern, ok := p.prompt("/"); ok && pattern != "" {
+				p.search = pattern
+				p.find(1)
+			}
+		case keyNextMatch:
+			p.find(1)
+		case keyPrevMatch:
+			p.find(-1)
+		}
+	}
+}
+
+// screen returns the width and the number of content rows, the last row is the status line
+func (p *builtinPager) screen() (int, int) {
+	width, height := p.size()
+	if width <= 0 {
+		width = 80
+	}
+	if height <= 1 {
+		height = 25
+	}
+	return width, height - 1
+}
+
+// fill reads the input until it has n lines or ends
+func (p *builtinPager) fill(n int) {
+	for !p.eof && len(p.lines) < n {
+		line, err := p.src.ReadString('\n')
+		if line != "" {
+			p.lines = append(p.lines, strings.TrimRight(line, "\r\n"))
+		}
+		if err != nil {
+			p.eof = true
+		}
+	}
+}
+
+func (p *builtinPager) fillAll() {
+	for !p.eof {
+		p.fill(len(p.lines) + 1)
+	}
+}
+
+// scroll moves the top line by delta, keeping the last page full
+func (p *builtinPager) scroll(delta int) {
+	width, height := p.screen()
+	p.top += delta
+	p.fill(p.top + height)
+	last := len(p.lines) - 1
+	for rows := 0; last > 0; last-- {
+		rows += p.rows(p.lines[last:last+1], width)
+		if rows >= height {
+			break
+		}
+	}
+	if p.top > last {
+		p.top = last
+	}
+	if p.top < 0 {
+		p.top = 0
+	}
+}
+
+// pageLines returns the number of lines shown on the current page
+func (p *builtinPager) pageLines(width, height int) int {
+	n := 0
+	for rows := 0; p.top+n < len(p.lines); n++ {
+		rows += p.rows(p.lines[p.top+n:p.top+n+1], width)
+		if rows > height {
+			break
+		}
+	}
+	if n == 0 {
+		return 1
+	}
+	return n
+}
+
+// rows returns the number of screen rows taken by the lines when wrapped at the width
+func (p *builtinPager) rows(lines []string, width int) int {
+	rows := 0
+	for _, line := range lines {
+		w := utf8.RuneCountInString(StripANSI(line))
+		if w == 0 {
+			rows++
+			continue
+		}
+		rows += (w + width - 1) / width
+	}
+	return rows
+}
+
+func (p *builtinPager) draw() {
+	width, height := p.screen()
+	p.fill(p.top + height)
+
+	var b strings.Builder
+	b.WriteString(clearScreen)
+	rows := 0
+	for i := p.top; i < len(p.lines); i++ {
+		rows += p.rows(p.lines[i:i+1], width)
+		if rows > height {
+			break
+		}
+		b.WriteString(p.lines[i])
+		b.WriteString("\x1b[0m\n")
+	}
+	for ; rows < height; rows++ {
+		b.WriteString("~\n")
+	}
+
+	status := p.status
+	if status == "" {
+		status = ":"
+		if p.eof && p.top+p.pageLines(width, height) >= len(p.lines) {
+			status = "(END)"
+		}
+	}
+	b.WriteString("\x1b[7m" + status + "\x1b[0m")
+	p.status = ""
+	fmt.Fprint(p.out, b.String())
+}
+
+// find moves the top line to the next match of the search in the direction
+func (p *builtinPager) find(direction int) {
+	if p.search == "" {
+		return
+	}
+	for i := p.top + direction; i >= 0; i += direction {
+		p.fill(i + 1)
+		if i >= len(p.lines) {
+			break
+		}
+		if strings.Contains(StripANSI(p.lines[i]), p.search) {
+			p.top = i
+			return
+		}
+	}
+	p.status = "Pattern not found"
+}
+
+// prompt reads a line of input after the prefix on the status line, false when canceled with Esc or Ctrl+C
+func (p *builtinPager) prompt(prefix string) (string, bool) {
+	var input []rune
+	buf := make([]byte, 16)
+	for {
+		fmt.Fprint(p.out, clearLine+prefix+string(input))
+		n, err := p.keys.Read(buf)
+		if err != nil {
+			return "", false
+		}
+		for _, r := range string(buf[:n]) {
+			switch r {
+			case '\r', '\n':
+				return string(input), true
+			case 0x1b, 0x03:
+				return "", false
+			case 0x7f, 0x08:
+				if len(input) > 0 {
+					input = input[:len(input)-1]
+				}
+			default:
+				if r >= ' ' {
+					input = append(input, r)
+				}
+			}
+		}
+	}
+}
+
+func (p *builtinPager) readKey() pagerKey {
+	buf := make([]byte, 8)
+	n, err := p.keys.Read(buf)
+	if err != nil {
+		return keyQuit
+	}
+	return parseKey(buf[:n])
+}
+
+func parseKey(b []byte) pagerKey {
+	if len(b) >= 3 && b[0] == 0x1b && b[1] == '[' {
+		switch string(b[2:]) {
+		case "A":
+			return keyLineUp
+		case "B":
+			return keyLineDown
+		case "5~":
+			return keyPageUp
+		case "6~":
+			return keyPageDown
+		case "H", "1~":
+			return keyTop
+		case "F", "4~":
+			return keyBottom
+		}
+		return keyNone
+	}
+	if len(b) == 0 {
+		return keyNone
+	}
+
+	switch b[0] {
+	case 'q', 'Q', 0x03:
+		return keyQuit
+	case 'j', '\r', '\n', 'e':
+		return keyLineDown
+	case 'k', 'y':
+		return keyLineUp
+	case ' ', 'f', 0x06:
+		return keyPageDown
+	case 'b', 0x02:
+		return keyPageUp
+	case 'g', '<':
+		return keyTop
+	case 'G', '>':
+		return keyBottom
+	case '/':
+		return keySearch
+	case 'n':
+		return keyNextMatch
+	case 'N':
+		return keyPrevMatch
+	}
+	return keyNone
+}
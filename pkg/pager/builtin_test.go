@@ -0,0 +1,299 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package pager
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type builtinPagerSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestBuiltinPagerSuite(t *testing.T) {
+	suite.Run(t, new(builtinPagerSuite))
+}
+
+func (s *builtinPagerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+// fakeKey is a key press of the fake terminal, resized to the width and height when they are set
+type fakeKey struct {
+	key           string
+	width, height int
+}
+
+// fakeTerminal feeds the keys to the pager one read at a time and records the top line at each read
+type fakeTerminal struct {
+	pager         *builtinPager
+	keys          []fakeKey
+	width, height int
+	tops          []int
+	out           bytes.Buffer
+}
+
+func (t *fakeTerminal) Read(b []byte) (int, error) {
+	t.tops = append(t.tops, t.pager.top)
+	if len(t.keys) == 0 {
+		return 0, io.EOF
+	}
+	key := t.keys[0]
+	t.keys = t.keys[1:]
+	if key.width > 0 {
+		t.width, t.height = key.width, key.height
+	}
+	return copy(b, key.key), nil
+}
+
+func (s *builtinPagerSuite) newPager(input string, width, height int, keys ...fakeKey) (*builtinPager, *fakeTerminal) {
+	t := &fakeTerminal{keys: keys, width: width, height: height}
+	t.pager = &builtinPager{
+		keys: t,
+		out:  &t.out,
+		src:  bufio.NewReader(strings.NewReader(input)),
+		size: func() (int, int) { return t.width, t.height },
+	}
+	return t.pager, t
+}
+
+func numberedLines(n int) string {
+	var b strings.Builder
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	return b.String()
+}
+
+func (s *builtinPagerSuite) TestParseKey() {
+	tests := map[string]pagerKey{
+		"\x1b[A":  keyLineUp,
+		"\x1b[B":  keyLineDown,
+		"\x1b[5~": keyPageUp,
+		"\x1b[6~": keyPageDown,
+		"\x1b[H":  keyTop,
+		"\x1b[1~": keyTop,
+		"\x1b[F":  keyBottom,
+		"\x1b[4~": keyBottom,
+		"\x1b[C":  keyNone,
+		"\x1b":    keyNone,
+		"":        keyNone,
+		"q":       keyQuit,
+		"Q":       keyQuit,
+		"\x03":    keyQuit,
+		"j":       keyLineDown,
+		"\r":      keyLineDown,
+		"k":       keyLineUp,
+		" ":       keyPageDown,
+		"f":       keyPageDown,
+		"b":       keyPageUp,
+		"g":       keyTop,
+		"G":       keyBottom,
+		"/":       keySearch,
+		"n":       keyNextMatch,
+		"N":       keyPrevMatch,
+		"x":       keyNone,
+	}
+	for input, expected := range tests {
+		s.Equal(expected, parseKey([]byte(input)), "key %q", input)
+	}
+}
+
+func (s *builtinPagerSuite) TestScreen() {
+	p, t := s.newPager("", 0, 0)
+	width, height := p.screen()
+	s.Equal(80, width)
+	s.Equal(24, height)
+
+	t.width, t.height = 100, 40
+	width, height = p.screen()
+	s.Equal(100, width)
+	s.Equal(39, height)
+}
+
+func (s *builtinPagerSuite) TestRows() {
+	p, _ := s.newPager("", 10, 10)
+	s.Equal(1, p.rows([]string{""}, 10))
+	s.Equal(1, p.rows([]string{"abc"}, 10))
+	s.Equal(1, p.rows([]string{"0123456789"}, 10))
+	s.Equal(2, p.rows([]string{"0123456789a"}, 10))
+	s.Equal(1, p.rows([]string{"\x1b[31m0123456789\x1b[0m"}, 10))
+	s.Equal(2, p.rows([]string{"héllo wörld"}, 10))
+	s.Equal(4, p.rows([]string{"", "abc", "0123456789a"}, 10))
+}
+
+func (s *builtinPagerSuite) TestScroll_Clamps() {
+	p, _ := s.newPager(numberedLines(100), 80, 11)
+	p.scroll(5)
+	s.Equal(5, p.top)
+	p.scroll(-10)
+	s.Equal(0, p.top)
+	p.scroll(1000)
+	s.Equal(90, p.top, "the last page stays full")
+	s.True(p.eof)
+}
+
+func (s *builtinPagerSuite) TestScroll_ShortInput() {
+	p, _ := s.newPager(numberedLines(5), 80, 11)
+	p.scroll(3)
+	s.Equal(0, p.top)
+}
+
+func (s *builtinPagerSuite) TestScroll_WrappedLastLine() {
+	p, _ := s.newPager(numberedLines(20)+strings.Repeat("x", 50)+"\n", 10, 11)
+	p.scroll(1000)
+	s.Equal(15, p.top, "the last line takes 5 rows")
+}
+
+func (s *builtinPagerSuite) TestPageLines() {
+	p, _ := s.newPager(numberedLines(100), 80, 11)
+	p.fill(100)
+	s.Equal(10, p.pageLines(80, 10))
+
+	p.lines[2] = strings.Repeat("x", 25)
+	s.Equal(8, p.pageLines(10, 10), "the wrapped line takes 3 rows")
+
+	p.lines[0] = strings.Repeat("x", 200)
+	s.Equal(1, p.pageLines(10, 10), "a line longer than the screen still moves the page")
+}
+
+func (s *builtinPagerSuite) TestDraw_End() {
+	p, t := s.newPager(numberedLines(3), 80, 6)
+	p.draw()
+	out := t.out.String()
+	s.True(strings.HasPrefix(out, clearScreen))
+	s.Contains(out, "line 0\x1b[0m\nline 1\x1b[0m\nline 2\x1b[0m\n")
+	s.Equal(2, strings.Count(out, "~\n"))
+	s.True(strings.HasSuffix(out, "(END)\x1b[0m"))
+}
+
+func (s *builtinPagerSuite) TestDraw_More() {
+	p, t := s.newPager(numberedLines(100), 80, 6)
+	p.draw()
+	out := t.out.String()
+	s.Contains(out, "line 4\x1b[0m\n")
+	s.NotContains(out, "line 5")
+	s.NotContains(out, "~\n")
+	s.True(strings.HasSuffix(out, ":\x1b[0m"))
+}
+
+func (s *builtinPagerSuite) TestFind() {
+	p, _ := s.newPager(numberedLines(100)+"\x1b[31mfoo\x1b[0m\n", 80, 11)
+	p.search = "line 42"
+	p.find(1)
+	s.Equal(42, p.top)
+	s.Empty(p.status)
+
+	p.find(1)
+	s.Equal(42, p.top)
+	s.Equal("Pattern not found", p.status)
+
+	p.status = ""
+	p.search = "foo"
+	p.find(1)
+	s.Equal(100, p.top)
+
+	p.search = "mfoo"
+	p.find(-1)
+	s.Equal(100, p.top, "the escape sequences do not match")
+	s.Equal("Pattern not found", p.status)
+}
+
+func (s *builtinPagerSuite) TestPrompt() {
+	p, t := s.newPager("", 80, 11, fakeKey{key: "ab"}, fakeKey{key: "c\x7f"}, fakeKey{key: "d\r"})
+	input, ok := p.prompt("/")
+	s.True(ok)
+	s.Equal("abd", input)
+	s.Contains(t.out.String(), clearLine+"/ab")
+
+	p, _ = s.newPager("", 80, 11, fakeKey{key: "ab\x1b"})
+	_, ok = p.prompt("/")
+	s.False(ok)
+
+	p, _ = s.newPager("", 80, 11)
+	_, ok = p.prompt("/")
+	s.False(ok)
+}
+
+func (s *builtinPagerSuite) TestRun_FitsOneScreen() {
+	p, t := s.newPager(numberedLines(3), 80, 11)
+	p.run()
+	s.Equal("line 0\nline 1\nline 2\n", t.out.String())
+	s.Empty(t.tops, "no keys are read")
+}
+
+func (s *builtinPagerSuite) TestRun_Keys() {
+	p, t := s.newPager(numberedLines(100), 80, 11,
+		fakeKey{key: " "},
+		fakeKey{key: "j"},
+		fakeKey{key: "k"},
+		fakeKey{key: "\x1b[6~"},
+		fakeKey{key: "\x1b[5~"},
+		fakeKey{key: "G"},
+		fakeKey{key: "g"},
+		fakeKey{key: "/"},
+		fakeKey{key: "line 50\r"},
+		fakeKey{key: "q"},
+	)
+	p.run()
+	s.Equal([]int{0, 10, 11, 10, 20, 10, 90, 0, 0, 50}, t.tops)
+	s.Equal(50, p.top)
+	s.Contains(t.out.String(), clearLine+"/")
+	s.True(strings.HasSuffix(t.out.String(), clearLine))
+}
+
+func (s *builtinPagerSuite) TestRun_Resize() {
+	p, t := s.newPager(numberedLines(100), 80, 11,
+		fakeKey{key: " "},
+		fakeKey{key: " ", width: 80, height: 6},
+		fakeKey{key: "G"},
+		fakeKey{key: "j", width: 80, height: 51},
+	)
+	p.run()
+	s.Equal([]int{0, 10, 15, 95, 50}, t.tops, "the page size and the last page follow the resizes")
+}
+
+func (s *builtinPagerSuite) TestRawMode_NotATerminal() {
+	f, err := os.CreateTemp("", "pager")
+	s.NoError(err)
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	s.False(IsTerminal(f))
+	_, err = MakeRaw(f)
+	s.Error(err)
+	width, height := TerminalSize(f)
+	s.Zero(width)
+	s.Zero(height)
+}
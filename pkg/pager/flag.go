@@ -27,6 +27,8 @@ package pager
 const (
 	FlagPager   = "pager"
 	FlagNoPager = "no-pager"
+
+	configKeyPager = "pager"
 )
 
 type PagerOption string
@@ -35,4 +37,6 @@ const (
 	Cat  PagerOption = "cat"
 	Less PagerOption = "less"
 	More PagerOption = "more"
+	// Builtin is the pager of tctl itself, used when neither less nor more is installed
+	Builtin PagerOption = "builtin"
 )
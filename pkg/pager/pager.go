@@ -30,9 +30,14 @@ import (
 	"os"
 	"os/exec"
 	"os/signal"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"github.com/urfave/cli/v2"
+
+	"github.com/temporalio/tctl/pkg/config"
 )
 
 const (
@@ -41,19 +46,20 @@ const (
 
 func NewPager(c *cli.Context, defaultPager string) (io.Writer, func()) {
 	noPager := c.Bool(FlagNoPager)
-	if noPager {
+	if noPager || !IsTerminal(os.Stdout) {
+		// piped or redirected output is written as is, a pager would wait for keys or add control characters
 		return os.Stdout, func() {}
 	}
 
 	pager, err := pickPager(c, defaultPager)
-	if err != nil {
-		return os.Stdout, func() {}
+	if err != nil || pager[0] == string(Builtin) {
+		return newBuiltinPager()
 	}
 
-	exe, _ := exec.LookPath(pager)
-	cmd := exec.Command(exe)
+	exe, _ := exec.LookPath(pager[0])
+	cmd := exec.Command(exe, pager[1:]...)
 
-	if pager == string(Less) {
+	if pagerName(pager) == Less {
 		env := os.Environ()
 		env = append(env, "LESS=FRX")
 		cmd.Env = env
@@ -80,25 +86,84 @@ func NewPager(c *cli.Context, defaultPager string) (io.Writer, func()) {
 	}
 }
 
-func pickPager(c *cli.Context, defaultPager string) (string, error) {
-	pagerFlag := c.String("pager")
-	if pagerFlag == "" {
-		pagerFlag = defaultPager
+// pickPager returns the pager command and its arguments: the one set with --pager or $TCTL_PAGER,
+// the pager config, ex. tctl config set pager 'less -RS', or $PAGER. Falls back to the default pager,
+// less, more and the builtin pager when the command is not installed
+func pickPager(c *cli.Context, defaultPager string) ([]string, error) {
+	candidates := []string{configuredPager(c), defaultPager, string(Less), string(More)}
+	for _, candidate := range candidates {
+		pager := strings.Fields(candidate)
+		if len(pager) == 0 {
+			continue
+		}
+		if pager[0] == string(Builtin) {
+			return pager, nil
+		}
+		if _, err := exec.LookPath(pager[0]); err == nil {
+			return pager, nil
+		}
 	}
 
-	if pagerFlag != "" {
-		if _, err := exec.LookPath(pagerFlag); err == nil {
-			return pagerFlag, nil
-		}
+	return nil, errors.New("no pager available. Set $TCTL_PAGER env variable or install 'less', 'more' or 'cat'")
+}
+
+// configuredPager returns the pager command set by the user, empty to use the default
+func configuredPager(c *cli.Context) string {
+	if c.IsSet(FlagPager) {
+		return c.String(FlagPager)
+	}
+	if configPager == nil {
+		pager, _ := config.Get(configKeyPager)
+		configPager = &pager
+	}
+	if *configPager != "" {
+		return *configPager
 	}
+	return os.Getenv("PAGER")
+}
+
+// configPager caches the pager config, consulted on every color check
+var configPager *string
 
-	if _, err := exec.LookPath(string(Less)); err == nil {
-		return string(Less), nil
+// pagerName returns the executable name of the pager command, ex. less for /usr/bin/less -RS
+func pagerName(pager []string) PagerOption {
+	if len(pager) == 0 {
+		return ""
 	}
+	return PagerOption(strings.TrimSuffix(filepath.Base(pager[0]), ".exe"))
+}
 
-	if _, err := exec.LookPath(string(More)); err == nil {
-		return string(More), nil
+// IsTerminal reports whether the file is attached to a terminal
+func IsTerminal(f *os.File) bool {
+	stat, err := f.Stat()
+	if err != nil {
+		return false
 	}
+	return stat.Mode()&os.ModeCharDevice != 0
+}
 
-	return "", errors.New("no pager available. Set $PAGER env variable or install 'less', 'more' or 'cat'")
+// TerminalWidth returns the number of columns of the terminal, as set by $COLUMNS or reported by the terminal.
+// Returns 0 when the file is not a terminal
+func TerminalWidth(f *os.File) int {
+	if !IsTerminal(f) {
+		return 0
+	}
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+	return terminalWidth(f)
+}
+
+// SupportsColor reports whether the pager set by the user passes the color codes through.
+// The default pagers do, less is started with -R
+func SupportsColor(c *cli.Context) bool {
+	pager := strings.Fields(configuredPager(c))
+	if c.Bool(FlagNoPager) || len(pager) == 0 {
+		return true
+	}
+	switch pagerName(pager) {
+	case Cat, Less, More, Builtin:
+		return true
+	}
+	return false
 }
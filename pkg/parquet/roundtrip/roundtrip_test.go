@@ -0,0 +1,229 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package roundtrip reads the files of the parquet writer back with github.com/xitongsys/parquet-go,
+// a reader independent of the library that writes them. It is a module of its own because the reader
+// requires a version of github.com/apache/thrift that is incompatible with the one required by the
+// Temporal server packages of tctl.
+package roundtrip
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	pq "github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+
+	"github.com/temporalio/tctl/pkg/parquet"
+)
+
+type roundTripSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestRoundTripSuite(t *testing.T) {
+	suite.Run(t, new(roundTripSuite))
+}
+
+func (s *roundTripSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+// write writes the batches of rows, each batch with its own columns
+func (s *roundTripSuite) write(rowGroupSize int, batches ...batch) []byte {
+	var buf bytes.Buffer
+	w, err := parquet.NewWriter(&buf)
+	s.NoError(err)
+	if rowGroupSize > 0 {
+		w.RowGroupSize = rowGroupSize
+	}
+	for _, b := range batches {
+		for _, row := range b.rows {
+			s.NoError(w.Write(b.names, row))
+		}
+	}
+	s.NoError(w.Close())
+	return buf.Bytes()
+}
+
+type batch struct {
+	names []string
+	rows  [][]interface{}
+}
+
+// read returns the schema elements of the file and the values of every column
+func (s *roundTripSuite) read(data []byte) ([]*pq.SchemaElement, [][]interface{}) {
+	file, err := buffer.NewBufferFile(data)
+	s.NoError(err)
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	s.NoError(err)
+	defer pr.ReadStop()
+
+	schema := pr.Footer.Schema
+	numRows := pr.GetNumRows()
+	var columns [][]interface{}
+	for i := range schema[1:] {
+		values, _, _, err := pr.ReadColumnByIndex(int64(i), numRows)
+		s.NoError(err)
+		columns = append(columns, values)
+	}
+	return schema, columns
+}
+
+func (s *roundTripSuite) TestTypes() {
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	data := s.write(0, batch{
+		names: []string{"WorkflowId", "HistoryLength", "Ratio", "Done", "StartTime"},
+		rows: [][]interface{}{
+			{"wid-1", int64(10), 0.5, true, start},
+			{"wid-2", nil, nil, false, nil},
+		},
+	})
+
+	schema, columns := s.read(data)
+	s.Len(schema, 6)
+	s.Equal(pq.Type_BYTE_ARRAY, schema[1].GetType())
+	s.Equal(pq.ConvertedType_UTF8, schema[1].GetConvertedType())
+	s.Equal(pq.Type_INT64, schema[2].GetType())
+	s.Equal(pq.Type_DOUBLE, schema[3].GetType())
+	s.Equal(pq.Type_BOOLEAN, schema[4].GetType())
+	s.Equal(pq.Type_INT64, schema[5].GetType())
+	s.Equal(pq.ConvertedType_TIMESTAMP_MILLIS, schema[5].GetConvertedType())
+	for _, el := range schema[1:] {
+		s.Equal(pq.FieldRepetitionType_OPTIONAL, el.GetRepetitionType())
+	}
+
+	s.Equal([]interface{}{"wid-1", "wid-2"}, columns[0])
+	s.Equal([]interface{}{int64(10), nil}, columns[1])
+	s.Equal([]interface{}{0.5, nil}, columns[2])
+	s.Equal([]interface{}{true, false}, columns[3])
+	s.Equal([]interface{}{start.UnixNano() / int64(time.Millisecond), nil}, columns[4])
+}
+
+func (s *roundTripSuite) TestLaterBatchesAddColumns() {
+	data := s.write(0,
+		batch{
+			names: []string{"WorkflowId", "Status"},
+			rows: [][]interface{}{
+				{"wid-1", "Running"},
+				{"wid-2", "Running"},
+			},
+		},
+		batch{
+			names: []string{"WorkflowId", "CloseTime", "Status", "HistoryLength"},
+			rows: [][]interface{}{
+				{"wid-3", "2021-06-01", "Completed", int64(12)},
+			},
+		},
+		batch{
+			names: []string{"HistoryLength", "WorkflowId"},
+			rows: [][]interface{}{
+				{int64(3), "wid-4"},
+			},
+		},
+	)
+
+	schema, columns := s.read(data)
+	var names []string
+	for _, el := range schema[1:] {
+		names = append(names, el.GetName())
+	}
+	s.Equal([]string{"WorkflowId", "Status", "CloseTime", "HistoryLength"}, names)
+	s.Equal(pq.Type_INT64, schema[4].GetType())
+
+	s.Equal([]interface{}{"wid-1", "wid-2", "wid-3", "wid-4"}, columns[0])
+	s.Equal([]interface{}{"Running", "Running", "Completed", nil}, columns[1])
+	s.Equal([]interface{}{nil, nil, "2021-06-01", nil}, columns[2])
+	s.Equal([]interface{}{nil, nil, int64(12), int64(3)}, columns[3])
+}
+
+func (s *roundTripSuite) TestLaterBatchesTypeEmptyColumns() {
+	data := s.write(0,
+		batch{names: []string{"WorkflowId", "HistoryLength"}, rows: [][]interface{}{{"wid-1", nil}}},
+		batch{names: []string{"WorkflowId", "HistoryLength"}, rows: [][]interface{}{{"wid-2", int64(7)}}},
+	)
+
+	schema, columns := s.read(data)
+	s.Equal(pq.Type_INT64, schema[2].GetType())
+	s.Equal([]interface{}{nil, int64(7)}, columns[1])
+}
+
+func (s *roundTripSuite) TestMixedTypesAsString() {
+	data := s.write(0,
+		batch{names: []string{"Value"}, rows: [][]interface{}{{int64(1)}}},
+		batch{names: []string{"Value"}, rows: [][]interface{}{{"two"}, {true}}},
+	)
+
+	schema, columns := s.read(data)
+	s.Equal(pq.Type_BYTE_ARRAY, schema[1].GetType())
+	s.Equal([]interface{}{"1", "two", "true"}, columns[0])
+}
+
+func (s *roundTripSuite) TestRowGroups() {
+	var rows [][]interface{}
+	var ids []interface{}
+	for i := int64(0); i < 100; i++ {
+		rows = append(rows, []interface{}{i, i%3 == 0})
+		ids = append(ids, i)
+	}
+	data := s.write(64, batch{names: []string{"Id", "Even"}, rows: rows})
+
+	file, err := buffer.NewBufferFile(data)
+	s.NoError(err)
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	s.NoError(err)
+	defer pr.ReadStop()
+	s.Greater(len(pr.Footer.RowGroups), 1)
+	s.Equal(int64(100), pr.GetNumRows())
+
+	values, _, _, err := pr.ReadColumnByIndex(0, 100)
+	s.NoError(err)
+	s.Equal(ids, values)
+	values, _, _, err = pr.ReadColumnByIndex(1, 100)
+	s.NoError(err)
+	s.Len(values, 100)
+	s.Equal(true, values[99])
+	s.Equal(false, values[98])
+}
+
+func (s *roundTripSuite) TestEmpty() {
+	var buf bytes.Buffer
+	w, err := parquet.NewWriter(&buf)
+	s.NoError(err)
+	w.AddColumns([]string{"WorkflowId"})
+	s.NoError(w.Close())
+	data := buf.Bytes()
+
+	file, err := buffer.NewBufferFile(data)
+	s.NoError(err)
+	pr, err := reader.NewParquetColumnReader(file, 1)
+	s.NoError(err)
+	defer pr.ReadStop()
+	s.Equal(int64(0), pr.GetNumRows())
+}
@@ -0,0 +1,335 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package parquet writes rows of any columns as Apache Parquet files with flat schemas of optional columns,
+// encoded by github.com/parquet-go/parquet-go. The schema covers the columns of all the written rows, so the
+// rows are spooled to a temp file and encoded on Close, once every column is known.
+package parquet
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/encoding"
+)
+
+const (
+	DefaultRowGroupSize = 16 * 1024 * 1024
+
+	createdBy = "tctl"
+)
+
+type Type int
+
+const (
+	// Unknown is the type of a column without values so far, written as String
+	Unknown Type = iota
+	String
+	Int64
+	Double
+	Boolean
+	Timestamp // milliseconds since the Unix epoch
+)
+
+type Column struct {
+	Name string
+	Type Type
+}
+
+// Writer collects rows of any columns and writes them as one parquet file on Close
+type Writer struct {
+	// RowGroupSize is the size of the buffered values at which a row group is written
+	RowGroupSize int
+
+	w       io.Writer
+	columns []Column
+	index   map[string]int
+	spool   *os.File
+	enc     *gob.Encoder
+	rows    int
+}
+
+func init() {
+	// spooled as an interface value
+	gob.Register(time.Time{})
+}
+
+// NewWriter creates a writer of a parquet file to w
+func NewWriter(w io.Writer) (*Writer, error) {
+	spool, err := ioutil.TempFile("", "tctl-parquet-")
+	if err != nil {
+		return nil, err
+	}
+	return &Writer{
+		RowGroupSize: DefaultRowGroupSize,
+		w:            w,
+		index:        map[string]int{},
+		spool:        spool,
+		enc:          gob.NewEncoder(spool),
+	}, nil
+}
+
+// Columns returns the columns written so far, in the order of their first appearance
+func (pw *Writer) Columns() []Column {
+	return pw.columns
+}
+
+// AddColumns appends the columns not written so far to the schema, ex. for a file without rows
+func (pw *Writer) AddColumns(names []string) {
+	for _, name := range names {
+		pw.column(name)
+	}
+}
+
+func (pw *Writer) column(name string) int {
+	col, ok := pw.index[name]
+	if !ok {
+		col = len(pw.columns)
+		pw.index[name] = col
+		pw.columns = append(pw.columns, Column{Name: name})
+	}
+	return col
+}
+
+// Write appends a row. The names are the columns of the values: new ones are appended to the schema and are
+// empty in the former rows. Values are nil or one of string, int64, float64, bool and time.Time. A column
+// given values of different types is written as String
+func (pw *Writer) Write(names []string, values []interface{}) error {
+	if len(names) != len(values) {
+		return fmt.Errorf("row has %d values for %d columns", len(values), len(names))
+	}
+
+	row := make([]interface{}, len(pw.columns))
+	for i, name := range names {
+		typ, err := typeOf(values[i])
+		if err != nil {
+			return fmt.Errorf("column %s: %w", name, err)
+		}
+		col := pw.column(name)
+		if col == len(row) {
+			row = append(row, nil)
+		}
+		switch current := pw.columns[col].Type; {
+		case typ == Unknown || typ == current:
+		case current == Unknown:
+			pw.columns[col].Type = typ
+		default:
+			pw.columns[col].Type = String
+		}
+		row[col] = values[i]
+	}
+
+	if err := pw.enc.Encode(row); err != nil {
+		return err
+	}
+	pw.rows++
+	return nil
+}
+
+// Close encodes the spooled rows and writes the footer. It does not close the underlying writer
+func (pw *Writer) Close() error {
+	defer pw.Abort()
+
+	if len(pw.columns) == 0 {
+		return fmt.Errorf("no columns to write")
+	}
+	if _, err := pw.spool.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	w := parquet.NewWriter(pw.w, parquet.NewSchema("tctl", newColumnGroup(pw.columns)),
+		parquet.CreatedBy(createdBy, "", ""),
+		parquet.Compression(&parquet.Snappy),
+	)
+
+	dec := gob.NewDecoder(pw.spool)
+	size := 0
+	for i := 0; i < pw.rows; i++ {
+		var values []interface{}
+		if err := dec.Decode(&values); err != nil {
+			return err
+		}
+		row := make(parquet.Row, len(pw.columns))
+		for col := range row {
+			var value interface{}
+			if col < len(values) {
+				value = pw.columns[col].Type.convert(values[col])
+			}
+			row[col] = valueOf(value).Level(0, definitionLevel(value), col)
+			size += sizeOf(value)
+		}
+		if _, err := w.WriteRows([]parquet.Row{row}); err != nil {
+			return err
+		}
+		if size >= pw.RowGroupSize {
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			size = 0
+		}
+	}
+	return w.Close()
+}
+
+// Abort removes the spooled rows without writing them
+func (pw *Writer) Abort() {
+	if pw.spool == nil {
+		return
+	}
+	pw.spool.Close()
+	os.Remove(pw.spool.Name())
+	pw.spool = nil
+}
+
+func typeOf(value interface{}) (Type, error) {
+	switch value.(type) {
+	case nil:
+		return Unknown, nil
+	case string:
+		return String, nil
+	case int64:
+		return Int64, nil
+	case float64:
+		return Double, nil
+	case bool:
+		return Boolean, nil
+	case time.Time:
+		return Timestamp, nil
+	}
+	return Unknown, fmt.Errorf("unsupported value type %T", value)
+}
+
+// convert turns the values of the columns written as String because of their mixed types into strings
+func (typ Type) convert(value interface{}) interface{} {
+	if value == nil || (typ != String && typ != Unknown) {
+		return value
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
+
+// node is the parquet type of the column values
+func (typ Type) node() parquet.Node {
+	switch typ {
+	case Int64:
+		return parquet.Int(64)
+	case Double:
+		return parquet.Leaf(parquet.DoubleType)
+	case Boolean:
+		return parquet.Leaf(parquet.BooleanType)
+	case Timestamp:
+		return parquet.Timestamp(parquet.Millisecond)
+	}
+	return parquet.String()
+}
+
+// valueOf converts a value of the spooled rows, nil or one of string, int64, float64, bool and time.Time
+func valueOf(value interface{}) parquet.Value {
+	switch v := value.(type) {
+	case string:
+		return parquet.ByteArrayValue([]byte(v))
+	case int64:
+		return parquet.Int64Value(v)
+	case float64:
+		return parquet.DoubleValue(v)
+	case bool:
+		return parquet.BooleanValue(v)
+	case time.Time:
+		return parquet.Int64Value(v.UnixNano() / int64(time.Millisecond))
+	}
+	return parquet.NullValue()
+}
+
+// sizeOf is the encoded size of the value, that bounds the size of the row groups
+func sizeOf(value interface{}) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case string:
+		return len(v)
+	case bool:
+		return 1
+	}
+	return 8
+}
+
+// definitionLevel tells whether the value of an optional column is set
+func definitionLevel(value interface{}) int {
+	if value == nil {
+		return 0
+	}
+	return 1
+}
+
+// columnGroup is the root of the schema. Unlike parquet.Group, which orders the columns by name, it keeps
+// the columns in the order of their first appearance
+type columnGroup []parquet.Field
+
+type columnField struct {
+	parquet.Node
+	name string
+}
+
+func newColumnGroup(columns []Column) columnGroup {
+	g := make(columnGroup, len(columns))
+	for i, col := range columns {
+		g[i] = columnField{Node: parquet.Optional(col.Type.node()), name: col.Name}
+	}
+	return g
+}
+
+func (g columnGroup) group() parquet.Group {
+	group := make(parquet.Group, len(g))
+	for _, f := range g {
+		group[f.Name()] = f
+	}
+	return group
+}
+
+func (g columnGroup) ID() int                     { return 0 }
+func (g columnGroup) String() string              { return g.group().String() }
+func (g columnGroup) Type() parquet.Type          { return g.group().Type() }
+func (g columnGroup) Optional() bool              { return false }
+func (g columnGroup) Repeated() bool              { return false }
+func (g columnGroup) Required() bool              { return true }
+func (g columnGroup) Leaf() bool                  { return false }
+func (g columnGroup) Fields() []parquet.Field     { return g }
+func (g columnGroup) Encoding() encoding.Encoding { return nil }
+func (g columnGroup) Compression() compress.Codec { return nil }
+func (g columnGroup) GoType() reflect.Type        { return g.group().GoType() }
+func (f columnField) Name() string                { return f.name }
+func (f columnField) Value(base reflect.Value) reflect.Value {
+	return base.MapIndex(reflect.ValueOf(f.name))
+}
@@ -0,0 +1,144 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package parquet
+
+import (
+	"bytes"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+// The files are also read back with github.com/xitongsys/parquet-go by the tests of the roundtrip module
+
+type writerSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestWriterSuite(t *testing.T) {
+	suite.Run(t, new(writerSuite))
+}
+
+func (s *writerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *writerSuite) TestColumns() {
+	w, err := NewWriter(&bytes.Buffer{})
+	s.NoError(err)
+	defer w.Abort()
+
+	s.NoError(w.Write([]string{"WorkflowId", "HistoryLength", "Value"}, []interface{}{"wid-1", nil, int64(1)}))
+	s.NoError(w.Write([]string{"StartTime", "HistoryLength", "Value"}, []interface{}{time.Now(), int64(2), "two"}))
+	s.Equal([]Column{
+		{Name: "WorkflowId", Type: String},
+		{Name: "HistoryLength", Type: Int64},
+		{Name: "Value", Type: String},
+		{Name: "StartTime", Type: Timestamp},
+	}, w.Columns())
+}
+
+func (s *writerSuite) TestWrite_Invalid() {
+	w, err := NewWriter(&bytes.Buffer{})
+	s.NoError(err)
+	defer w.Abort()
+
+	s.Error(w.Write([]string{"WorkflowId"}, []interface{}{"wid-1", "extra"}))
+	s.Error(w.Write([]string{"Size"}, []interface{}{int32(1)}))
+}
+
+func (s *writerSuite) TestClose_NoColumns() {
+	w, err := NewWriter(&bytes.Buffer{})
+	s.NoError(err)
+	s.Error(w.Close())
+
+	w, err = NewWriter(&bytes.Buffer{})
+	s.NoError(err)
+	w.AddColumns([]string{"WorkflowId"})
+	s.NoError(w.Close())
+	s.Equal([]Column{{Name: "WorkflowId", Type: Unknown}}, w.Columns())
+}
+
+func (s *writerSuite) TestClose_RemovesSpool() {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	s.NoError(err)
+	spool := w.spool.Name()
+
+	s.NoError(w.Write([]string{"WorkflowId"}, []interface{}{"wid-1"}))
+	s.NoError(w.Close())
+	_, err = os.Stat(spool)
+	s.True(os.IsNotExist(err))
+	_, err = parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	s.NoError(err)
+}
+
+func (s *writerSuite) TestClose_ReadBack() {
+	start := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf)
+	s.NoError(err)
+	w.RowGroupSize = 16
+	s.NoError(w.Write([]string{"WorkflowId", "HistoryLength", "Ratio"}, []interface{}{"wid-1", int64(10), 0.5}))
+	s.NoError(w.Write([]string{"WorkflowId", "Done", "StartTime"}, []interface{}{"wid-2", true, start}))
+	s.NoError(w.Close())
+
+	file, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	s.NoError(err)
+	s.Equal(int64(2), file.NumRows())
+	s.Len(file.RowGroups(), 2)
+
+	var names []string
+	for _, f := range file.Schema().Fields() {
+		s.True(f.Optional())
+		names = append(names, f.Name())
+	}
+	s.Equal([]string{"WorkflowId", "HistoryLength", "Ratio", "Done", "StartTime"}, names)
+
+	var rows []parquet.Row
+	for _, rg := range file.RowGroups() {
+		r := rg.Rows()
+		batch := make([]parquet.Row, rg.NumRows())
+		n, _ := r.ReadRows(batch)
+		for _, row := range batch[:n] {
+			// the values refer to the pages of the reader
+			rows = append(rows, row.Clone())
+		}
+		s.NoError(r.Close())
+	}
+	s.Len(rows, 2)
+	s.Equal("wid-1", rows[0][0].String())
+	s.Equal(int64(10), rows[0][1].Int64())
+	s.Equal(0.5, rows[0][2].Double())
+	s.True(rows[0][3].IsNull())
+	s.True(rows[1][1].IsNull())
+	s.True(rows[1][3].Boolean())
+	s.Equal(start.UnixNano()/int64(time.Millisecond), rows[1][4].Int64())
+}
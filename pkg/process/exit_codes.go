@@ -0,0 +1,104 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package process
+
+import (
+	"errors"
+
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Exit codes of tctl, so that scripts can branch on the class of a failure
+const (
+	ExitCodeOK                = 0
+	ExitCodeError             = 1 // any failure not listed below
+	ExitCodeInvalidArgument   = 2 // missing or malformed options, rejected requests
+	ExitCodeNotFound          = 3 // the workflow, namespace or other entity does not exist
+	ExitCodeAlreadyExists     = 4 // ex. the workflow is already started
+	ExitCodePermissionDenied  = 5 // the caller is not authenticated or authorized
+	ExitCodeConnectionFailure = 6 // the server is unreachable or did not respond in time
+	ExitCodeWorkflowFailed    = 7 // an awaited workflow failed, timed out, was canceled or terminated
+	ExitCodeQueryRejected     = 8 // the query was rejected by its reject condition
+	ExitCodeTimeout           = 9 // the --timeout of a wait expired before the workflow closed or met the condition
+)
+
+// ExitCode returns the exit code for the error. A missing error is a failure of an unknown class,
+// invalid user input is reported with UsageErrorAndExit instead
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeError
+	}
+
+	switch errorCode(err) {
+	case codes.InvalidArgument:
+		return ExitCodeInvalidArgument
+	case codes.NotFound:
+		return ExitCodeNotFound
+	case codes.AlreadyExists:
+		return ExitCodeAlreadyExists
+	case codes.PermissionDenied, codes.Unauthenticated:
+		return ExitCodePermissionDenied
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return ExitCodeConnectionFailure
+	default:
+		return ExitCodeError
+	}
+}
+
+// UsageErrorAndExit prints the message of invalid user input and exits with ExitCodeInvalidArgument
+func UsageErrorAndExit(msg string) {
+	if jsonErrors {
+		printJSON(&jsonError{Code: codes.InvalidArgument.String(), Message: msg})
+	} else {
+		printError(msg, nil)
+	}
+	ExitOnError(ExitCodeInvalidArgument)
+}
+
+// TimeoutErrorAndExit prints the message of an expired --timeout of a wait and exits with ExitCodeTimeout,
+// which tells it apart from a server that did not respond in time
+func TimeoutErrorAndExit(msg string) {
+	if jsonErrors {
+		printJSON(&jsonError{Code: codes.DeadlineExceeded.String(), Message: msg})
+	} else {
+		printError(msg, nil)
+	}
+	ExitOnError(ExitCodeTimeout)
+}
+
+// errorCode returns the gRPC code of the error, looking through wrapped errors for a service error
+func errorCode(err error) codes.Code {
+	var svcErr serviceerror.ServiceError
+	if errors.As(err, &svcErr) {
+		return svcErr.Status().Code()
+	}
+	// errors of the clients that are not wrapped into service errors, ex. the health client
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) {
+		return grpcErr.GRPCStatus().Code()
+	}
+	return serviceerror.ToStatus(err).Code()
+}
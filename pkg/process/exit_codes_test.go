@@ -0,0 +1,77 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package process
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/api/serviceerror"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type exitCodesSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestExitCodesSuite(t *testing.T) {
+	suite.Run(t, new(exitCodesSuite))
+}
+
+func (s *exitCodesSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *exitCodesSuite) TestExitCode() {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"nil", nil, ExitCodeError},
+		{"plain", errors.New("failed"), ExitCodeError},
+		{"invalid argument", serviceerror.NewInvalidArgument("bad"), ExitCodeInvalidArgument},
+		{"not found", serviceerror.NewNotFound("missing"), ExitCodeNotFound},
+		{"already started", serviceerror.NewWorkflowExecutionAlreadyStarted("started", "", ""), ExitCodeAlreadyExists},
+		{"permission denied", serviceerror.NewPermissionDenied("denied", ""), ExitCodePermissionDenied},
+		{"unauthenticated", status.Error(codes.Unauthenticated, "who"), ExitCodePermissionDenied},
+		{"unavailable", serviceerror.NewUnavailable("down"), ExitCodeConnectionFailure},
+		{"deadline exceeded", serviceerror.NewDeadlineExceeded("slow"), ExitCodeConnectionFailure},
+		{"context deadline", context.DeadlineExceeded, ExitCodeConnectionFailure},
+		{"grpc status", status.Error(codes.Unavailable, "down"), ExitCodeConnectionFailure},
+		{"wrapped", fmt.Errorf("describe: %w", serviceerror.NewNotFound("missing")), ExitCodeNotFound},
+		{"wrapped grpc status", fmt.Errorf("health: %w", status.Error(codes.PermissionDenied, "denied")), ExitCodePermissionDenied},
+		{"internal", serviceerror.NewInternal("boom"), ExitCodeError},
+	}
+	for _, tt := range tests {
+		s.Equal(tt.code, ExitCode(tt.err), tt.name)
+	}
+}
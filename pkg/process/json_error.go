@@ -0,0 +1,105 @@
+// The MIT License
+//
+// Copyright (c) 2020 Temporal Technologies Inc.  All rights reserved.
+//
+// Copyright (c) 2020 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+package process
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gogo/protobuf/proto"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/server/common/codec"
+)
+
+// jsonErrors is set when the command prints json, so that errors are machine readable too
+var jsonErrors bool
+
+// SetJSONErrors makes ErrorAndExit print errors as a JSON object on stderr
+func SetJSONErrors(enabled bool) {
+	jsonErrors = enabled
+}
+
+// JSONErrors tells whether errors are printed as JSON
+func JSONErrors() bool {
+	return jsonErrors
+}
+
+type jsonError struct {
+	Code          string             `json:"code"`
+	Message       string             `json:"message"`
+	Details       string             `json:"details,omitempty"`
+	ServerFailure *jsonServerFailure `json:"serverFailure,omitempty"`
+}
+
+// jsonServerFailure is the typed failure attached by the server to the error, ex. NotFoundFailure
+type jsonServerFailure struct {
+	Type    string          `json:"type"`
+	Details json.RawMessage `json:"details,omitempty"`
+}
+
+func printJSONError(msg string, err error) {
+	printJSON(newJSONError(msg, err))
+}
+
+func printJSON(e *jsonError) {
+	b, mErr := json.Marshal(e)
+	if mErr != nil {
+		b = []byte(fmt.Sprintf(`{"code":%q,"message":%q}`, e.Code, e.Message))
+	}
+	fmt.Fprintln(os.Stderr, string(b))
+}
+
+func newJSONError(msg string, err error) *jsonError {
+	e := &jsonError{Code: "Unknown", Message: msg}
+	if err == nil {
+		return e
+	}
+
+	if msg == "" {
+		e.Message = err.Error()
+	} else {
+		e.Details = err.Error()
+	}
+
+	e.Code = errorCode(err).String()
+	var svcErr serviceerror.ServiceError
+	if !errors.As(err, &svcErr) {
+		return e
+	}
+
+	for _, d := range svcErr.Status().Details() {
+		m, ok := d.(proto.Message)
+		if !ok {
+			continue
+		}
+		e.ServerFailure = &jsonServerFailure{Type: proto.MessageName(m)}
+		if b, err := codec.NewJSONPBEncoder().Encode(m); err == nil {
+			e.ServerFailure.Details = b
+		}
+		break
+	}
+	return e
+}
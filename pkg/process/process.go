@@ -37,6 +37,10 @@ const (
 )
 
 func printError(msg string, err error) {
+	if jsonErrors {
+		printJSONError(msg, err)
+		return
+	}
 	if err != nil {
 		fmt.Printf("%s %s\n%s %+v\n", color.RedString("Error:"), msg, color.MagentaString("Error Details:"), err)
 		if os.Getenv(showErrorStackEnv) != `` {
@@ -50,8 +54,46 @@ func printError(msg string, err error) {
 	}
 }
 
+// exitHooks are run once before tctl exits, ex. to move the --output-file into place, or to remove
+// its temp file when the command failed
+var exitHooks []func(failed bool) error
+
+// OnExit adds the hook run by RunExitHooks
+func OnExit(hook func(failed bool) error) {
+	exitHooks = append(exitHooks, hook)
+}
+
+// RunExitHooks runs the exit hooks added so far and returns the first error
+func RunExitHooks(failed bool) error {
+	hooks := exitHooks
+	exitHooks = nil
+	var first error
+	for _, hook := range hooks {
+		if err := hook(failed); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Exit runs the exit hooks and exits with the code, for the commands that exit with the status of
+// what they printed, ex. the failed workflow of workflow result
+func Exit(code int) {
+	if err := RunExitHooks(false); err != nil {
+		ErrorAndExit("", err)
+	}
+	os.Exit(code)
+}
+
+// ExitOnError runs the exit hooks of a failed command, which discard its partial output, and exits
+// with the code
+func ExitOnError(code int) {
+	_ = RunExitHooks(true)
+	os.Exit(code)
+}
+
 // ErrorAndExit print easy to understand error msg first then error detail in a new line
 func ErrorAndExit(msg string, err error) {
 	printError(msg, err)
-	os.Exit(1)
+	ExitOnError(ExitCode(err))
 }